@@ -12,6 +12,8 @@ import (
 
 var nilCleanupRegex = regexp.MustCompile(`\w+?="nil"`)
 
+var masteryEffectRegex = regexp.MustCompile(`\{(\d+),(\d+)\}`)
+
 var pobGemIDtoGameGemIDs = map[string]string{
 	"Metadata/Items/Gems/Smite":                   "Metadata/Items/Gems/SkillGemSmite",
 	"Metadata/Items/Gems/ConsecratedPath":         "Metadata/Items/Gems/SkillGemConsecratedPath",
@@ -58,5 +60,21 @@ func ParseBuild(rawXML []byte) (*pob.PathOfBuilding, error) {
 		build.Build.PassiveNodes = append(build.Build.PassiveNodes, num)
 	}
 
+	// Mastery effects are stored as {node,effect} pairs
+	build.Build.MasteryEffects = make(map[int64]int64)
+	for _, match := range masteryEffectRegex.FindAllStringSubmatch(spec.MasteryEffects, -1) {
+		nodeID, err := strconv.ParseInt(match[1], 10, 64)
+		if err != nil {
+			return nil, fmt.Errorf("spec has an invalid mastery node: %s", match[0])
+		}
+
+		effect, err := strconv.ParseInt(match[2], 10, 64)
+		if err != nil {
+			return nil, fmt.Errorf("spec has an invalid mastery effect: %s", match[0])
+		}
+
+		build.Build.MasteryEffects[nodeID] = effect
+	}
+
 	return &build, nil
 }
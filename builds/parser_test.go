@@ -14,3 +14,13 @@ func TestParseBuild(t *testing.T) {
 	_, err = ParseBuild(file)
 	testza.AssertNoError(t, err)
 }
+
+func TestParseBuildMasteryEffects(t *testing.T) {
+	file, err := os.ReadFile("../testdata/builds/Fireball-full.xml")
+	testza.AssertNoError(t, err)
+
+	build, err := ParseBuild(file)
+	testza.AssertNoError(t, err)
+
+	testza.AssertEqual(t, map[int64]int64{12382: 47642, 5348: 30502}, build.Build.MasteryEffects)
+}
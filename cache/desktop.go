@@ -61,6 +61,8 @@ func (d desktopCache) Get(key string) ([]byte, error) {
 		return nil, nil
 	}
 
+	defer r.Close()
+
 	b, err := io.ReadAll(r)
 	if err != nil {
 		return nil, fmt.Errorf("failed to read from cache: %w", err)
@@ -80,11 +82,15 @@ func (d desktopCache) Set(key string, value []byte) error {
 
 	_ = cache.Remove(key)
 
-	_, w, err := cache.Get(key)
+	r, w, err := cache.Get(key)
 	if err != nil {
 		return fmt.Errorf("failed to set key on cache: %s: %w", key, err)
 	}
 
+	if r != nil {
+		defer r.Close()
+	}
+
 	if w == nil {
 		return fmt.Errorf("could not write to cache")
 	}
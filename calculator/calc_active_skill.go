@@ -1,6 +1,8 @@
 package calculator
 
 import (
+	"slices"
+
 	"github.com/Vilsol/go-pob-data/poe"
 	"github.com/Vilsol/go-pob/data"
 	raw2 "github.com/Vilsol/go-pob/data/raw"
@@ -24,12 +26,9 @@ func CreateActiveSkill(activeEffect *GemEffect, supportList []*GemEffect, actor
 
 	activeSkill.SkillTypes = utils.CopyMap(activeEffect.GrantedEffect.SkillTypes)
 
-	/*
-		TODO -- Initialise skill types
-		if activeGrantedEffect.minionSkillTypes then
-			activeSkill.minionSkillTypes = copyTable(activeGrantedEffect.minionSkillTypes)
-		end
-	*/
+	if activeEffect.GrantedEffect.MinionSkillTypes != nil {
+		activeSkill.MinionSkillTypes = utils.CopyMap(activeEffect.GrantedEffect.MinionSkillTypes)
+	}
 
 	activeSkill.SkillFlags = utils.CopyMap(activeEffect.GrantedEffect.BaseFlags)
 	activeSkill.SkillFlags[SkillFlagHit] = activeSkill.SkillFlags[SkillFlagHit] || activeSkill.SkillTypes[data.SkillTypeAttack] || activeSkill.SkillTypes[data.SkillTypeDamage] || activeSkill.SkillTypes[data.SkillTypeProjectile]
@@ -92,7 +91,9 @@ func CalcMergeSkillInstanceMods(env *Environment, modList *moddb.ModList, skillE
 		}
 	}
 
-	// TODO modList:AddList(grantedEffect.baseMods)
+	for _, m := range raw2.SkillBaseMods[grantedEffect.Raw.ID] {
+		modList.AddMod(m)
+	}
 }
 
 // mergeLevelMod Merges level modifier with given mod list
@@ -150,27 +151,26 @@ func CalcBuildActiveSkillModList(env *Environment, activeSkill *ActiveSkill) {
 
 	// Handle multipart skills
 	activeGemParts := activeGrantedEffect.Parts
-	if activeGemParts != nil {
-		/*
-			TODO Handle multipart skills
-			if env.mode == "CALCS" and activeSkill == env.player.mainSkill then
-				activeEffect.srcInstance.skillPartCalcs = m_min(#activeGemParts, activeEffect.srcInstance.skillPartCalcs or 1)
-				activeSkill.skillPart = activeEffect.srcInstance.skillPartCalcs
-			else
-				activeEffect.srcInstance.skillPart = m_min(#activeGemParts, activeEffect.srcInstance.skillPart or 1)
-				activeSkill.skillPart = activeEffect.srcInstance.skillPart
-			end
-			local part = activeGemParts[activeSkill.skillPart]
-			for k, v in pairs(part) do
-				if v == true then
-					skillFlags[k] = true
-				elseif v == false then
-					skillFlags[k] = nil
-				end
-			end
-			activeSkill.skillPartName = part.name
-			skillFlags.multiPart = #activeGemParts > 1
-		*/
+	if len(activeGemParts) > 0 && activeEffect.SrcInstance != nil {
+		if env.Mode == OutputModeCalcs && activeSkill == env.Player.MainSkill {
+			activeEffect.SrcInstance.SkillPartCalcs = min(len(activeGemParts), max(activeEffect.SrcInstance.SkillPartCalcs, 1))
+			activeSkill.SkillPart = activeEffect.SrcInstance.SkillPartCalcs
+		} else {
+			activeEffect.SrcInstance.SkillPart = min(len(activeGemParts), max(activeEffect.SrcInstance.SkillPart, 1))
+			activeSkill.SkillPart = activeEffect.SrcInstance.SkillPart
+		}
+
+		part := activeGemParts[activeSkill.SkillPart-1]
+		for flag, enabled := range part.Flags {
+			if enabled {
+				skillFlags[SkillFlag(flag)] = true
+			} else {
+				delete(skillFlags, SkillFlag(flag))
+			}
+		}
+
+		activeSkill.SkillPartName = part.Name
+		skillFlags[SkillFlagMultiPart] = len(activeGemParts) > 1
 	}
 	/*
 		TODO Shield Attacks
@@ -228,7 +228,7 @@ func CalcBuildActiveSkillModList(env *Environment, activeSkill *ActiveSkill) {
 			} else if skillTypes[data.SkillTypeDualWieldOnly] || weapon2Info != nil {
 				// Skill requires a compatible off hand weapon
 				skillFlags[SkillFlagDisable] = true
-				if activeSkill.DisableReason != "" {
+				if activeSkill.DisableReason == "" {
 					activeSkill.DisableReason = "Off Hand weapon is not usable with this skill"
 				}
 			} else if skillFlags[SkillFlagDisable] {
@@ -366,16 +366,16 @@ func CalcBuildActiveSkillModList(env *Environment, activeSkill *ActiveSkill) {
 		Flags:        utils.Ptr(skillModFlags | activeSkill.Weapon1Flags | activeSkill.Weapon2Flags),
 		KeywordFlags: utils.Ptr(skillKeywordFlags),
 		SkillCond:    make(map[string]bool),
+		SkillPart:    activeSkill.SkillPart,
+		SkillGem:     newSkillGem(activeEffect.GemData),
+		SlotName:     activeSkill.SlotName,
 		/*
 			TODO
 			skillName = activeGrantedEffect.name:gsub("^Vaal ",""):gsub("Summon Skeletons","Summon Skeleton"), -- This allows modifiers that target specific skills to also apply to their Vaal counterpart
 			summonSkillName = activeSkill.summonSkill and activeSkill.summonSkill.activeEffect.grantedEffect.name,
-			skillGem = activeEffect.gemData,
 			skillGrantedEffect = activeGrantedEffect,
-			skillPart = activeSkill.skillPart,
 			skillTypes = activeSkill.skillTypes,
 			skillDist = env.mode_effective and effectiveRange,
-			slotName = activeSkill.slotName,
 		*/
 	}
 
@@ -389,18 +389,24 @@ func CalcBuildActiveSkillModList(env *Environment, activeSkill *ActiveSkill) {
 			Source:       activeSkill.SkillCfg.Source,
 			SkillStats:   activeSkill.SkillCfg.SkillStats,
 			SkillCond:    cond,
+			SkillPart:    activeSkill.SkillCfg.SkillPart,
+			SkillGem:     activeSkill.SkillCfg.SkillGem,
+			SlotName:     activeSkill.SkillCfg.SlotName,
 		}
 	}
 
 	if skillFlags[SkillFlagWeapon2Attack] {
 		cond := utils.CopyMap(activeSkill.SkillCfg.SkillCond)
 		cond["OffHandAttack"] = true
-		activeSkill.Weapon1Cfg = &moddb.ListCfg{
+		activeSkill.Weapon2Cfg = &moddb.ListCfg{
 			Flags:        utils.Ptr(skillModFlags | activeSkill.Weapon2Flags),
 			KeywordFlags: activeSkill.SkillCfg.KeywordFlags,
 			Source:       activeSkill.SkillCfg.Source,
 			SkillStats:   activeSkill.SkillCfg.SkillStats,
 			SkillCond:    cond,
+			SkillPart:    activeSkill.SkillCfg.SkillPart,
+			SkillGem:     activeSkill.SkillCfg.SkillGem,
+			SlotName:     activeSkill.SkillCfg.SlotName,
 		}
 	}
 
@@ -525,10 +531,10 @@ func CalcBuildActiveSkillModList(env *Environment, activeSkill *ActiveSkill) {
 	*/
 
 	// Extract skill data
-	for _, value := range utils.CastSlice[*mod.SkillData](env.ModDB.List(activeSkill.SkillCfg, "SkillData")) {
+	for _, value := range skillDataList(env.ModDB.List(activeSkill.SkillCfg, "SkillData")) {
 		activeSkill.SkillData[value.Key] = value.Value
 	}
-	for _, value := range utils.CastSlice[*mod.SkillData](skillModList.List(activeSkill.SkillCfg, "SkillData")) {
+	for _, value := range skillDataList(skillModList.List(activeSkill.SkillCfg, "SkillData")) {
 		activeSkill.SkillData[value.Key] = value.Value
 	}
 
@@ -709,6 +715,20 @@ func CalcBuildActiveSkillModList(env *Environment, activeSkill *ActiveSkill) {
 	*/
 }
 
+// skillDataList returns the values of SkillData mods, which are pointers for gem stats and values for parsed mods
+func skillDataList(values []interface{}) []*mod.SkillData {
+	out := make([]*mod.SkillData, 0, len(values))
+	for _, value := range values {
+		switch value := value.(type) {
+		case *mod.SkillData:
+			out = append(out, value)
+		case mod.SkillData:
+			out = append(out, &value)
+		}
+	}
+	return out
+}
+
 func getWeaponFlags(env *Environment, weaponData map[string]interface{}, weaponTypes [][]data.ItemClassName) (mod.MFlag, *data.WeaponTypeInfo) {
 	if _, ok := weaponData["type"]; !ok {
 		return 0, nil
@@ -720,19 +740,28 @@ func getWeaponFlags(env *Environment, weaponData map[string]interface{}, weaponT
 		return 0, nil
 	}
 
-	if weaponTypes != nil {
-		/*
-			for _, types in ipairs(weaponTypes) do
-				if not types[weaponData.type] and
-				(not weaponData.countsAsAll1H or not (types["Claw"] or types["Dagger"] or types["One Handed Axe"] or types["One Handed Mace"] or types["One Handed Sword"])) then
-					return nil, info
-				end
-			end
-		*/
+	weaponType := data.ItemClassName(weaponData["type"].(string))
+	countsAsAll1H := utils.HasTrue(weaponData, "countsAsAll1H")
+	for _, types := range weaponTypes {
+		if len(types) == 0 {
+			continue
+		}
+
+		if slices.Contains(types, weaponType) {
+			continue
+		}
+
+		if countsAsAll1H && slices.ContainsFunc(types, func(t data.ItemClassName) bool {
+			return t == data.Claw || t == data.Dagger || t == data.OneHandAxe || t == data.OneHandMace || t == data.OneHandSword
+		}) {
+			continue
+		}
+
+		return 0, info
 	}
 
 	flags := info.ModFlag
-	if utils.HasTrue(weaponData, "CountsAsAll1H") {
+	if utils.HasTrue(weaponData, "countsAsAll1H") {
 		flags = mod.MFlagAxe | mod.MFlagClaw | mod.MFlagDagger | mod.MFlagMace | mod.MFlagSword
 	}
 
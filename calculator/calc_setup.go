@@ -1,33 +1,29 @@
 package calculator
 
 import (
+	"fmt"
 	"strconv"
 	"strings"
 
 	"github.com/Vilsol/go-pob/data"
+	"github.com/Vilsol/go-pob/mod"
 	"github.com/Vilsol/go-pob/moddb"
 )
 
-func buildModListForNodeList(env *Environment, nodes map[string]data.Node) *moddb.ModList { // TODO ADD: finishJewels)
+func buildModListForNodeList(env *Environment, nodes map[string]data.Node, finishJewels bool) *moddb.ModList {
 	// Initialise radius jewels
-	/* *
-	for _, rad in pairs(env.radiusJewelList) do
-		wipeTable(rad.data)
-		rad.data.modSource = "Tree:"..rad.nodeId
-	end
-	/* */
+	for _, rad := range env.RadiusJewelList {
+		rad.Data = &JewelFuncData{
+			ModSource: mod.Source("Tree:" + rad.NodeID),
+			Stats:     make(map[string]float64),
+			ModList:   moddb.NewModList(),
+		}
+	}
 
 	// Add node modifiers
 	var modList = moddb.NewModList()
-	for nodeId, node := range nodes {
-		cachedModList, isCached := env.Cache.modsForNodes[nodeId]
-		if isCached {
-			modList.AddDB(&cachedModList)
-		} else {
-			var nodeModList = buildModListForNode(env, node)
-			env.Cache.modsForNodes[nodeId] = *nodeModList
-			modList.AddDB(nodeModList)
-		}
+	for nodeID, node := range nodes {
+		modList.AddDB(buildModListForNode(env, nodeID, node))
 
 		// TODO: Is this still a good idea?
 		/* *
@@ -37,80 +33,103 @@ func buildModListForNodeList(env *Environment, nodes map[string]data.Node) *modd
 		/* */
 	}
 
-	/* *
-	// TODO
-	if finishJewels then
+	if finishJewels {
 		// Process extra radius nodes; these are unallocated nodes near conversion or threshold jewels that need to be processed
-		for _, node in pairs(env.extraRadiusNodeList) do
-			local nodeModList = calcs.buildModListForNode(env, node)
-			if env.mode == "MAIN" then
-				node.finalModList = nodeModList
-			end
-		end
+		for nodeID, node := range env.ExtraRadiusNodeList {
+			buildModListForNode(env, nodeID, node)
+		}
 
-		-- Finalise radius jewels
-		for _, rad in pairs(env.radiusJewelList) do
-			rad.func(nil, modList, rad.data)
-			if env.mode == "MAIN" then
-				if not rad.item.jewelRadiusData then
-					rad.item.jewelRadiusData = { }
-				end
-				rad.item.jewelRadiusData[rad.nodeId] = rad.data
-			end
-		end
-	end
-	/* */
+		// Finalise radius jewels
+		for _, rad := range env.RadiusJewelList {
+			rad.Func(nil, modList, rad.Data)
+			// TODO Store rad.Data on the jewel item for display
+		}
+	}
 
 	return modList
 }
 
-func buildModListForNode(env *Environment, node data.Node) *moddb.ModList {
-	var modList = moddb.NewModList()
-	for i, stat := range node.Stats {
-		var mods, err = parseMod(stat, i)
-		if strings.Trim(err, " ") != "" {
-			env.DebugErrors = append(env.DebugErrors, "Error parsing Passive Node ("+*node.Name+") mod: "+err+", stat text: "+stat+", with "+strconv.Itoa(len(mods))+" mods found")
+func buildModListForNode(env *Environment, nodeID string, node data.Node) *moddb.ModList {
+	var nodeModList *moddb.ModList
+	subgraph := env.subgraphForNode(nodeID)
+	if subgraph != nil {
+		// Generated nodes depend on the socketed jewel, so they can't share the cache with other builds
+		nodeModList = parseModListForNode(env, node)
+		isSmall := (node.IsNotable == nil || !*node.IsNotable) && (node.IsKeystone == nil || !*node.IsKeystone)
+		if isSmall && subgraph.IncEffect != 0 {
+			nodeModList.AddMod(MOD("PassiveSkillEffect", "INC", subgraph.IncEffect))
 		}
-		for _, mod := range mods {
-			modList.AddMod(mod)
+	} else {
+		nodeModList = cachedModListForNode(env, nodeCacheKey(env, nodeID), node)
+	}
+
+	inRadius := false
+	for _, rad := range env.RadiusJewelList {
+		if rad.Nodes[nodeID] {
+			inRadius = true
+			break
 		}
 	}
 
-	/* *
-	// TODO
-	-- Run first pass radius jewels
-	for _, rad in pairs(env.radiusJewelList) do
-		if rad.type == "Other" and rad.nodes[node.id] and rad.nodes[node.id].type ~= "Mastery" then
-			rad.func(node, modList, rad.data)
-		end
-	end
+	if !inRadius && subgraph == nil {
+		return nodeModList
+	}
 
-	if modList:Flag(nil, "PassiveSkillHasNoEffect") or (env.allocNodes[node.id] and modList:Flag(nil, "AllocatedPassiveSkillHasNoEffect")) then
-		wipeTable(modList)
-	end
+	// The cached list is shared between calculations, so radius jewels work on a copy
+	var modList = moddb.NewModList()
+	modList.AddDB(nodeModList)
+
+	funcNode := &JewelFuncNode{
+		ID:      nodeID,
+		Node:    node,
+		ModList: nodeModList,
+	}
+
+	_, allocated := env.AllocatedNodes[nodeID]
 
-	-- Apply effect scaling
-	local scale = calcLib.mod(modList, nil, "PassiveSkillEffect")
-	if scale ~= 1 then
-		local scaledList = new("ModList")
-		scaledList:ScaleAddList(modList, scale)
+	// Run first pass radius jewels
+	for _, rad := range env.RadiusJewelList {
+		if rad.Type == JewelFuncTypeOther && rad.Nodes[nodeID] {
+			rad.Func(funcNode, modList, rad.Data)
+		}
+	}
+
+	if modList.Flag(nil, "PassiveSkillHasNoEffect") || (allocated && modList.Flag(nil, "AllocatedPassiveSkillHasNoEffect")) {
+		modList = moddb.NewModList()
+	}
+
+	// Apply effect scaling
+	if scale := CalcMod(modList, nil, "PassiveSkillEffect"); scale != 1 {
+		scaledList := moddb.NewModList()
+		scaledList.ScaleAddList(modList, scale)
 		modList = scaledList
-	end
+	}
 
-	-- Run second pass radius jewels
-	for _, rad in pairs(env.radiusJewelList) do
-		if rad.nodes[node.id] and rad.nodes[node.id].type ~= "Mastery" and (rad.type == "Threshold" or (rad.type == "Self" and env.allocNodes[node.id]) or (rad.type == "SelfUnalloc" and not env.allocNodes[node.id])) then
-			rad.func(node, modList, rad.data)
-		end
-	end
+	// Run second pass radius jewels
+	for _, rad := range env.RadiusJewelList {
+		if !rad.Nodes[nodeID] {
+			continue
+		}
 
-	if modList:Flag(nil, "PassiveSkillHasOtherEffect") then
-		for i, mod in ipairs(modList:List(skillCfg, "NodeModifier")) do
-			if i == 1 then wipeTable(modList) end
-			modList:AddMod(mod.mod)
-		end
-	end
+		if rad.Type == JewelFuncTypeThreshold ||
+			(rad.Type == JewelFuncTypeSelf && allocated) ||
+			(rad.Type == JewelFuncTypeSelfUnalloc && !allocated) {
+			rad.Func(funcNode, modList, rad.Data)
+		}
+	}
+
+	if modList.Flag(nil, "PassiveSkillHasOtherEffect") {
+		modifiers := modList.List(nil, "NodeModifier")
+		if len(modifiers) > 0 {
+			modList = moddb.NewModList()
+			for _, modifier := range modifiers {
+				modList.AddMod(modifier.(mod.NodeModifier).Mod)
+			}
+		}
+	}
 
+	/* *
+	// TODO
 	node.grantedSkills = { }
 	for _, skill in ipairs(modList:List(nil, "ExtraSkill")) do
 		if skill.name ~= "Unknown" then
@@ -130,3 +149,138 @@ func buildModListForNode(env *Environment, node data.Node) *moddb.ModList {
 
 	return modList
 }
+
+// subgraphForNode returns the cluster jewel subgraph the node was generated by, if any
+func (env *Environment) subgraphForNode(nodeID string) *data.Subgraph {
+	id, err := strconv.ParseInt(nodeID, 10, 64)
+	if err != nil || !data.IsSubgraphNode(id) || env.Spec == nil {
+		return nil
+	}
+
+	for _, subgraph := range env.Spec.SubGraphs {
+		if _, ok := subgraph.Nodes[id]; ok {
+			return subgraph
+		}
+	}
+
+	return nil
+}
+
+// nodeCacheKey returns the key of the node in the mod cache, masteries are cached per selected effect
+// and conquered nodes per timeless jewel
+func nodeCacheKey(env *Environment, nodeID string) string {
+	if env.Spec != nil {
+		if effect, ok := env.Spec.MasterySelections[nodeID]; ok {
+			return nodeID + ":" + strconv.FormatInt(effect, 10)
+		}
+
+		if socketID, ok := env.Spec.ConqueredNodes[nodeID]; ok {
+			jewel := env.Spec.TimelessJewels[socketID]
+			return fmt.Sprintf("%s:timeless:%d:%d:%d:%d", nodeID, jewel.Type, jewel.Seed, jewel.ConquerorIndex, jewel.ConquerorVersion)
+		}
+	}
+	return nodeID
+}
+
+// cachedModListForNode returns the parsed mods of the node, parsing and caching them on first use
+func cachedModListForNode(env *Environment, cacheKey string, node data.Node) *moddb.ModList {
+	env.Cache.mu.Lock()
+	defer env.Cache.mu.Unlock()
+
+	if cachedModList, isCached := env.Cache.modsForNodes[cacheKey]; isCached {
+		return &cachedModList
+	}
+
+	var nodeModList = parseModListForNode(env, node)
+	env.Cache.modsForNodes[cacheKey] = *nodeModList
+	env.Cache.modsForNodesDirty = true
+	return nodeModList
+}
+
+func parseModListForNode(env *Environment, node data.Node) *moddb.ModList {
+	var modList = moddb.NewModList()
+	for i, stat := range node.Stats {
+		var mods, err = parseMod(stat, i)
+		if strings.Trim(err, " ") != "" {
+			env.DebugErrors = append(env.DebugErrors, "Error parsing Passive Node ("+*node.Name+") mod: "+err+", stat text: "+stat+", with "+strconv.Itoa(len(mods))+" mods found")
+		}
+		for _, mod := range mods {
+			modList.AddMod(mod)
+		}
+	}
+
+	return modList
+}
+
+// addRadiusJewel registers the jewel functions of a jewel socketed in the allocated socket node
+func (env *Environment) addRadiusJewel(socketID int64, radiusIndex int, jewelModList *moddb.ModList) {
+	socketNodeID := strconv.FormatInt(socketID, 10)
+	if _, ok := env.AllocatedNodes[socketNodeID]; !ok {
+		return
+	}
+
+	for _, value := range jewelModList.List(nil, "JewelData") {
+		if jewelData := value.(mod.JewelData); jewelData.Key == "radiusIndex" {
+			switch index := jewelData.Value.(type) {
+			case int:
+				radiusIndex = index
+			case float64:
+				radiusIndex = int(index)
+			}
+		}
+	}
+
+	funcList := make([]JewelFunc, 0)
+	for _, value := range jewelModList.List(nil, "JewelFunc") {
+		funcList = append(funcList, value.(JewelFunc))
+	}
+
+	if len(funcList) == 0 {
+		// Tally the attributes in radius of jewels without any functions
+		funcList = append(funcList, JewelFunc{
+			Type: JewelFuncTypeSelf,
+			Func: func(node *JewelFuncNode, out *moddb.ModList, data *JewelFuncData) {
+				if node != nil {
+					for _, stat := range []string{"Str", "Dex", "Int"} {
+						data.Stats[stat] += out.Sum(mod.TypeBase, nil, stat)
+					}
+				}
+			},
+		})
+	}
+
+	treeVersion := data.TreeVersions[env.Cache.TreeVersion]
+	tree := treeVersion.Tree()
+
+	nodes := make(map[string]bool)
+	for _, id := range treeVersion.NodesInRadius(socketID, radiusIndex) {
+		nodes[strconv.FormatInt(id, 10)] = true
+	}
+
+	for _, jewelFunc := range funcList {
+		env.RadiusJewelList = append(env.RadiusJewelList, &RadiusJewel{
+			NodeID: socketNodeID,
+			Nodes:  nodes,
+			Type:   jewelFunc.Type,
+			Func:   jewelFunc.Func,
+			Data:   &JewelFuncData{},
+		})
+
+		if jewelFunc.Type == JewelFuncTypeSelf {
+			continue
+		}
+
+		for nodeID := range nodes {
+			if _, ok := env.AllocatedNodes[nodeID]; ok {
+				continue
+			}
+
+			// Unallocated nodes conquered by timeless jewels count as changed by them
+			if env.Spec != nil {
+				env.ExtraRadiusNodeList[nodeID], _ = env.Spec.Node(nodeID)
+			} else {
+				env.ExtraRadiusNodeList[nodeID] = tree.Nodes[nodeID]
+			}
+		}
+	}
+}
@@ -0,0 +1,155 @@
+package calculator
+
+import (
+	"strconv"
+	"testing"
+
+	"github.com/MarvinJWendt/testza"
+
+	"github.com/Vilsol/go-pob/data"
+	"github.com/Vilsol/go-pob/mod"
+	"github.com/Vilsol/go-pob/moddb"
+)
+
+func TestRadiusJewels(t *testing.T) {
+	// Socket with three +10 Dexterity (7112, 13885, 53213) and three +10 Intelligence (27656, 56295, 58244)
+	// small passives in small radius, and another +10 Dexterity (46277) in medium radius
+	socket := int64(61834)
+
+	tests := []struct {
+		name      string
+		jewel     []string
+		radius    int
+		allocated []int64
+		expected  map[string]float64
+	}{
+		{
+			name:      "Conversion",
+			jewel:     []string{"Dexterity from Passives in Radius is Transformed to Intelligence"},
+			radius:    data.JewelRadiusSmall,
+			allocated: []int64{7112, 13885, 27656},
+			expected:  map[string]float64{"Dex": 0, "Int": 30},
+		},
+		{
+			name:      "Effect",
+			jewel:     []string{"50% increased Effect of non-Keystone Passive Skills in Radius"},
+			radius:    data.JewelRadiusSmall,
+			allocated: []int64{7112, 27656},
+			expected:  map[string]float64{"Dex": 15, "Int": 15},
+		},
+		{
+			name:      "Self",
+			jewel:     []string{"1% increased Evasion Rating per 3 Dexterity Allocated in Radius"},
+			radius:    data.JewelRadiusSmall,
+			allocated: []int64{7112, 13885, 53213},
+			expected:  map[string]float64{"Dex": 30, "Evasion": 10},
+		},
+		{
+			name:      "SelfUnalloc",
+			jewel:     []string{"+15 to maximum Mana per 10 Dexterity on Unallocated Passives in Radius"},
+			radius:    data.JewelRadiusSmall,
+			allocated: []int64{7112},
+			expected:  map[string]float64{"Dex": 10, "Mana": 30},
+		},
+		{
+			name:      "UnallocatedSmallPassives",
+			jewel:     []string{"Grants all bonuses of Unallocated Small Passive Skills in Radius"},
+			radius:    data.JewelRadiusSmall,
+			allocated: []int64{7112},
+			expected:  map[string]float64{"Dex": 30, "Int": 30},
+		},
+		{
+			name:      "ThresholdMet",
+			jewel:     []string{"With at least 40 Dexterity in Radius, Ice Shot has 25% increased Area of Effect"},
+			radius:    data.JewelRadiusMedium,
+			allocated: []int64{7112, 13885, 53213, 46277},
+			expected:  map[string]float64{"Dex": 40, "AreaOfEffect": 25},
+		},
+		{
+			name:      "ThresholdUnmet",
+			jewel:     []string{"With at least 40 Dexterity in Radius, Ice Shot has 25% increased Area of Effect"},
+			radius:    data.JewelRadiusSmall,
+			allocated: []int64{7112, 13885, 53213, 46277},
+			expected:  map[string]float64{"Dex": 40, "AreaOfEffect": 0},
+		},
+		{
+			// Like in PoB, unallocated nodes in radius count towards the threshold
+			name:      "ThresholdUnallocated",
+			jewel:     []string{"With at least 40 Dexterity in Radius, Ice Shot has 25% increased Area of Effect"},
+			radius:    data.JewelRadiusMedium,
+			allocated: []int64{7112},
+			expected:  map[string]float64{"Dex": 10, "AreaOfEffect": 25},
+		},
+		{
+			name: "Ring",
+			jewel: []string{
+				"Only affects Passives in Small Ring",
+				"Dexterity from Passives in Radius is Transformed to Intelligence",
+			},
+			radius:    data.JewelRadiusLarge,
+			allocated: []int64{7112},
+			expected:  map[string]float64{"Dex": 10, "Int": 0},
+		},
+	}
+
+	tree := data.TreeVersions[data.LatestTreeVersion].Tree()
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			allocated := make(map[string]data.Node)
+			for _, id := range append(test.allocated, socket) {
+				nodeID := strconv.FormatInt(id, 10)
+				allocated[nodeID] = tree.Nodes[nodeID]
+			}
+
+			env := newNodeModEnv(nil, allocated)
+			env.addRadiusJewel(socket, test.radius, parseTestModList(t, test.jewel...))
+			modList := buildModListForNodeList(env, env.AllocatedNodes, true)
+
+			for name, value := range test.expected {
+				testza.AssertEqual(t, value, sumModValues(modList, name), name)
+			}
+		})
+	}
+}
+
+// sumModValues sums the values of all mods with the name regardless of their tags
+func sumModValues(modList *moddb.ModList, name string) float64 {
+	sum := 0.0
+	for _, m := range modList.Mods() {
+		if m.Name() == name && m.Value().Type() == mod.ModValueMultiTypeFloat {
+			sum += m.Value().Float()
+		}
+	}
+	return sum
+}
+
+// newNodeModEnv returns an environment of the latest tree version with the allocated nodes and an empty node mod cache,
+// for building the mod lists of nodes without a build
+func newNodeModEnv(spec *PassiveSpec, allocated map[string]data.Node) *Environment {
+	return &Environment{
+		Cache: &EnvironmentCache{
+			TreeVersion:  data.LatestTreeVersion,
+			modsForNodes: make(map[string]moddb.ModList),
+		},
+		Spec:                spec,
+		RadiusJewelList:     make([]*RadiusJewel, 0),
+		ExtraRadiusNodeList: make(map[string]data.Node),
+		AllocatedNodes:      allocated,
+	}
+}
+
+// parseTestModList parses the lines into a mod list, failing the test if any line is not fully parsed
+func parseTestModList(t *testing.T, lines ...string) *moddb.ModList {
+	t.Helper()
+
+	modList := moddb.NewModList()
+	for i, line := range lines {
+		mods, extra := parseMod(line, i)
+		testza.AssertEqual(t, "", extra, line)
+		for _, m := range mods {
+			modList.AddMod(m)
+		}
+	}
+	return modList
+}
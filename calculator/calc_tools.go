@@ -62,6 +62,19 @@ func CalcGemIsType(gem *poe.SkillGem, t string) bool {
 	return ok
 }
 
+// MinionSkillTypes returns the skill types of the minions created by the active skill, or nil if it creates none
+func MinionSkillTypes(activeSkill *poe.ActiveSkill) map[data.SkillType]bool {
+	if activeSkill == nil || len(activeSkill.MinionActiveSkillTypes) == 0 {
+		return nil
+	}
+
+	types := make(map[data.SkillType]bool, len(activeSkill.MinionActiveSkillTypes))
+	for _, skillType := range activeSkill.MinionActiveSkillTypes {
+		types[data.SkillType(poe.ActiveSkillTypes[skillType].ID)] = true
+	}
+	return types
+}
+
 func TypesToFlagsAndTypes(in []*poe.ActiveSkillType) (map[SkillFlag]bool, map[data.SkillType]bool) {
 	flags := make(map[SkillFlag]bool)
 	types := make(map[data.SkillType]bool)
@@ -155,24 +168,12 @@ func CalcBuildSkillInstanceStats(skillInstance *GemEffect, grantedEffect *Grante
 	allQualities := grantedEffect.Raw.GetEffectQualityStats()
 
 	if skillInstance.Quality > 0 && allQualities != nil {
-		qualityID := 0
-		switch skillInstance.QualityID {
-		case "Alternate1":
-			qualityID = 1
-		case "Alternate2":
-			qualityID = 2
-		case "Alternate3":
-			qualityID = 3
-		}
+		if qualityStats, ok := allQualities[gemQualitySetID(skillInstance.QualityID)]; ok {
+			for i, stat := range qualityStats.GetStats() {
+				baseVal := float64(qualityStats.StatsValuesPermille[i]) / 1000
 
-		qualityStats := allQualities[qualityID]
-		for i, stat := range qualityStats.GetStats() {
-			baseVal := float64(qualityStats.StatsValuesPermille[i])
-			if baseVal > 0 {
-				baseVal = baseVal / 1000
+				stats[stat.ID] = stats[stat.ID] + utils.ModF(baseVal*float64(skillInstance.Quality))
 			}
-
-			stats[stat.ID] = stats[stat.ID] + utils.ModF(baseVal*float64(skillInstance.Quality))
 		}
 	}
 
@@ -257,7 +258,8 @@ func CalcCanGrantedEffectSupportActiveSkill(grantedEffect *GrantedEffect, active
 		minionTypes = activeSkill.MinionSkillTypes
 	}
 
-	return CalcDoesTypeExpressionMatch(data.RawToSkillTypes(grantedEffect.Raw.GetSupportTypes()), activeSkill.SkillTypes, minionTypes)
+	// Supports without any required types support every skill
+	return len(grantedEffect.Raw.SupportTypes) == 0 || CalcDoesTypeExpressionMatch(data.RawToSkillTypes(grantedEffect.Raw.GetSupportTypes()), activeSkill.SkillTypes, minionTypes)
 }
 
 // CalcDoesTypeExpressionMatch Evaluates a skill type postfix expression
@@ -1,12 +1,13 @@
 package calculator
 
 import (
-	"strconv"
+	"maps"
 	"strings"
 
 	"github.com/Vilsol/go-pob-data/poe"
 
 	"github.com/Vilsol/go-pob/data"
+	raw2 "github.com/Vilsol/go-pob/data/raw"
 	"github.com/Vilsol/go-pob/mod"
 	"github.com/Vilsol/go-pob/moddb"
 	"github.com/Vilsol/go-pob/pob"
@@ -20,11 +21,14 @@ func InitEnv(build *pob.PathOfBuilding, envCache *EnvironmentCache, mode OutputM
 	// TODO This come from the build at some point but below it's not so not sure what to do here yet
 	currentTreeVersion := data.LatestTreeVersion
 
-	// Clear Node Mod cache if tree has changed
-	if env.Cache.TreeVersion != currentTreeVersion {
-		env.Cache.modsForNodes = make(map[string]moddb.ModList, len(data.TreeVersions[currentTreeVersion].Tree().Nodes))
+	// Reload Node Mod cache if tree has changed
+	env.Cache.mu.Lock()
+	if env.Cache.TreeVersion != currentTreeVersion || env.Cache.modsForNodes == nil {
+		env.Cache.modsForNodes = loadNodeMods(currentTreeVersion)
+		env.Cache.modsForNodesDirty = false
 		env.Cache.TreeVersion = currentTreeVersion
 	}
+	env.Cache.mu.Unlock()
 
 	env.DebugErrors = make([]string, 0)
 	env.Build = build
@@ -41,6 +45,7 @@ func InitEnv(build *pob.PathOfBuilding, envCache *EnvironmentCache, mode OutputM
 	env.Player = &Actor{
 		ModDB:           env.ModDB,
 		Level:           build.Build.Level,
+		ItemList:        make(map[string]*EquippedItem),
 		ActiveSkillList: make([]*ActiveSkill, 0),
 	}
 
@@ -57,8 +62,8 @@ func InitEnv(build *pob.PathOfBuilding, envCache *EnvironmentCache, mode OutputM
 	env.RequirementsTableItems = make(map[string]interface{})
 	env.RequirementsTableGems = make([]*RequirementsTableGems, 0)
 
-	env.RadiusJewelList = make(map[string]interface{})
-	env.ExtraRadiusNodeList = make(map[string]interface{})
+	env.RadiusJewelList = make([]*RadiusJewel, 0)
+	env.ExtraRadiusNodeList = make(map[string]data.Node)
 	env.GrantedSkills = make(map[string]interface{})
 	env.GrantedSkillsNodes = make(map[string]interface{})
 	env.GrantedSkillsItems = make(map[string]interface{})
@@ -207,7 +212,10 @@ func InitEnv(build *pob.PathOfBuilding, envCache *EnvironmentCache, mode OutputM
 	cachedEnemyDB := env.EnemyModDB.Clone()
 	cachedMinionDB := env.Minion.Clone()
 
-	var tree = data.TreeVersions[data.LatestTreeVersion].Tree()
+	// Cluster, timeless and intuitive leap jewels change which nodes are allocated
+	treeJewels, jewelErrs := env.Spec.socketTreeJewels()
+	env.DebugErrors = append(env.DebugErrors, jewelErrs...)
+
 	env.AllocatedNodes = make(map[string]data.Node)
 	/* *
 	// TODO
@@ -225,268 +233,27 @@ func InitEnv(build *pob.PathOfBuilding, envCache *EnvironmentCache, mode OutputM
 		end
 	end
 	/* */
-	for _, id := range env.Build.Build.PassiveNodes {
-		var strId = strconv.FormatInt(id, 10)
-		env.AllocatedNodes[strId] = tree.Nodes[strId]
+	for nodeID, node := range env.Spec.AllocNodes {
+		env.AllocatedNodes[nodeID] = node
 	}
 
+	// Build and merge item modifiers, and create list of radius jewels
+	env.buildItemModList(build, treeJewels)
+
 	/*
-		TODO -- Build and merge item modifiers, and create list of radius jewels
-		for _, slot in pairs(build.itemsTab.orderedSlots) do
-			local slotName = slot.slotName
-			local item
-			if slotName == override.repSlotName then
-				item = override.repItem
-			elseif override.repItem and override.repSlotName:match("^Weapon 1") and slotName:match("^Weapon 2") and
-			(override.repItem.base.type == "Staff" or override.repItem.base.type == "Two Handed Sword" or override.repItem.base.type == "Two Handed Axe" or override.repItem.base.type == "Two Handed Mace"
-			or (override.repItem.base.type == "Bow" and item and item.base.type ~= "Quiver")) then
-				item = nil
-			elseif slot.nodeId and override.spec then
-				item = build.itemsTab.items[env.spec.jewels[slot.nodeId]]
-			else
-				item = build.itemsTab.items[slot.selItemId]
-			end
-			if item then
-				-- Find skills granted by this item
-				for _, skill in ipairs(item.grantedSkills) do
-					local grantedSkill = copyTable(skill)
-					grantedSkill.sourceItem = item
-					grantedSkill.slotName = slotName
-					t_insert(env.grantedSkillsItems, grantedSkill)
-				end
-			end
-			if slot.weaponSet and slot.weaponSet ~= (build.itemsTab.activeItemSet.useSecondWeaponSet and 2 or 1) then
-				item = nil
-			end
-			if slot.weaponSet == 2 and build.itemsTab.activeItemSet.useSecondWeaponSet then
-				slotName = slotName:gsub(" Swap","")
-			end
-			if slot.nodeId then
-				-- Slot is a jewel socket, check if socket is allocated
-				if not env.allocNodes[slot.nodeId] then
-					item = nil
-				elseif item and item.jewelRadiusIndex then
-					-- Jewel has a radius, add it to the list
-					local funcList = item.jewelData.funcList or { { type = "Self", func = function(node, out, data)
-						-- Default function just tallies all stats in radius
-						if node then
-							for _, stat in pairs({"Str","Dex","Int"}) do
-								data[stat] = (data[stat] or 0) + out:Sum("BASE", nil, stat)
-							end
-						end
-					end } }
-					for _, func in ipairs(funcList) do
-						local node = env.spec.nodes[slot.nodeId]
-						t_insert(env.radiusJewelList, {
-							nodes = node.nodesInRadius and node.nodesInRadius[item.jewelRadiusIndex] or { },
-							func = func.func,
-							type = func.type,
-							item = item,
-							nodeId = slot.nodeId,
-							attributes = node.attributesInRadius and node.attributesInRadius[item.jewelRadiusIndex] or { },
-							data = { }
-						})
-						if func.type ~= "Self" and node.nodesInRadius then
-							-- Add nearby unallocated nodes to the extra node list
-							for nodeId, node in pairs(node.nodesInRadius[item.jewelRadiusIndex]) do
-								if not env.allocNodes[nodeId] then
-									env.extraRadiusNodeList[nodeId] = env.spec.nodes[nodeId]
-								end
-							end
-						end
-					end
-				end
-			end
-			if item and item.type == "Flask" then
-				if slot.active then
-					env.flasks[item] = true
-				end
-				if item.base.subType == "Life" then
-					local highestLifeRecovery = env.itemModDB.multipliers["LifeFlaskRecovery"] or 0
-					if item.flaskData.lifeTotal > highestLifeRecovery then
-						env.itemModDB.multipliers["LifeFlaskRecovery"] = item.flaskData.lifeTotal
-					end
-				end
-				item = nil
-			end
-			local scale = 1
-			if item and item.type == "Jewel" and item.base.subType == "Abyss" and slot.parentSlot then
-				-- Check if the item in the parent slot has enough Abyssal Sockets
-				local parentItem = env.player.itemList[slot.parentSlot.slotName]
-				if not parentItem or parentItem.abyssalSocketCount < slot.slotNum then
-					item = nil
-				else
-					scale = parentItem.socketedJewelEffectModifier
-				end
-			end
-			if slot.nodeId and item and item.type == "Jewel" and item.jewelData and item.jewelData.jewelIncEffectFromClassStart then
-				local node = env.spec.nodes[slot.nodeId]
-				if node and node.distanceToClassStart then
-					scale = scale + node.distanceToClassStart * (item.jewelData.jewelIncEffectFromClassStart / 100)
-				end
-			end
-			if item then
-				env.player.itemList[slotName] = item
-				-- Merge mods for this item
-				local srcList = item.modList or item.slotModList[slot.slotNum]
-				if item.requirements and not accelerate.requirementsItems then
-					t_insert(env.requirementsTableItems, {
-						source = "Item",
-						sourceItem = item,
-						sourceSlot = slotName,
-						Str = item.requirements.strMod,
-						Dex = item.requirements.dexMod,
-						Int = item.requirements.intMod,
-					})
-				end
-				if item.type == "Jewel" and item.base.subType == "Abyss" then
-					-- Update Abyss Jewel conditions/multipliers
-					local cond = "Have"..item.baseName:gsub(" ","")
-					if not env.itemModDB.conditions[cond] then
-						env.itemModDB.conditions[cond] = true
-						env.itemModDB.multipliers["AbyssJewelType"] = (env.itemModDB.multipliers["AbyssJewelType"] or 0) + 1
-					end
-					if slot.parentSlot then
-						env.itemModDB.conditions[cond.."In"..slot.parentSlot.slotName] = true
-					end
-					env.itemModDB.multipliers["AbyssJewel"] = (env.itemModDB.multipliers["AbyssJewel"] or 0) + 1
-					env.itemModDB.multipliers[item.baseName:gsub(" ","")] = (env.itemModDB.multipliers[item.baseName:gsub(" ","")] or 0) + 1
-				end
-				if item.type == "Shield" and env.allocNodes[45175] and env.allocNodes[45175].dn == "Necromantic Aegis" then
-					-- Special handling for Necromantic Aegis
-					env.aegisModList = new("ModList")
-					for _, mod in ipairs(srcList) do
-						-- Filter out mods that apply to socketed gems, or which add supports
-						local add = true
-						for _, tag in ipairs(mod) do
-							if tag.type == "SocketedIn" then
-								add = false
-								break
-							end
-						end
-						if add then
-							env.aegisModList:ScaleAddMod(mod, scale)
-						else
-							env.itemModDB:ScaleAddMod(mod, scale)
-						end
-					end
-				elseif (slotName == "Weapon 1" or slotName == "Weapon 2") and modDB:Flag(nil, "Condition:EnergyBladeActive") then
-					local type = env.player.itemList[slotName] and env.player.itemList[slotName].weaponData and env.player.itemList[slotName].weaponData[1].type
-					local info = env.data.weaponTypeInfo[type]
-					if info and type ~= "Bow" then
-						local name = info.oneHand and "Energy Blade One Handed" or "Energy Blade Two Handed"
-						local item = new("Item")
-						item.name = name
-						item.base = data.itemBases[name]
-						item.baseName = name
-						item.classRequirementModLines = { }
-						item.buffModLines = { }
-						item.enchantModLines = { }
-						item.scourgeModLines = { }
-						item.implicitModLines = { }
-						item.explicitModLines = { }
-						item.quality = 0
-						item.rarity = "NORMAL"
-						if item.baseName.implicit then
-							local implicitIndex = 1
-							for line in item.baseName.implicit:gmatch("[^\n]+") do
-								local modList, extra = modLib.parseMod(line)
-								t_insert(item.implicitModLines, { line = line, extra = extra, modList = modList or { }, modTags = item.baseName.implicitModTypes and item.baseName.implicitModTypes[implicitIndex] or { } })
-								implicitIndex = implicitIndex + 1
-							end
-						end
-						item:NormaliseQuality()
-						item:BuildAndParseRaw()
-						env.player.itemList[slotName] = item
-					else
-						env.itemModDB:ScaleAddList(srcList, scale)
-					end
-				elseif slotName == "Weapon 1" and item.name == "The Iron Mass, Gladius" then
-					-- Special handling for The Iron Mass
-					env.theIronMass = new("ModList")
-					for _, mod in ipairs(srcList) do
-						-- Filter out mods that apply to socketed gems, or which add supports
-						local add = true
-						for _, tag in ipairs(mod) do
-							if tag.type == "SocketedIn" then
-								add = false
-								break
-							end
-						end
-						if add then
-							env.theIronMass:ScaleAddMod(mod, scale)
-						end
-						-- Add all the stats to player as well
-						env.itemModDB:ScaleAddMod(mod, scale)
-					end
-				elseif slotName == "Weapon 1" and item.grantedSkills[1] and item.grantedSkills[1].skillId == "UniqueAnimateWeapon" then
-					-- Special handling for The Dancing Dervish
-					env.weaponModList1 = new("ModList")
-					for _, mod in ipairs(srcList) do
-						-- Filter out mods that apply to socketed gems, or which add supports
-						local add = true
-						for _, tag in ipairs(mod) do
-							if tag.type == "SocketedIn" then
-								add = false
-								break
-							end
-						end
-						if add then
-							env.weaponModList1:ScaleAddMod(mod, scale)
-						else
-							env.itemModDB:ScaleAddMod(mod, scale)
-						end
-					end
-				else
-					env.itemModDB:ScaleAddList(srcList, scale)
-				end
-				-- set conditions on restricted items
-				if item.classRestriction then
-					env.itemModDB.conditions[item.title:gsub(" ", "")] = item.classRestriction
-				end
-				if item.type ~= "Jewel" and item.type ~= "Flask" then
-					-- Update item counts
-					local key
-					if item.rarity == "UNIQUE" or item.rarity == "RELIC" then
-						key = "UniqueItem"
-					elseif item.rarity == "RARE" then
-						key = "RareItem"
-					elseif item.rarity == "MAGIC" then
-						key = "MagicItem"
-					else
-						key = "NormalItem"
-					end
-					env.itemModDB.multipliers[key] = (env.itemModDB.multipliers[key] or 0) + 1
-					env.itemModDB.conditions[key .. "In" .. slotName] = true
-					if item.corrupted then
-						env.itemModDB.multipliers.CorruptedItem = (env.itemModDB.multipliers.CorruptedItem or 0) + 1
-					else
-						env.itemModDB.multipliers.NonCorruptedItem = (env.itemModDB.multipliers.NonCorruptedItem or 0) + 1
-					end
-					if item.shaper then
-						env.itemModDB.multipliers.ShaperItem = (env.itemModDB.multipliers.ShaperItem or 0) + 1
-						env.itemModDB.conditions["ShaperItemIn"..slotName] = true
-					else
-						env.itemModDB.multipliers.NonShaperItem = (env.itemModDB.multipliers.NonShaperItem or 0) + 1
-					end
-					if item.elder then
-						env.itemModDB.multipliers.ElderItem = (env.itemModDB.multipliers.ElderItem or 0) + 1
-						env.itemModDB.conditions["ElderItemIn"..slotName] = true
-					else
-						env.itemModDB.multipliers.NonElderItem = (env.itemModDB.multipliers.NonElderItem or 0) + 1
-					end
-					if item.shaper or item.elder then
-						env.itemModDB.multipliers.ShaperOrElderItem = (env.itemModDB.multipliers.ShaperOrElderItem or 0) + 1
-					end
-				end
-			end
+		TODO -- Find skills granted by items
+		for _, skill in ipairs(item.grantedSkills) do
+			local grantedSkill = copyTable(skill)
+			grantedSkill.sourceItem = item
+			grantedSkill.slotName = slotName
+			t_insert(env.grantedSkillsItems, grantedSkill)
 		end
 	*/
 
-	/*
-		TODO -- Merge env.itemModDB with env.ModDB
-		mergeDB(env.modDB, env.itemModDB)
-	*/
+	// Merge env.itemModDB with env.ModDB
+	env.ModDB.AddDB(env.ItemModDB)
+	maps.Copy(env.ModDB.Conditions, env.ItemModDB.Conditions)
+	maps.Copy(env.ModDB.Multipliers, env.ItemModDB.Multipliers)
 
 	/*
 		TODO Flask Override
@@ -530,7 +297,7 @@ func InitEnv(build *pob.PathOfBuilding, envCache *EnvironmentCache, mode OutputM
 		end
 	*/
 
-	env.ModDB.AddList(buildModListForNodeList(env, env.AllocatedNodes))
+	env.ModDB.AddList(buildModListForNodeList(env, env.AllocatedNodes, true))
 
 	/*
 		TODO -- Find skills granted by tree nodes
@@ -622,30 +389,20 @@ func InitEnv(build *pob.PathOfBuilding, envCache *EnvironmentCache, mode OutputM
 		*/
 	}
 
+	// Get the weapon data tables for the equipped weapons
 	env.Player.WeaponData1 = utils.CopyMap(data.UnarmedWeaponData[data.ClassIDs[env.Spec.ClassName]])
-	//if _, ok := env.Player.ItemList["Weapon 1"]; ok {
-	// TODO Weapon 1 Data
-	// env.player.itemList["Weapon 1"].weaponData and env.player.itemList["Weapon 1"].weaponData[1]
-	//}
+	if weapon1 := env.Player.ItemList["Weapon 1"]; weapon1 != nil && weapon1.WeaponData != nil {
+		env.Player.WeaponData1 = utils.CopyMap(weapon1.WeaponData)
+	}
 
 	if utils.HasTrue(env.Player.WeaponData1, "countsAsDualWielding") {
-		// TODO
-		// env.player.weaponData2 = env.player.itemList["Weapon 1"].weaponData[2]
+		env.Player.WeaponData2 = utils.CopyMap(env.Player.ItemList["Weapon 1"].WeaponData)
+	} else if weapon2 := env.Player.ItemList["Weapon 2"]; weapon2 != nil && weapon2.WeaponData != nil {
+		env.Player.WeaponData2 = utils.CopyMap(weapon2.WeaponData)
 	} else {
-		// TODO
-		// env.player.weaponData2 = env.player.itemList["Weapon 2"] and env.player.itemList["Weapon 2"].weaponData and env.player.itemList["Weapon 2"].weaponData[2] or { }
 		env.Player.WeaponData2 = make(map[string]interface{})
 	}
 
-	/*
-		TODO -- Get the weapon data tables for the equipped weapons
-		if env.player.weaponData1.countsAsDualWielding then
-			env.player.weaponData2 = env.player.itemList["Weapon 1"].weaponData[2]
-		else
-			env.player.weaponData2 = env.player.itemList["Weapon 2"] and env.player.itemList["Weapon 2"].weaponData and env.player.itemList["Weapon 2"].weaponData[2] or { }
-		end
-	*/
-
 	if env.Mode == OutputModeCalcs {
 
 	} else {
@@ -700,15 +457,9 @@ func InitEnv(build *pob.PathOfBuilding, envCache *EnvironmentCache, mode OutputM
 	for _, index := range indexOrder {
 		socketGroup := build.Skills.SkillSets[selectedSkillSet].Skills[index]
 		socketGroupSkillList := make([]*ActiveSkill, 0)
-		var slot interface{}
-		if socketGroup.Slot != "" {
-			// TODO
-			// slot = build.itemsTab.slots[socketGroup.slot]
-		}
-
-		socketGroup.SlotEnabled = slot == nil
-		// TODO
-		// socketGroup.slotEnabled = not slot or not slot.weaponSet or slot.weaponSet == (build.itemsTab.activeItemSet.useSecondWeaponSet and 2 or 1)
+		// Groups socketed in the inactive weapon set are disabled
+		weaponSet := slotWeaponSet(socketGroup.Slot)
+		socketGroup.SlotEnabled = weaponSet == 0 || weaponSet == activeWeaponSet(build)
 		if index == env.MainSocketGroup || (socketGroup.Enabled && socketGroup.SlotEnabled) {
 			if socketGroup.Slot != "" {
 				groupCfg.SlotName = strings.Replace(socketGroup.Slot, " Swap", "", -1)
@@ -895,10 +646,11 @@ func InitEnv(build *pob.PathOfBuilding, envCache *EnvironmentCache, mode OutputM
 							temp := gemInstance
 							activeEffect := &GemEffect{
 								GrantedEffect: &GrantedEffect{
-									Raw:        grantedEffect,
-									Parts:      nil, // TODO Parts
-									SkillTypes: skillTypes,
-									BaseFlags:  baseFlags,
+									Raw:              grantedEffect,
+									Parts:            raw2.SkillParts[grantedEffect.ID],
+									SkillTypes:       skillTypes,
+									MinionSkillTypes: MinionSkillTypes(grantedEffect.GetActiveSkill()),
+									BaseFlags:        baseFlags,
 								},
 								Level:       gemInstance.Level,
 								Quality:     gemInstance.Quality,
@@ -1013,7 +765,6 @@ func InitEnv(build *pob.PathOfBuilding, envCache *EnvironmentCache, mode OutputM
 		defaultEffect := &GemEffect{
 			GrantedEffect: &GrantedEffect{
 				Raw:        playerMelee,
-				Parts:      nil, // TODO Parts
 				SkillTypes: skillTypes,
 				BaseFlags:  baseFlags,
 			},
@@ -0,0 +1,355 @@
+package calculator
+
+import (
+	"cmp"
+	"fmt"
+	"maps"
+	"slices"
+	"strings"
+	"sync"
+
+	"github.com/Vilsol/go-pob-data/poe"
+
+	"github.com/Vilsol/go-pob/data"
+	"github.com/Vilsol/go-pob/moddb"
+)
+
+type GemColour string
+
+const (
+	GemColourRed   = GemColour("red")
+	GemColourGreen = GemColour("green")
+	GemColourBlue  = GemColour("blue")
+	GemColourWhite = GemColour("white")
+)
+
+// The gem tags of the data bundle can't be resolved, so tags are derived from the skill types instead
+var skillTypeGemTags = map[data.SkillType]string{
+	data.SkillTypeAttack:         "attack",
+	data.SkillTypeSpell:          "spell",
+	data.SkillTypeProjectile:     "projectile",
+	data.SkillTypeMinion:         "minion",
+	data.SkillTypeArea:           "area",
+	data.SkillTypeDuration:       "duration",
+	data.SkillTypeMelee:          "melee",
+	data.SkillTypeChains:         "chaining",
+	data.SkillTypeFire:           "fire",
+	data.SkillTypeCold:           "cold",
+	data.SkillTypeLightning:      "lightning",
+	data.SkillTypeChaos:          "chaos",
+	data.SkillTypePhysical:       "physical",
+	data.SkillTypeSummonsTotem:   "totem",
+	data.SkillTypeTrapped:        "trap",
+	data.SkillTypeRemoteMined:    "mine",
+	data.SkillTypeMovement:       "movement",
+	data.SkillTypeTravel:         "travel",
+	data.SkillTypeBlink:          "blink",
+	data.SkillTypeVaal:           "vaal",
+	data.SkillTypeAura:           "aura",
+	data.SkillTypeChannel:        "channelling",
+	data.SkillTypeGolem:          "golem",
+	data.SkillTypeHerald:         "herald",
+	data.SkillTypeWarcry:         "warcry",
+	data.SkillTypeBrand:          "brand",
+	data.SkillTypeAppliesCurse:   "curse",
+	data.SkillTypeHex:            "hex",
+	data.SkillTypeMark:           "mark",
+	data.SkillTypeGuard:          "guard",
+	data.SkillTypeNova:           "nova",
+	data.SkillTypeBanner:         "banner",
+	data.SkillTypeSlam:           "slam",
+	data.SkillTypeStance:         "stance",
+	data.SkillTypeOrb:            "orb",
+	data.SkillTypeArcane:         "arcane",
+	data.SkillTypeLink:           "link",
+	data.SkillTypeBlessing:       "blessing",
+	data.SkillTypeTriggered:      "trigger",
+	data.SkillTypeSteel:          "steel",
+	data.SkillTypeDamageOverTime: "damage_over_time",
+}
+
+type GemCatalogueEntry struct {
+	// Base item type ID, as used by socketed gems of a build
+	ID              string
+	Name            string
+	GrantedEffectID string
+	Colour          GemColour
+	Support         bool
+	Vaal            bool
+	Awakened        bool
+
+	// Highest level the gem reaches without corruption
+	MaxLevel int
+
+	// Sorted lowercase tags, e.g. spell, fire or support
+	Tags []string
+
+	// Skill types of the active skill, or the types a support adds to the skills it supports
+	SkillTypes []data.SkillType
+
+	// Skill types of the minions created by the active skill
+	MinionSkillTypes []data.SkillType
+
+	// Default and alternate quality variants of the gem
+	Qualities []GemQuality
+
+	gem           *poe.SkillGem
+	grantedEffect *poe.GrantedEffect
+}
+
+// GemFilter restricts the gems of the catalogue. Unset fields match every gem.
+type GemFilter struct {
+	// Case-insensitive part of the name, or of the name of any of its quality variants
+	Name string
+
+	// Tags the gem must all have
+	Tags []string
+
+	// Colours of which the gem must have any
+	Colours []GemColour
+
+	Support  *bool
+	Vaal     *bool
+	Awakened *bool
+
+	// Skill types the gem must all have, including the skill types of its minions
+	SkillTypes []data.SkillType
+}
+
+var (
+	gemCatalogueMu sync.Mutex
+	gemCatalogue   []GemCatalogueEntry
+)
+
+// SkillGemColour returns the socket colour of the gem, based on its highest attribute requirement
+func SkillGemColour(gem *poe.SkillGem) GemColour {
+	switch {
+	case gem.Str > gem.Dex && gem.Str > gem.Int:
+		return GemColourRed
+	case gem.Dex > gem.Int && gem.Dex > gem.Str:
+		return GemColourGreen
+	case gem.Int > gem.Dex && gem.Int > gem.Str:
+		return GemColourBlue
+	default:
+		return GemColourWhite
+	}
+}
+
+// naturalMaxLevel returns the highest level of the gem without corruption. The data only contains the amount of
+// levels of every gem, which include the levels reachable by corruption and level bonuses.
+func naturalMaxLevel(gem *poe.SkillGem) int {
+	levels := len(gem.GetGrantedEffect().Levels())
+
+	switch {
+	case gem.RegularVariant != nil:
+		return 5
+	case levels >= 40:
+		return 20
+	case levels > 20:
+		return levels - 20
+	case gem.GetGrantedEffect().IsSupport:
+		// Empower, Enhance and Enlighten
+		return min(levels, 3)
+	default:
+		return 1
+	}
+}
+
+// GemCatalogue returns all gems that can be obtained in the game, sorted by name
+func GemCatalogue() []GemCatalogueEntry {
+	gemCatalogueMu.Lock()
+	defer gemCatalogueMu.Unlock()
+
+	if gemCatalogue != nil {
+		return gemCatalogue
+	}
+
+	catalogue := make([]GemCatalogueEntry, 0)
+	for _, gem := range poe.SkillGems {
+		baseType := gem.GetBaseItemType()
+		if baseType == nil || baseType.SiteVisibility < 1 {
+			continue
+		}
+
+		catalogue = append(catalogue, newGemCatalogueEntry(gem))
+	}
+
+	slices.SortFunc(catalogue, func(a, b GemCatalogueEntry) int {
+		return cmp.Or(cmp.Compare(a.Name, b.Name), cmp.Compare(a.ID, b.ID))
+	})
+
+	gemCatalogue = catalogue
+	return gemCatalogue
+}
+
+func newGemCatalogueEntry(gem *poe.SkillGem) GemCatalogueEntry {
+	grantedEffect := gem.GetGrantedEffect()
+
+	entry := GemCatalogueEntry{
+		ID:              gem.GetBaseItemType().ID,
+		Name:            gem.GetBaseItemType().Name,
+		GrantedEffectID: grantedEffect.ID,
+		Colour:          SkillGemColour(gem),
+		Support:         grantedEffect.IsSupport,
+		Vaal:            gem.IsVaalGem,
+		Awakened:        gem.RegularVariant != nil,
+		MaxLevel:        naturalMaxLevel(gem),
+		Qualities:       GemQualities(grantedEffect, gem.GetBaseItemType().Name),
+		gem:             gem,
+		grantedEffect:   grantedEffect,
+	}
+
+	skillTypes := gemSkillTypes(grantedEffect)
+	entry.SkillTypes = slices.Sorted(maps.Keys(skillTypes))
+	if !entry.Support {
+		entry.MinionSkillTypes = slices.Sorted(maps.Keys(MinionSkillTypes(grantedEffect.GetActiveSkill())))
+	}
+
+	entry.Tags = gemTags(gem, skillTypes)
+
+	return entry
+}
+
+// gemSkillTypes returns the skill types of the active skill, or the types a support adds to the skills it supports
+func gemSkillTypes(grantedEffect *poe.GrantedEffect) map[data.SkillType]bool {
+	if !grantedEffect.IsSupport {
+		_, skillTypes := TypesToFlagsAndTypes(grantedEffect.GetActiveSkill().GetActiveSkillTypes())
+		return skillTypes
+	}
+
+	skillTypes := make(map[data.SkillType]bool)
+	for _, skillType := range grantedEffect.AddTypes {
+		skillTypes[data.SkillType(poe.ActiveSkillTypes[skillType].ID)] = true
+	}
+	return skillTypes
+}
+
+// gemTags returns the sorted tags of the gem, derived from its skill types
+func gemTags(gem *poe.SkillGem, skillTypes map[data.SkillType]bool) []string {
+	tags := make(map[string]bool)
+	for skillType := range skillTypes {
+		if tag, ok := skillTypeGemTags[skillType]; ok {
+			tags[tag] = true
+		}
+	}
+
+	if tags["fire"] || tags["cold"] || tags["lightning"] {
+		tags["elemental"] = true
+	}
+
+	if gem.GetGrantedEffect().IsSupport {
+		tags["support"] = true
+	} else {
+		tags["active_skill"] = true
+	}
+
+	if gem.IsVaalGem {
+		tags["vaal"] = true
+	}
+
+	if gem.RegularVariant != nil {
+		tags["awakened"] = true
+	}
+
+	return slices.Sorted(maps.Keys(tags))
+}
+
+// newSkillGem returns the gem of an active skill as matched by the keywords of modifiers, or nil if there is none
+func newSkillGem(gem *poe.SkillGem) *moddb.SkillGem {
+	if gem == nil || gem.GetBaseItemType() == nil {
+		return nil
+	}
+
+	return &moddb.SkillGem{
+		Name: gem.GetBaseItemType().Name,
+		Tags: gemTags(gem, gemSkillTypes(gem.GetGrantedEffect())),
+	}
+}
+
+// SearchGems returns the gems of the catalogue matching the filter, sorted by name
+func SearchGems(filter GemFilter) []GemCatalogueEntry {
+	result := make([]GemCatalogueEntry, 0)
+	for _, entry := range GemCatalogue() {
+		if filter.matches(entry) {
+			result = append(result, entry)
+		}
+	}
+	return result
+}
+
+// matches returns whether the gem matches every set field of the filter
+func (f GemFilter) matches(entry GemCatalogueEntry) bool {
+	if f.Name != "" && !entry.nameContains(strings.ToLower(f.Name)) {
+		return false
+	}
+
+	for _, tag := range f.Tags {
+		if !slices.Contains(entry.Tags, strings.ToLower(tag)) {
+			return false
+		}
+	}
+
+	if len(f.Colours) > 0 && !slices.Contains(f.Colours, entry.Colour) {
+		return false
+	}
+
+	if (f.Support != nil && *f.Support != entry.Support) ||
+		(f.Vaal != nil && *f.Vaal != entry.Vaal) ||
+		(f.Awakened != nil && *f.Awakened != entry.Awakened) {
+		return false
+	}
+
+	for _, skillType := range f.SkillTypes {
+		if !slices.Contains(entry.SkillTypes, skillType) && !slices.Contains(entry.MinionSkillTypes, skillType) {
+			return false
+		}
+	}
+
+	return true
+}
+
+func (e GemCatalogueEntry) nameContains(lowerName string) bool {
+	if strings.Contains(strings.ToLower(e.Name), lowerName) {
+		return true
+	}
+
+	return slices.ContainsFunc(e.Qualities, func(quality GemQuality) bool {
+		return strings.Contains(strings.ToLower(quality.Name), lowerName)
+	})
+}
+
+// CompatibleSupports returns the support gems that can support the active skill of the gem with the provided ID,
+// including supports that only apply to the minions of the skill
+func CompatibleSupports(activeGemID string) ([]GemCatalogueEntry, error) {
+	catalogue := GemCatalogue()
+
+	index := slices.IndexFunc(catalogue, func(entry GemCatalogueEntry) bool { return entry.ID == activeGemID })
+	if index < 0 {
+		return nil, fmt.Errorf("unknown skill gem: %s", activeGemID)
+	}
+
+	active := catalogue[index]
+	if active.Support {
+		return nil, fmt.Errorf("not an active skill gem: %s", activeGemID)
+	}
+
+	baseFlags, skillTypes := TypesToFlagsAndTypes(active.grantedEffect.GetActiveSkill().GetActiveSkillTypes())
+	activeSkill := CreateActiveSkill(&GemEffect{
+		GrantedEffect: &GrantedEffect{
+			Raw:              active.grantedEffect,
+			SkillTypes:       skillTypes,
+			MinionSkillTypes: MinionSkillTypes(active.grantedEffect.GetActiveSkill()),
+			BaseFlags:        baseFlags,
+		},
+		GemData: active.gem,
+	}, nil, nil, nil, nil)
+
+	result := make([]GemCatalogueEntry, 0)
+	for _, entry := range catalogue {
+		if entry.Support && CalcCanGrantedEffectSupportActiveSkill(&GrantedEffect{Raw: entry.grantedEffect}, activeSkill) {
+			result = append(result, entry)
+		}
+	}
+
+	return result, nil
+}
@@ -0,0 +1,144 @@
+package calculator
+
+import (
+	"testing"
+
+	"github.com/MarvinJWendt/testza"
+
+	"github.com/Vilsol/go-pob/data"
+	"github.com/Vilsol/go-pob/utils"
+)
+
+func TestSearchGems(t *testing.T) {
+	names := func(entries []GemCatalogueEntry) []string {
+		out := make([]string, len(entries))
+		for i, entry := range entries {
+			out[i] = entry.Name
+		}
+		return out
+	}
+
+	tests := []struct {
+		name     string
+		filter   GemFilter
+		expected func(t *testing.T, entries []GemCatalogueEntry)
+	}{
+		{
+			name:   "All",
+			filter: GemFilter{},
+			expected: func(t *testing.T, entries []GemCatalogueEntry) {
+				testza.AssertLen(t, entries, len(GemCatalogue()))
+			},
+		},
+		{
+			name:   "TagsAndVaal",
+			filter: GemFilter{Tags: []string{"Fire", "spell"}, Vaal: utils.Ptr(true)},
+			expected: func(t *testing.T, entries []GemCatalogueEntry) {
+				testza.AssertContains(t, names(entries), "Vaal Fireball")
+				testza.AssertNotContains(t, names(entries), "Fireball")
+				for _, entry := range entries {
+					testza.AssertTrue(t, entry.Vaal)
+					testza.AssertContains(t, entry.Tags, "elemental")
+				}
+			},
+		},
+		{
+			name:   "AwakenedSupports",
+			filter: GemFilter{Awakened: utils.Ptr(true)},
+			expected: func(t *testing.T, entries []GemCatalogueEntry) {
+				testza.AssertContains(t, names(entries), "Awakened Added Fire Damage Support")
+				for _, entry := range entries {
+					testza.AssertTrue(t, entry.Support)
+				}
+			},
+		},
+		{
+			name:   "Colour",
+			filter: GemFilter{Name: "fireball", Colours: []GemColour{GemColourBlue}, Support: utils.Ptr(false)},
+			expected: func(t *testing.T, entries []GemCatalogueEntry) {
+				testza.AssertEqual(t, []string{"Fireball", "Vaal Fireball"}, names(entries))
+			},
+		},
+		{
+			name:   "QualityVariantName",
+			filter: GemFilter{Name: "divergent added fire"},
+			expected: func(t *testing.T, entries []GemCatalogueEntry) {
+				testza.AssertEqual(t, []string{"Added Fire Damage Support"}, names(entries))
+			},
+		},
+		{
+			name:   "MinionSkillTypes",
+			filter: GemFilter{Name: "raise", SkillTypes: []data.SkillType{data.SkillTypeSpell, data.SkillTypeMelee}},
+			expected: func(t *testing.T, entries []GemCatalogueEntry) {
+				testza.AssertContains(t, names(entries), "Raise Zombie")
+			},
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			test.expected(t, SearchGems(test.filter))
+		})
+	}
+}
+
+func TestCompatibleSupports(t *testing.T) {
+	tests := []struct {
+		name     string
+		gemID    string
+		contains []string
+		excludes []string
+	}{
+		{
+			name:     "Spell",
+			gemID:    "Metadata/Items/Gems/SkillGemFireball",
+			contains: []string{"Greater Multiple Projectiles Support", "Spell Echo Support", "Inspiration Support"},
+			excludes: []string{"Multistrike Support", "Minion Damage Support"},
+		},
+		{
+			name:     "Minion",
+			gemID:    "Metadata/Items/Gems/SkillGemRaiseZombie",
+			contains: []string{"Minion Damage Support", "Multistrike Support", "Melee Physical Damage Support"},
+			excludes: []string{"Greater Multiple Projectiles Support"},
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			supports, err := CompatibleSupports(test.gemID)
+			testza.AssertNoError(t, err)
+
+			names := make([]string, len(supports))
+			for i, support := range supports {
+				testza.AssertTrue(t, support.Support)
+				names[i] = support.Name
+			}
+
+			for _, name := range test.contains {
+				testza.AssertContains(t, names, name)
+			}
+			for _, name := range test.excludes {
+				testza.AssertNotContains(t, names, name)
+			}
+		})
+	}
+
+	_, err := CompatibleSupports("Metadata/Items/Gems/SupportGemAddedFireDamage")
+	testza.AssertNotNil(t, err)
+
+	_, err = CompatibleSupports("Metadata/Items/Gems/NotAGem")
+	testza.AssertNotNil(t, err)
+}
+
+func TestNewSkillGem(t *testing.T) {
+	entry, ok := findGemCatalogueEntry("Metadata/Items/Gems/SkillGemSummonRockGolem")
+	testza.AssertTrue(t, ok)
+
+	gem := newSkillGem(entry.gem)
+	testza.AssertEqual(t, entry.Name, gem.Name)
+	testza.AssertEqual(t, entry.Tags, gem.Tags)
+	testza.AssertTrue(t, gem.IsType("golem"))
+	testza.AssertFalse(t, gem.IsType("attack"))
+
+	testza.AssertNil(t, newSkillGem(nil))
+}
@@ -0,0 +1,29 @@
+package calculator
+
+import (
+	"fmt"
+
+	"github.com/Vilsol/go-pob-data/poe"
+
+	raw2 "github.com/Vilsol/go-pob/data/raw"
+)
+
+// DescribeGrantedEffect returns the tooltip lines of the granted effect at the provided level and quality
+func DescribeGrantedEffect(grantedEffect *poe.GrantedEffect, level int, quality int, qualityID string) ([]string, error) {
+	if _, ok := raw2.GetCalculatedGrantedEffect(grantedEffect).GetCalculatedLevels()[level]; !ok {
+		return nil, fmt.Errorf("granted effect %s has no level %d", grantedEffect.ID, level)
+	}
+
+	descriptions, err := raw2.GetStatDescriptions(raw2.StatDescriptionsDefault)
+	if err != nil {
+		return nil, err
+	}
+
+	stats := CalcBuildSkillInstanceStats(&GemEffect{
+		Level:     level,
+		Quality:   quality,
+		QualityID: qualityID,
+	}, &GrantedEffect{Raw: grantedEffect})
+
+	return descriptions.Describe(stats), nil
+}
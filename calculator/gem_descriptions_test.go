@@ -0,0 +1,66 @@
+package calculator
+
+import (
+	"testing"
+
+	"github.com/Vilsol/go-pob-data/poe"
+
+	"github.com/MarvinJWendt/testza"
+)
+
+func TestDescribeGrantedEffect(t *testing.T) {
+	fireball := poe.GrantedEffectByID("Fireball")
+
+	tests := []struct {
+		name      string
+		level     int
+		quality   int
+		qualityID string
+		contains  []string
+		excludes  []string
+	}{
+		{
+			name:     "Level1",
+			level:    1,
+			contains: []string{"Deals 9 to 14 Fire Damage", "25% chance to Ignite"},
+			excludes: []string{"20% increased Projectile Speed"},
+		},
+		{
+			name:     "Quality",
+			level:    1,
+			quality:  20,
+			contains: []string{"Deals 9 to 14 Fire Damage", "20% increased Projectile Speed"},
+		},
+		{
+			name:      "AlternateQuality",
+			level:     1,
+			quality:   20,
+			qualityID: "Alternate1",
+			contains:  []string{"60% increased Area of Effect", "40% reduced Projectile Speed"},
+			excludes:  []string{"20% increased Projectile Speed"},
+		},
+		{
+			name:     "Level20",
+			level:    20,
+			contains: []string{"Deals 1640 to 2460 Fire Damage"},
+			excludes: []string{"Deals 9 to 14 Fire Damage"},
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			lines, err := DescribeGrantedEffect(fireball, test.level, test.quality, test.qualityID)
+			testza.AssertNoError(t, err)
+
+			for _, line := range test.contains {
+				testza.AssertContains(t, lines, line)
+			}
+			for _, line := range test.excludes {
+				testza.AssertNotContains(t, lines, line)
+			}
+		})
+	}
+
+	_, err := DescribeGrantedEffect(fireball, 100, 0, "")
+	testza.AssertNotNil(t, err)
+}
@@ -0,0 +1,75 @@
+package calculator
+
+import (
+	"slices"
+
+	"github.com/Vilsol/go-pob-data/poe"
+)
+
+// Quality IDs as saved in builds
+const (
+	GemQualityDefault    = "Default"
+	GemQualityAnomalous  = "Alternate1"
+	GemQualityDivergent  = "Alternate2"
+	GemQualityPhantasmal = "Alternate3"
+)
+
+type gemQualitySet struct {
+	ID     string
+	Prefix string
+}
+
+// Quality IDs in the order of their quality stat sets in the data
+var gemQualitySets = []gemQualitySet{
+	{ID: GemQualityDefault},
+	{ID: GemQualityAnomalous, Prefix: "Anomalous"},
+	{ID: GemQualityDivergent, Prefix: "Divergent"},
+	{ID: GemQualityPhantasmal, Prefix: "Phantasmal"},
+}
+
+type GemQuality struct {
+	// Quality ID as saved in builds, e.g. Default or Alternate1
+	ID string
+
+	// Name of the gem with this quality, e.g. Anomalous Fireball
+	Name string
+
+	// Stat values gained per 1% quality
+	Stats map[string]float64
+}
+
+// gemQualitySetID returns the quality stat set of the quality ID. Unknown and empty IDs use the default set.
+func gemQualitySetID(qualityID string) int {
+	return max(slices.IndexFunc(gemQualitySets, func(set gemQualitySet) bool { return set.ID == qualityID }), 0)
+}
+
+// GemQualities returns the quality variants of the granted effect that exist in the data, in quality ID order
+func GemQualities(grantedEffect *poe.GrantedEffect, name string) []GemQuality {
+	allQualities := grantedEffect.GetEffectQualityStats()
+
+	result := make([]GemQuality, 0, len(allQualities))
+	for setID, set := range gemQualitySets {
+		qualityStats, ok := allQualities[setID]
+		if !ok {
+			continue
+		}
+
+		quality := GemQuality{
+			ID:    set.ID,
+			Name:  name,
+			Stats: make(map[string]float64),
+		}
+
+		if set.Prefix != "" {
+			quality.Name = set.Prefix + " " + name
+		}
+
+		for i, stat := range qualityStats.GetStats() {
+			quality.Stats[stat.ID] += float64(qualityStats.StatsValuesPermille[i]) / 1000
+		}
+
+		result = append(result, quality)
+	}
+
+	return result
+}
@@ -0,0 +1,67 @@
+package calculator
+
+import (
+	"testing"
+
+	"github.com/Vilsol/go-pob-data/poe"
+
+	"github.com/MarvinJWendt/testza"
+)
+
+func TestGemQualities(t *testing.T) {
+	qualities := GemQualities(poe.GrantedEffectByID("Fireball"), "Fireball")
+	testza.AssertLen(t, qualities, 4)
+
+	testza.AssertEqual(t, GemQuality{
+		ID:    GemQualityDefault,
+		Name:  "Fireball",
+		Stats: map[string]float64{"base_projectile_speed_+%": 1},
+	}, qualities[0])
+
+	testza.AssertEqual(t, GemQuality{
+		ID:   GemQualityAnomalous,
+		Name: "Anomalous Fireball",
+		Stats: map[string]float64{
+			"base_projectile_speed_+%":     -2,
+			"base_skill_area_of_effect_+%": 3,
+		},
+	}, qualities[1])
+
+	testza.AssertEqual(t, "Divergent Fireball", qualities[2].Name)
+	testza.AssertEqual(t, "Phantasmal Fireball", qualities[3].Name)
+
+	// Greater Multiple Projectiles has no Phantasmal variant
+	qualities = GemQualities(poe.GrantedEffectByID("SupportGreaterMultipleProjectiles"), "Greater Multiple Projectiles Support")
+	testza.AssertLen(t, qualities, 3)
+	testza.AssertEqual(t, GemQualityDivergent, qualities[2].ID)
+}
+
+func TestCalcBuildSkillInstanceStatsQuality(t *testing.T) {
+	addedFire := &GrantedEffect{Raw: poe.GrantedEffectByID("SupportAddedFireDamage")}
+
+	tests := []struct {
+		qualityID string
+		expected  map[string]float64
+	}{
+		{qualityID: "", expected: map[string]float64{"fire_damage_+%": 10}},
+		{qualityID: GemQualityDefault, expected: map[string]float64{"fire_damage_+%": 10}},
+		{qualityID: GemQualityAnomalous, expected: map[string]float64{"fire_damage_+%": -20, "fire_dot_multiplier_+": 20}},
+		{qualityID: GemQualityDivergent, expected: map[string]float64{"skill_physical_damage_%_to_convert_to_fire": 20}},
+		{qualityID: GemQualityPhantasmal, expected: map[string]float64{}},
+	}
+
+	for _, test := range tests {
+		t.Run(test.qualityID, func(t *testing.T) {
+			stats := CalcBuildSkillInstanceStats(&GemEffect{Level: 1, Quality: 20, QualityID: test.qualityID}, addedFire)
+			for id, value := range test.expected {
+				testza.AssertEqual(t, value, stats[id], id)
+			}
+
+			for _, id := range []string{"fire_damage_+%", "fire_dot_multiplier_+", "skill_physical_damage_%_to_convert_to_fire"} {
+				if _, ok := test.expected[id]; !ok {
+					testza.AssertEqual(t, float64(0), stats[id], id)
+				}
+			}
+		})
+	}
+}
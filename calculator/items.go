@@ -0,0 +1,710 @@
+package calculator
+
+import (
+	"fmt"
+	"math"
+	"slices"
+	"strconv"
+	"strings"
+
+	"github.com/Vilsol/go-pob-data/poe"
+
+	"github.com/Vilsol/go-pob/data"
+	"github.com/Vilsol/go-pob/items"
+	"github.com/Vilsol/go-pob/mod"
+	"github.com/Vilsol/go-pob/moddb"
+	"github.com/Vilsol/go-pob/pob"
+	"github.com/Vilsol/go-pob/utils"
+)
+
+// EquippedItem is an item equipped in a slot of the active item set, or socketed in a jewel socket of the tree
+type EquippedItem struct {
+	*items.Item
+
+	ID   int
+	Slot string
+
+	// Item class ID of the base type, e.g. Two Hand Sword or Body Armour
+	Type string
+
+	// Mods applying to the player, local mods are already included in the weapon and armour data
+	ModList *moddb.ModList
+
+	// Base stats of weapons with their local mods applied, nil for other items
+	WeaponData map[string]interface{}
+
+	// Base defences of armour pieces and shields with their local mods applied, nil for other items
+	ArmourData map[string]float64
+}
+
+// Keys of weapon data that are set by mods to mark the weapon instead of overriding a stat
+var weaponDataFlags = map[string]bool{
+	"countsAsDualWielding": true,
+	"countsAsAll1H":        true,
+}
+
+// Local defence mods that increase the base defences of armour pieces, by the defences they apply to
+var localDefenceIncreases = map[string][]string{
+	"Armour":       {"Armour", "ArmourAndEvasion", "ArmourAndEnergyShield", "Defences"},
+	"Evasion":      {"Evasion", "ArmourAndEvasion", "EvasionAndEnergyShield", "Defences"},
+	"EnergyShield": {"EnergyShield", "ArmourAndEnergyShield", "EvasionAndEnergyShield", "Defences"},
+	"Ward":         {"Ward", "Defences"},
+}
+
+// newEquippedItem parses the saved item and builds the mods it grants in the slot, cluster jewels are parsed for the
+// tree version
+func newEquippedItem(saved pob.Item, slot string, treeVersion data.TreeVersion) (*EquippedItem, []string, error) {
+	item, err := items.ParseItem(saved.Text)
+	if err != nil {
+		return nil, nil, fmt.Errorf("failed to equip item %d in %s: %w", saved.ID, slot, err)
+	}
+
+	equipped := &EquippedItem{
+		Item: item,
+		ID:   saved.ID,
+		Slot: slot,
+	}
+
+	var base *poe.BaseItemType
+	if item.BaseID != "" {
+		base = poe.BaseItemTypeByIDMap[item.BaseID]
+		if base != nil && base.ItemClassesKey >= 0 && base.ItemClassesKey < len(poe.ItemClasses) {
+			equipped.Type = poe.ItemClasses[base.ItemClassesKey].ID
+		}
+	}
+
+	var clusterJewels *data.ClusterJewelData
+	if strings.HasSuffix(item.BaseName, "Cluster Jewel") {
+		if clusterJewels, err = data.TreeVersions[treeVersion].ClusterJewels(); err != nil {
+			return nil, nil, fmt.Errorf("failed to equip item %d in %s: %w", saved.ID, slot, err)
+		}
+	}
+
+	mods, errs := equipped.parseMods(clusterJewels)
+
+	if base != nil {
+		if weaponType := weaponTypeForBase(base.Key); weaponType != nil {
+			equipped.WeaponData = equipped.buildWeaponData(weaponType, &mods)
+		} else if armourType := armourTypeForBase(base.Key); armourType != nil {
+			equipped.ArmourData = equipped.buildArmourData(armourType, shieldTypeForBase(base.Key), &mods)
+		}
+	}
+
+	equipped.ModList = moddb.NewModList()
+	for _, m := range mods {
+		equipped.ModList.AddMod(m)
+	}
+
+	return equipped, errs, nil
+}
+
+// parseMods parses all mod lines of the item, returning the mods and the lines that failed to parse. The skill,
+// notables and keystone of cluster jewels are looked up in the cluster jewel data if provided.
+func (i *EquippedItem) parseMods(clusterJewels *data.ClusterJewelData) ([]mod.Mod, []string) {
+	source := mod.Source("Item:" + strconv.Itoa(i.ID) + ":" + i.Title)
+	slotNum := slotNumber(i.Slot)
+
+	mods := make([]mod.Mod, 0)
+	errs := make([]string, 0)
+	for _, lines := range [][]items.ItemMod{i.Enchants, i.Implicits, i.Explicits} {
+		if clusterJewels != nil {
+			var clusterMods []mod.Mod
+			clusterMods, lines = clusterJewelMods(clusterJewels, lines)
+			for _, m := range clusterMods {
+				mods = append(mods, m.Source(source))
+			}
+		}
+
+		for _, line := range lines {
+			lineMods, extra := parseMod(line.Line, 1)
+			if lineMods != nil && extra != "" {
+				lineMods, extra = parseMod(line.Line, 2)
+			}
+
+			if strings.Trim(extra, " ") != "" {
+				errs = append(errs, "Error parsing Item ("+i.Title+") mod: "+extra+", mod text: "+line.Line)
+			}
+
+			for _, m := range lineMods {
+				if m = i.slotMod(m, slotNum); m != nil {
+					mods = append(mods, m.Source(source))
+				}
+			}
+		}
+	}
+
+	return mods, errs
+}
+
+// clusterJewelMods parses the lines selecting the skill, notables or keystone of a cluster jewel, which depend on the
+// tree version. Returns the mods and the remaining lines.
+func clusterJewelMods(clusterJewels *data.ClusterJewelData, lines []items.ItemMod) ([]mod.Mod, []items.ItemMod) {
+	mods := make([]mod.Mod, 0)
+	remaining := make([]items.ItemMod, 0, len(lines))
+
+	// Skills with multiple stats have one enchant line per stat
+	skillLines := make([]string, 0)
+	skillRemaining := make([]items.ItemMod, 0)
+	for _, line := range lines {
+		lineLower := strings.ToLower(line.Line)
+		if strings.HasPrefix(lineLower, strings.ToLower(data.ClusterJewelSkillPrefix)) {
+			skillLines = append(skillLines, lineLower)
+			skillRemaining = append(skillRemaining, line)
+			continue
+		}
+
+		if notable, ok := clusterJewels.NotableLines[lineLower]; ok {
+			mods = append(mods, MOD("ClusterJewelNotable", "LIST", notable))
+		} else if keystone, ok := clusterJewels.KeystoneLines[lineLower]; ok {
+			mods = append(mods, MOD("JewelData", "LIST", mod.JewelData{Key: "clusterJewelKeystone", Value: keystone}))
+		} else {
+			remaining = append(remaining, line)
+		}
+	}
+
+	if skillID, ok := clusterJewels.SkillLines[strings.Join(skillLines, " ")]; ok {
+		mods = append(mods, MOD("JewelData", "LIST", mod.JewelData{Key: "clusterJewelSkill", Value: skillID}))
+	} else {
+		remaining = append(remaining, skillRemaining...)
+	}
+
+	return mods, remaining
+}
+
+// slotMod returns a copy of the mod with the slot placeholders of its tags filled in, or nil if the mod does not
+// apply in the slot
+func (i *EquippedItem) slotMod(m mod.Mod, slotNum int) mod.Mod {
+	hand := "MainHand"
+	if slotNum == 2 {
+		hand = "OffHand"
+	}
+
+	tags := make([]mod.Tag, 0, len(m.Tags()))
+	for _, tag := range m.Tags() {
+		switch tag := tag.(type) {
+		case *mod.InSlotTag:
+			if tag.N != slotNum {
+				return nil
+			}
+		case *mod.SocketedInTag:
+			if tag.SlotName == "{SlotName}" {
+				tags = append(tags, mod.SocketedIn(i.Slot).Keyword(tag.TagKeyword))
+				continue
+			}
+		case *mod.ConditionTag:
+			varList := make([]string, len(tag.VarList))
+			for j, v := range tag.VarList {
+				varList[j] = strings.ReplaceAll(v, "{Hand}", hand)
+			}
+			tags = append(tags, mod.Condition(varList...).Neg(tag.Negative))
+			continue
+		}
+		tags = append(tags, tag)
+	}
+
+	out := m.Clone()
+	out.ClearTags()
+	return out.Tag(tags...)
+}
+
+// buildWeaponData applies the local mods of the weapon to its base stats
+func (i *EquippedItem) buildWeaponData(weaponType *poe.WeaponType, mods *[]mod.Mod) map[string]interface{} {
+	weaponData := map[string]interface{}{
+		"type": string(weaponClassName(i.Type)),
+		"name": i.Title,
+	}
+
+	attackSpeedInc := sumLocal(mods, "Speed", mod.TypeIncrease, mod.MFlagAttack)
+	attackRate := utils.RoundTo(1000/float64(weaponType.Speed)*(1+attackSpeedInc/100), 2)
+	weaponData["AttackSpeedInc"] = attackSpeedInc
+	weaponData["AttackRate"] = attackRate
+
+	rangeBonus := sumLocal(mods, "WeaponRange", mod.TypeBase, 0)
+	weaponData["rangeBonus"] = rangeBonus
+	weaponData["range"] = float64(weaponType.RangeMax) + rangeBonus
+
+	for _, damageType := range data.DamageType("").Values() {
+		minDamage := sumLocal(mods, string(damageType)+"Min", mod.TypeBase, 0)
+		maxDamage := sumLocal(mods, string(damageType)+"Max", mod.TypeBase, 0)
+		if damageType == data.DamageTypePhysical {
+			physInc := sumLocal(mods, "PhysicalDamage", mod.TypeIncrease, 0) + float64(i.Quality)
+			minDamage = math.Round((minDamage + float64(weaponType.DamageMin)) * (1 + physInc/100))
+			maxDamage = math.Round((maxDamage + float64(weaponType.DamageMax)) * (1 + physInc/100))
+		}
+
+		if minDamage > 0 && maxDamage > 0 {
+			weaponData[string(damageType)+"Min"] = minDamage
+			weaponData[string(damageType)+"Max"] = maxDamage
+			weaponData[string(damageType)+"DPS"] = (minDamage + maxDamage) / 2 * attackRate
+		}
+	}
+
+	critInc := sumLocal(mods, "CritChance", mod.TypeIncrease, 0)
+	weaponData["CritChance"] = utils.RoundTo(float64(weaponType.Critical)/100*(1+critInc/100), 2)
+
+	for _, value := range listLocal(mods, "WeaponData") {
+		weaponDataMod := value.(mod.WeaponData)
+		if weaponDataFlags[weaponDataMod.Key] {
+			weaponData[weaponDataMod.Key] = weaponDataMod.Value != 0
+		} else {
+			weaponData[weaponDataMod.Key] = weaponDataMod.Value
+		}
+	}
+
+	return weaponData
+}
+
+// buildArmourData applies the local mods of the armour piece or shield to its base defences
+func (i *EquippedItem) buildArmourData(armourType *poe.ArmourType, shieldType *poe.ShieldType, mods *[]mod.Mod) map[string]float64 {
+	bases := map[string]int{
+		"Armour":       armourType.ArmourMax,
+		"Evasion":      armourType.EvasionMax,
+		"EnergyShield": armourType.EnergyShieldMax,
+		"Ward":         armourType.WardMax,
+	}
+
+	// Hybrid increases apply to multiple defences, so they are all summed up front
+	increases := make(map[string]float64)
+	for _, name := range []string{"Armour", "Evasion", "EnergyShield", "Ward", "ArmourAndEvasion", "ArmourAndEnergyShield", "EvasionAndEnergyShield", "Defences"} {
+		increases[name] = sumLocal(mods, name, mod.TypeIncrease, 0)
+	}
+
+	armourData := make(map[string]float64)
+	for _, defence := range []string{"Armour", "Evasion", "EnergyShield", "Ward"} {
+		inc := float64(i.Quality)
+		for _, name := range localDefenceIncreases[defence] {
+			inc += increases[name]
+		}
+
+		base := float64(bases[defence]) + sumLocal(mods, defence, mod.TypeBase, 0)
+		armourData[defence] = math.Round(base * (1 + inc/100))
+	}
+
+	if shieldType != nil {
+		armourData["BlockChance"] = float64(shieldType.Block) + sumLocal(mods, "BlockChance", mod.TypeBase, 0)
+	}
+
+	for _, value := range listLocal(mods, "ArmourData") {
+		armourDataMod := value.(mod.ArmourData)
+		armourData[armourDataMod.Key] = armourDataMod.Value
+	}
+
+	return armourData
+}
+
+// sumLocal removes the local mods matching exactly from the list and returns their total value. Mods are local if
+// they have no keyword flags and no tags other than the slot they apply in.
+func sumLocal(mods *[]mod.Mod, name string, modType mod.Type, flags mod.MFlag) float64 {
+	result := 0.0
+	kept := (*mods)[:0]
+	for _, m := range *mods {
+		if m.Name() == name && m.Type() == modType && m.Flags() == flags && isLocalMod(m) {
+			result += m.Value().Float()
+			continue
+		}
+		kept = append(kept, m)
+	}
+	*mods = kept
+	return result
+}
+
+// listLocal removes the local list mods with the name from the list and returns their values
+func listLocal(mods *[]mod.Mod, name string) []interface{} {
+	result := make([]interface{}, 0)
+	kept := (*mods)[:0]
+	for _, m := range *mods {
+		if m.Name() == name && m.Type() == mod.TypeList && isLocalMod(m) {
+			result = append(result, m.Value().List())
+			continue
+		}
+		kept = append(kept, m)
+	}
+	*mods = kept
+	return result
+}
+
+func isLocalMod(m mod.Mod) bool {
+	if m.KeywordFlags() != 0 {
+		return false
+	}
+
+	if len(m.Tags()) == 0 {
+		return true
+	}
+
+	_, inSlot := m.Tags()[0].(*mod.InSlotTag)
+	return inSlot
+}
+
+// weaponClassName returns the weapon type of the item class, rune daggers and warstaves count as daggers and staves
+func weaponClassName(itemClass string) data.ItemClassName {
+	switch data.ItemClassName(itemClass) {
+	case data.RuneDagger:
+		return data.Dagger
+	case data.Warstaff:
+		return data.Staff
+	}
+	return data.ItemClassName(itemClass)
+}
+
+func weaponTypeForBase(baseKey int) *poe.WeaponType {
+	for _, weaponType := range poe.WeaponTypes {
+		if weaponType.BaseItemTypesKey == baseKey {
+			return weaponType
+		}
+	}
+	return nil
+}
+
+func armourTypeForBase(baseKey int) *poe.ArmourType {
+	for _, armourType := range poe.ArmourTypes {
+		if armourType.BaseItemTypesKey == baseKey {
+			return armourType
+		}
+	}
+	return nil
+}
+
+func shieldTypeForBase(baseKey int) *poe.ShieldType {
+	for _, shieldType := range poe.ShieldTypes {
+		if shieldType.BaseItemTypesKey == baseKey {
+			return shieldType
+		}
+	}
+	return nil
+}
+
+// slotNumber returns the number of the slot, e.g. 2 for Weapon 2 and Ring 2, and 1 for all other slots
+func slotNumber(slot string) int {
+	if strings.HasSuffix(slot, " 2") {
+		return 2
+	}
+	return 1
+}
+
+// jewelRadiusIndex returns the radius index of the jewel radius label, or 0 if the jewel has no radius
+func jewelRadiusIndex(radius string) int {
+	switch radius {
+	case "Small":
+		return data.JewelRadiusSmall
+	case "Medium":
+		return data.JewelRadiusMedium
+	case "Large":
+		return data.JewelRadiusLarge
+	case "Variable":
+		return data.JewelRadiusSmallRing
+	}
+	return 0
+}
+
+// isFlask returns whether the item class is a flask, e.g. Life Flask or Utility Flask
+func (i *EquippedItem) isFlask() bool {
+	return strings.HasSuffix(i.Type, "Flask")
+}
+
+// isJewel returns whether the item class is a jewel, including abyss jewels
+func (i *EquippedItem) isJewel() bool {
+	return i.Type == "Jewel" || i.Type == "AbyssJewel"
+}
+
+// abyssalSocketCount returns the amount of abyssal sockets of the item
+func (i *EquippedItem) abyssalSocketCount() int {
+	count := 0
+	for _, socket := range i.Sockets {
+		if socket.Colour == "A" {
+			count++
+		}
+	}
+	return count
+}
+
+// activeItemSet returns the active item set of the build, or nil if the build has none
+func activeItemSet(build *pob.PathOfBuilding) *pob.ItemSet {
+	if len(build.Items.ItemSets) == 0 {
+		return nil
+	}
+
+	index := min(max(build.Items.ActiveItemSet, 1), len(build.Items.ItemSets)) - 1
+	return &build.Items.ItemSets[index]
+}
+
+// activeSpec returns the active tree spec of the build, or nil if the build has none
+func activeSpec(build *pob.PathOfBuilding) *pob.Spec {
+	if build.Tree.ActiveSpec < 1 || build.Tree.ActiveSpec > len(build.Tree.Specs) {
+		return nil
+	}
+	return &build.Tree.Specs[build.Tree.ActiveSpec-1]
+}
+
+func savedItemsByID(build *pob.PathOfBuilding) map[int]pob.Item {
+	out := make(map[int]pob.Item, len(build.Items.Items))
+	for _, saved := range build.Items.Items {
+		out[saved.ID] = saved
+	}
+	return out
+}
+
+// socketTreeJewels parses the jewels in allocated sockets of the active spec of the build. Cluster, timeless and
+// intuitive leap jewels are socketed into the passive spec, as they change which nodes are allocated. Returns the
+// jewels and the errors of jewels that failed to parse or socket.
+func (p *PassiveSpec) socketTreeJewels() ([]*EquippedItem, []string) {
+	spec := activeSpec(p.Build)
+	if spec == nil {
+		return nil, nil
+	}
+
+	savedItems := savedItemsByID(p.Build)
+	jewels := make([]*EquippedItem, 0, len(spec.Sockets))
+	errs := make([]string, 0)
+	for _, socket := range spec.Sockets {
+		saved, ok := savedItems[socket.ItemID]
+		if !ok {
+			continue
+		}
+
+		if _, ok := p.AllocNodes[strconv.FormatInt(socket.NodeID, 10)]; !ok {
+			continue
+		}
+
+		jewel, parseErrs, err := newEquippedItem(saved, "Jewel "+strconv.FormatInt(socket.NodeID, 10), p.TreeVersion)
+		if err != nil {
+			errs = append(errs, err.Error())
+			continue
+		}
+		errs = append(errs, parseErrs...)
+
+		if err := p.socketTreeJewel(socket.NodeID, jewel); err != nil {
+			errs = append(errs, err.Error())
+		}
+
+		jewels = append(jewels, jewel)
+	}
+
+	return jewels, errs
+}
+
+// socketTreeJewel sockets the jewel into the passive spec if it changes the tree
+func (p *PassiveSpec) socketTreeJewel(socketID int64, jewel *EquippedItem) error {
+	if strings.HasSuffix(jewel.BaseName, "Cluster Jewel") {
+		return p.SocketClusterJewel(socketID, jewel.BaseName, jewel.ModList)
+	}
+
+	for _, value := range jewel.ModList.List(nil, "JewelData") {
+		switch value.(mod.JewelData).Key {
+		case "conqueredBy":
+			return p.SocketTimelessJewel(socketID, jewel.ModList)
+		case "intuitiveLeapLike", "impossibleEscapeKeystone":
+			return p.SocketIntuitiveLeapJewel(socketID, jewelRadiusIndex(jewel.Radius), jewel.ModList)
+		}
+	}
+
+	return nil
+}
+
+// buildItemModList equips the items of the active item set and the jewels of the tree, merging their mods into the
+// item mod database and registering the radius jewels
+func (env *Environment) buildItemModList(build *pob.PathOfBuilding, treeJewels []*EquippedItem) {
+	for _, jewel := range treeJewels {
+		socketID, _ := strconv.ParseInt(strings.TrimPrefix(jewel.Slot, "Jewel "), 10, 64)
+		if _, ok := env.AllocatedNodes[strconv.FormatInt(socketID, 10)]; !ok {
+			continue
+		}
+
+		radiusIndex := jewelRadiusIndex(jewel.Radius)
+		for _, value := range jewel.ModList.List(nil, "JewelData") {
+			if jewelData := value.(mod.JewelData); jewelData.Key == "radiusIndex" {
+				radiusIndex = jewelDataInt(jewelData.Value)
+			}
+		}
+
+		if radiusIndex > 0 {
+			env.addRadiusJewel(socketID, radiusIndex, jewel.ModList)
+		}
+
+		env.addItem(jewel)
+	}
+
+	itemSet := activeItemSet(build)
+	if itemSet == nil {
+		return
+	}
+
+	savedItems := savedItemsByID(build)
+	weaponSet := activeWeaponSet(build)
+
+	// Abyss jewels are equipped after the items they are socketed in
+	slotItems := make(map[string]*EquippedItem)
+	slots := make([]string, 0, len(itemSet.Slots))
+	abyssSlots := make([]string, 0)
+	for _, slot := range itemSet.Slots {
+		if slot.ItemID == 0 {
+			continue
+		}
+
+		if set := slotWeaponSet(slot.Name); set != 0 && set != weaponSet {
+			continue
+		}
+
+		saved, ok := savedItems[slot.ItemID]
+		if !ok {
+			continue
+		}
+
+		// Items of the second weapon set are equipped in the regular weapon slots
+		slotName := activeSlotName(slot.Name)
+
+		item, errs, err := newEquippedItem(saved, slotName, env.Spec.TreeVersion)
+		if err != nil {
+			env.DebugErrors = append(env.DebugErrors, err.Error())
+			continue
+		}
+		env.DebugErrors = append(env.DebugErrors, errs...)
+
+		// TODO Flasks
+		if item.isFlask() {
+			continue
+		}
+
+		slotItems[slotName] = item
+		if strings.Contains(slotName, " Abyssal Socket ") {
+			abyssSlots = append(abyssSlots, slotName)
+		} else {
+			slots = append(slots, slotName)
+		}
+	}
+
+	// Two handed weapons leave the off hand empty, unless it holds a quiver for a bow
+	// TODO Dual wielding two handed weapons
+	if weapon1, weapon2 := slotItems["Weapon 1"], slotItems["Weapon 2"]; weapon1 != nil && weapon2 != nil {
+		if weapon1.isTwoHanded() && (weapon1.Type != string(data.Bow) || weapon2.Type != string(data.Quiver)) {
+			delete(slotItems, "Weapon 2")
+		}
+	}
+
+	for _, slotName := range append(slots, abyssSlots...) {
+		item, ok := slotItems[slotName]
+		if !ok {
+			continue
+		}
+
+		if parentSlot, socketNum, ok := strings.Cut(slotName, " Abyssal Socket "); ok {
+			parent := env.Player.ItemList[parentSlot]
+			if parent == nil || parent.abyssalSocketCount() < utils.Int(socketNum) {
+				continue
+			}
+		}
+
+		env.addItem(item)
+	}
+}
+
+// activeWeaponSet returns the weapon set (1 or 2) the build is currently using
+func activeWeaponSet(build *pob.PathOfBuilding) int {
+	useSecond := build.Items.UseSecondWeaponSet
+	if itemSet := activeItemSet(build); itemSet != nil && itemSet.UseSecondWeaponSet != nil {
+		useSecond = itemSet.UseSecondWeaponSet
+	}
+
+	if useSecond != nil && *useSecond {
+		return 2
+	}
+
+	return 1
+}
+
+// slotWeaponSet returns the weapon set the slot belongs to, or 0 if it is not a weapon slot
+func slotWeaponSet(slotName string) int {
+	if !strings.HasPrefix(slotName, "Weapon ") {
+		return 0
+	}
+
+	if strings.Contains(slotName, "Swap") {
+		return 2
+	}
+
+	return 1
+}
+
+// activeSlotName maps a slot of the second weapon set to the matching slot of the first
+func activeSlotName(slotName string) string {
+	slotName = strings.Replace(slotName, " Swap", "", 1)
+	return strings.Replace(slotName, "Swap", "", 1)
+}
+
+// isTwoHanded returns whether the item is a weapon that takes up both hands
+func (i *EquippedItem) isTwoHanded() bool {
+	if i.WeaponData == nil {
+		return false
+	}
+
+	info, ok := data.WeaponTypes[weaponClassName(i.Type)]
+	return ok && !info.OneHand
+}
+
+// addItem adds the equipped item to the item list of the player and merges its mods into the item mod database
+func (env *Environment) addItem(item *EquippedItem) {
+	env.Player.ItemList[item.Slot] = item
+
+	/*
+		TODO Special handling of Necromantic Aegis, Energy Blade, The Iron Mass and The Dancing Dervish
+		TODO Scaling of socketed jewel effect
+	*/
+	env.ItemModDB.AddList(item.ModList)
+
+	if item.Type == "AbyssJewel" {
+		baseName := strings.ReplaceAll(item.BaseName, " ", "")
+		cond := "Have" + baseName
+		if !env.ItemModDB.Conditions[cond] {
+			env.ItemModDB.Conditions[cond] = true
+			env.ItemModDB.Multipliers["AbyssJewelType"]++
+		}
+		if parentSlot, _, ok := strings.Cut(item.Slot, " Abyssal Socket "); ok {
+			env.ItemModDB.Conditions[cond+"In"+parentSlot] = true
+		}
+		env.ItemModDB.Multipliers["AbyssJewel"]++
+		env.ItemModDB.Multipliers[baseName]++
+	}
+
+	if item.isJewel() {
+		return
+	}
+
+	// Update item counts
+	var key string
+	switch item.Rarity {
+	case items.RarityUnique, items.RarityRelic:
+		key = "UniqueItem"
+	case items.RarityRare:
+		key = "RareItem"
+	case items.RarityMagic:
+		key = "MagicItem"
+	default:
+		key = "NormalItem"
+	}
+	env.ItemModDB.Multipliers[key]++
+	env.ItemModDB.Conditions[key+"In"+item.Slot] = true
+
+	if item.Corrupted {
+		env.ItemModDB.Multipliers["CorruptedItem"]++
+	} else {
+		env.ItemModDB.Multipliers["NonCorruptedItem"]++
+	}
+
+	shaper := slices.Contains(item.Influences, items.InfluenceShaper)
+	elder := slices.Contains(item.Influences, items.InfluenceElder)
+	if shaper {
+		env.ItemModDB.Multipliers["ShaperItem"]++
+		env.ItemModDB.Conditions["ShaperItemIn"+item.Slot] = true
+	} else {
+		env.ItemModDB.Multipliers["NonShaperItem"]++
+	}
+	if elder {
+		env.ItemModDB.Multipliers["ElderItem"]++
+		env.ItemModDB.Conditions["ElderItemIn"+item.Slot] = true
+	} else {
+		env.ItemModDB.Multipliers["NonElderItem"]++
+	}
+	if shaper || elder {
+		env.ItemModDB.Multipliers["ShaperOrElderItem"]++
+	}
+}
@@ -0,0 +1,328 @@
+package calculator
+
+import (
+	"context"
+	"os"
+	"strconv"
+	"testing"
+
+	"github.com/MarvinJWendt/testza"
+	"github.com/Vilsol/go-pob-data/poe"
+
+	"github.com/Vilsol/go-pob/builds"
+	"github.com/Vilsol/go-pob/cache"
+	"github.com/Vilsol/go-pob/data"
+	"github.com/Vilsol/go-pob/data/raw"
+	"github.com/Vilsol/go-pob/items"
+	"github.com/Vilsol/go-pob/mod"
+	"github.com/Vilsol/go-pob/moddb"
+	"github.com/Vilsol/go-pob/pob"
+	"github.com/Vilsol/go-pob/utils"
+)
+
+const testWeapon = `Rarity: RARE
+Doom Edge
+Tiger Sword
+Quality: 20
+Implicits: 0
++1 to Level of Socketed Gems
+Adds 10 to 20 Physical Damage
+50% increased Physical Damage
+10% increased Attack Speed
+20% increased Critical Strike Chance`
+
+const testBodyArmour = `Rarity: RARE
+Woe Shell
+Vaal Regalia
+Quality: 20
+Implicits: 0
++98 to maximum Energy Shield
+50% increased Energy Shield
++55 to maximum Life`
+
+func equipTestItems(t *testing.T, build *pob.PathOfBuilding, slotItems map[string]string) {
+	t.Helper()
+
+	build.Items.ActiveItemSet = 1
+	build.Items.ItemSets = []pob.ItemSet{{ID: "1"}}
+	for slot, text := range slotItems {
+		build.Items.ItemSets[0].Slots = append(build.Items.ItemSets[0].Slots, pob.Slot{
+			Name:   slot,
+			ItemID: build.AddItem(text),
+		})
+	}
+}
+
+func TestInitEnvItems(t *testing.T) {
+	testza.AssertNoError(t, poe.InitializeAll(context.Background(), raw.LatestVersion, cache.Disk(), nil))
+
+	file, err := os.ReadFile("../testdata/builds/Fireball.xml")
+	testza.AssertNoError(t, err)
+
+	build, err := builds.ParseBuild(file)
+	testza.AssertNoError(t, err)
+
+	equipTestItems(t, build, map[string]string{
+		"Weapon 1":    testWeapon,
+		"Body Armour": testBodyArmour,
+	})
+
+	env, _, _, _ := InitEnv(build, &EnvironmentCache{}, OutputModeMain)
+
+	weapon := env.Player.ItemList["Weapon 1"]
+	testza.AssertNotNil(t, weapon)
+	testza.AssertEqual(t, "Two Hand Sword", weapon.Type)
+
+	// (54 + 10) to (89 + 20) with 50% increased Physical Damage and 20% quality
+	testza.AssertEqual(t, "Two Hand Sword", env.Player.WeaponData1["type"])
+	testza.AssertEqual(t, 109.0, env.Player.WeaponData1["PhysicalMin"])
+	testza.AssertEqual(t, 185.0, env.Player.WeaponData1["PhysicalMax"])
+	testza.AssertEqual(t, 1.54, env.Player.WeaponData1["AttackRate"])
+	testza.AssertEqual(t, 6.0, env.Player.WeaponData1["CritChance"])
+	testza.AssertLen(t, env.Player.WeaponData2, 0)
+
+	// (197 + 98) with 50% increased Energy Shield and 20% quality
+	testza.AssertEqual(t, 502.0, env.Player.ItemList["Body Armour"].ArmourData["EnergyShield"])
+
+	// Local mods only apply to the item itself
+	testza.AssertEqual(t, 55.0, env.ItemModDB.Sum(mod.TypeBase, nil, "Life"))
+	testza.AssertEqual(t, 0.0, env.ItemModDB.Sum(mod.TypeBase, nil, "EnergyShield", "PhysicalMin"))
+	testza.AssertEqual(t, 0.0, env.ItemModDB.Sum(mod.TypeIncrease, nil, "EnergyShield", "PhysicalDamage", "CritChance"))
+
+	// Socketed gem mods only apply to skills socketed in the item
+	testza.AssertLen(t, env.ModDB.List(&moddb.ListCfg{SlotName: "Weapon 1"}, "GemProperty"), 1)
+	testza.AssertLen(t, env.ModDB.List(&moddb.ListCfg{SlotName: "Body Armour"}, "GemProperty"), 0)
+	testza.AssertEqual(t, 2.0, env.ModDB.GetMultiplier("RareItem", nil, false))
+	testza.AssertTrue(t, env.ModDB.Conditions["RareItemInWeapon 1"])
+
+	for _, m := range weapon.ModList.Mods() {
+		testza.AssertEqual(t, mod.Source("Item:"+strconv.Itoa(weapon.ID)+":Doom Edge"), m.GetSource())
+		for _, tag := range m.Tags() {
+			if socketedIn, ok := tag.(*mod.SocketedInTag); ok {
+				testza.AssertEqual(t, "Weapon 1", socketedIn.SlotName)
+			}
+		}
+	}
+}
+
+func TestInitEnvUnarmed(t *testing.T) {
+	testza.AssertNoError(t, poe.InitializeAll(context.Background(), raw.LatestVersion, cache.Disk(), nil))
+
+	file, err := os.ReadFile("../testdata/builds/Fireball.xml")
+	testza.AssertNoError(t, err)
+
+	build, err := builds.ParseBuild(file)
+	testza.AssertNoError(t, err)
+
+	equipTestItems(t, build, map[string]string{
+		"Body Armour": testBodyArmour,
+	})
+
+	env, _, _, _ := InitEnv(build, &EnvironmentCache{}, OutputModeMain)
+	testza.AssertEqual(t, "None", env.Player.WeaponData1["type"])
+	testza.AssertEqual(t, 1.0, env.ModDB.GetMultiplier("RareItem", nil, false))
+}
+
+func TestInitEnvTreeJewels(t *testing.T) {
+	testza.AssertNoError(t, poe.InitializeAll(context.Background(), raw.LatestVersion, cache.Disk(), nil))
+
+	file, err := os.ReadFile("../testdata/builds/Fireball-full.xml")
+	testza.AssertNoError(t, err)
+
+	build, err := builds.ParseBuild(file)
+	testza.AssertNoError(t, err)
+
+	env, _, _, _ := InitEnv(build, &EnvironmentCache{}, OutputModeMain)
+
+	// The large cluster jewel in socket 32763 allocates the saved nodes of its subgraph
+	testza.AssertNotNil(t, env.Spec.SubGraphs["32763"])
+	_, ok := env.AllocatedNodes["65824"]
+	testza.AssertTrue(t, ok)
+	testza.AssertNotNil(t, env.Player.ItemList["Jewel 32763"])
+	testza.AssertNotZero(t, len(env.RadiusJewelList))
+
+	// Paths through the subgraph of the cluster jewel need the jewels socketed outside of the environment as well
+	testza.AssertEqual(t, env.Spec.GraphExtensions(), newSocketedPassiveSpec(build, data.LatestTreeVersion).GraphExtensions())
+	testza.AssertNotZero(t, len(env.Spec.GraphExtensions()))
+}
+
+func TestClusterJewelMods(t *testing.T) {
+	testza.AssertNoError(t, poe.InitializeAll(context.Background(), raw.LatestVersion, cache.Disk(), nil))
+
+	clusterJewels, err := data.TreeVersions[data.LatestTreeVersion].ClusterJewels()
+	testza.AssertNoError(t, err)
+
+	tests := []struct {
+		name      string
+		lines     []string
+		expected  []mod.Mod
+		remaining []string
+	}{
+		{
+			name:     "Skill",
+			lines:    []string{"Added Small Passive Skills grant: 12% increased Fire Damage"},
+			expected: []mod.Mod{MOD("JewelData", "LIST", mod.JewelData{Key: "clusterJewelSkill", Value: "affliction_fire_damage"})},
+		},
+		{
+			name: "MultiLineSkill",
+			lines: []string{
+				"Added Small Passive Skills grant: 12% increased Trap Damage",
+				"Added Small Passive Skills grant: 12% increased Mine Damage",
+			},
+			expected: []mod.Mod{MOD("JewelData", "LIST", mod.JewelData{Key: "clusterJewelSkill", Value: "affliction_trap_and_mine_damage"})},
+		},
+		{
+			name:      "UnknownSkill",
+			lines:     []string{"Added Small Passive Skills grant: 12% increased Mine Damage"},
+			expected:  []mod.Mod{},
+			remaining: []string{"Added Small Passive Skills grant: 12% increased Mine Damage"},
+		},
+		{
+			name:     "Notable",
+			lines:    []string{"1 Added Passive Skill is Cooked Alive"},
+			expected: []mod.Mod{MOD("ClusterJewelNotable", "LIST", "Cooked Alive")},
+		},
+		{
+			name:     "Keystone",
+			lines:    []string{"Adds Kineticism"},
+			expected: []mod.Mod{MOD("JewelData", "LIST", mod.JewelData{Key: "clusterJewelKeystone", Value: "Kineticism"})},
+		},
+		{
+			name:      "OtherLines",
+			lines:     []string{"Adds 8 Passive Skills", "Adds 10 to 20 Fire Damage", "1 Added Passive Skill is a Jewel Socket"},
+			expected:  []mod.Mod{},
+			remaining: []string{"Adds 8 Passive Skills", "Adds 10 to 20 Fire Damage", "1 Added Passive Skill is a Jewel Socket"},
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			lines := make([]items.ItemMod, len(test.lines))
+			for i, line := range test.lines {
+				lines[i] = items.ItemMod{Line: line}
+			}
+
+			mods, remaining := clusterJewelMods(clusterJewels, lines)
+			testza.AssertEqual(t, test.expected, mods)
+
+			var remainingLines []string
+			for _, line := range remaining {
+				remainingLines = append(remainingLines, line.Line)
+			}
+			testza.AssertEqual(t, test.remaining, remainingLines)
+		})
+	}
+}
+
+const testOneHandSword = `Rarity: NORMAL
+Corsair Sword`
+
+const testShield = `Rarity: NORMAL
+Titanium Spirit Shield`
+
+func TestInitEnvWeaponSets(t *testing.T) {
+	file, err := os.ReadFile("../testdata/builds/Fireball.xml")
+	testza.AssertNoError(t, err)
+
+	build, err := builds.ParseBuild(file)
+	testza.AssertNoError(t, err)
+
+	equipTestItems(t, build, map[string]string{
+		"Weapon 1":      testWeapon,
+		"Weapon 2":      testShield,
+		"Weapon 1 Swap": testOneHandSword,
+		"Weapon 2 Swap": testShield,
+	})
+
+	// Two handed weapons leave no room for the shield
+	env, _, _, _ := InitEnv(build, &EnvironmentCache{}, OutputModeMain)
+	testza.AssertEqual(t, "Two Hand Sword", env.Player.ItemList["Weapon 1"].Type)
+	testza.AssertNil(t, env.Player.ItemList["Weapon 2"])
+
+	build.Items.ItemSets[0].UseSecondWeaponSet = utils.Ptr(true)
+
+	env, _, _, _ = InitEnv(build, &EnvironmentCache{}, OutputModeMain)
+	testza.AssertEqual(t, "One Hand Sword", env.Player.ItemList["Weapon 1"].Type)
+	testza.AssertEqual(t, "Weapon 1", env.Player.ItemList["Weapon 1"].Slot)
+	testza.AssertEqual(t, "Shield", env.Player.ItemList["Weapon 2"].Type)
+	testza.AssertEqual(t, "One Hand Sword", env.Player.WeaponData1["type"])
+	testza.AssertLen(t, env.Player.WeaponData2, 0)
+}
+
+func TestWeaponConditions(t *testing.T) {
+	tests := []struct {
+		Name       string
+		Items      map[string]string
+		Conditions []string
+		Missing    []string
+	}{
+		{
+			Name:       "Unarmed",
+			Items:      map[string]string{},
+			Conditions: []string{"Unarmed", "Unencumbered", "OffHandIsEmpty"},
+			Missing:    []string{"DualWielding", "UsingShield", "UsingMeleeWeapon"},
+		},
+		{
+			Name:       "TwoHanded",
+			Items:      map[string]string{"Weapon 1": testWeapon},
+			Conditions: []string{"UsingSword", "UsingMeleeWeapon", "UsingTwoHandedWeapon", "OffHandIsEmpty"},
+			Missing:    []string{"Unarmed", "DualWielding", "UsingOneHandedWeapon"},
+		},
+		{
+			Name:       "Shield",
+			Items:      map[string]string{"Weapon 1": testOneHandSword, "Weapon 2": testShield},
+			Conditions: []string{"UsingSword", "UsingOneHandedWeapon", "UsingShield"},
+			Missing:    []string{"DualWielding", "OffHandIsEmpty"},
+		},
+		{
+			Name:       "DualWielding",
+			Items:      map[string]string{"Weapon 1": testOneHandSword, "Weapon 2": testOneHandSword},
+			Conditions: []string{"UsingSword", "UsingOneHandedWeapon", "DualWielding"},
+			Missing:    []string{"UsingShield", "DualWieldingClaws", "WieldingDifferentWeaponTypes"},
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.Name, func(t *testing.T) {
+			file, err := os.ReadFile("../testdata/builds/Fireball.xml")
+			testza.AssertNoError(t, err)
+
+			build, err := builds.ParseBuild(file)
+			testza.AssertNoError(t, err)
+
+			equipTestItems(t, build, test.Items)
+
+			calculator := &Calculator{PoB: build}
+			env := calculator.BuildOutput(OutputModeMain)
+
+			for _, condition := range test.Conditions {
+				testza.AssertTrue(t, env.Player.ModDB.Conditions[condition], condition)
+			}
+			for _, condition := range test.Missing {
+				testza.AssertFalse(t, env.Player.ModDB.Conditions[condition], condition)
+			}
+		})
+	}
+}
+
+func TestGetWeaponFlags(t *testing.T) {
+	sword := map[string]interface{}{"type": "One Hand Sword"}
+	all1H := map[string]interface{}{"type": "One Hand Sword", "countsAsAll1H": true}
+
+	flags, info := getWeaponFlags(nil, sword, nil)
+	testza.AssertTrue(t, flags&mod.MFlagSword != 0)
+	testza.AssertNotNil(t, info)
+
+	flags, _ = getWeaponFlags(nil, sword, [][]data.ItemClassName{{data.OneHandSword}, nil})
+	testza.AssertTrue(t, flags&mod.MFlagSword != 0)
+
+	flags, info = getWeaponFlags(nil, sword, [][]data.ItemClassName{{data.Bow}})
+	testza.AssertEqual(t, mod.MFlag(0), flags)
+	testza.AssertNotNil(t, info)
+
+	flags, _ = getWeaponFlags(nil, all1H, [][]data.ItemClassName{{data.Claw}})
+	testza.AssertTrue(t, flags&mod.MFlagClaw != 0)
+
+	flags, _ = getWeaponFlags(nil, map[string]interface{}{}, nil)
+	testza.AssertEqual(t, mod.MFlag(0), flags)
+}
@@ -12,6 +12,7 @@ import (
 
 	"github.com/Vilsol/go-pob/data"
 	"github.com/Vilsol/go-pob/mod"
+	"github.com/Vilsol/go-pob/moddb"
 	"github.com/Vilsol/go-pob/utils"
 )
 
@@ -5565,330 +5566,366 @@ func initializeSkillNameList() {
 	}
 }
 
-/*
-// TODO Radius jewels that modify other nodes
-local function getSimpleConv(srcList, dst, type, remove, factor)
-	return function(node, out, data)
-		if node then
-			for _, src in pairs(srcList) do
-				for _, mod in ipairs(node.modList) do
-					if mod.name == src and mod.type == type then
-						if remove then
-							out:MergeNewMod(src, type, -mod.value, mod.source, mod.flags, mod.keywordFlags, unpack(mod))
-						end
-						if factor then
-							out:MergeNewMod(dst, type, math.floor(mod.value * factor), mod.source, mod.flags, mod.keywordFlags, unpack(mod))
-						else
-							out:MergeNewMod(dst, type, mod.value, mod.source, mod.flags, mod.keywordFlags, unpack(mod))
-						end
-					end
-				end
-			end
-		end
-	end
-end
-local jewelOtherFuncs = {
-	["Strength from Passives in Radius is Transformed to Dexterity"] = getSimpleConv({ "Str" }, "Dex", "BASE", true),
-	["Dexterity from Passives in Radius is Transformed to Strength"] = getSimpleConv({ "Dex" }, "Str", "BASE", true),
-	["Strength from Passives in Radius is Transformed to Intelligence"] = getSimpleConv({ "Str" }, "Int", "BASE", true),
-	["Intelligence from Passives in Radius is Transformed to Strength"] = getSimpleConv({ "Int" }, "Str", "BASE", true),
-	["Dexterity from Passives in Radius is Transformed to Intelligence"] = getSimpleConv({ "Dex" }, "Int", "BASE", true),
-	["Intelligence from Passives in Radius is Transformed to Dexterity"] = getSimpleConv({ "Int" }, "Dex", "BASE", true),
-	["Increases and Reductions to Life in Radius are Transformed to apply to Energy Shield"] = getSimpleConv({ "Life" }, "EnergyShield", "INC", true),
-	["Increases and Reductions to Energy Shield in Radius are Transformed to apply to Armour at 200% of their value"] = getSimpleConv({ "EnergyShield" }, "Armour", "INC", true, 2),
-	["Increases and Reductions to Life in Radius are Transformed to apply to Mana at 200% of their value"] = getSimpleConv({ "Life" }, "Mana", "INC", true, 2),
-	["Increases and Reductions to Physical Damage in Radius are Transformed to apply to Cold Damage"] = getSimpleConv({ "PhysicalDamage" }, "ColdDamage", "INC", true),
-	["Increases and Reductions to Cold Damage in Radius are Transformed to apply to Physical Damage"] = getSimpleConv({ "ColdDamage" }, "PhysicalDamage", "INC", true),
-	["Increases and Reductions to other Damage Types in Radius are Transformed to apply to Fire Damage"] = getSimpleConv({ "PhysicalDamage","ColdDamage","LightningDamage","ChaosDamage" }, "FireDamage", "INC", true),
-	["Passives granting Lightning Resistance or all Elemental Resistances in Radius also grant Chance to Block Spells at 35% of its value"] = getSimpleConv({ "LightningResist","ElementalResist" }, "SpellBlockChance", "BASE", false, 0.35),
-	["Passives granting Lightning Resistance or all Elemental Resistances in Radius also grant Chance to Block Spell Damage at 35% of its value"] = getSimpleConv({ "LightningResist","ElementalResist" }, "SpellBlockChance", "BASE", false, 0.35),
-	["Passives granting Cold Resistance or all Elemental Resistances in Radius also grant Chance to Dodge Attacks at 35% of its value"] = getSimpleConv({ "ColdResist","ElementalResist" }, "AttackDodgeChance", "BASE", false, 0.35),
-	["Passives granting Cold Resistance or all Elemental Resistances in Radius also grant Chance to Dodge Attack Hits at 35% of its value"] = getSimpleConv({ "ColdResist","ElementalResist" }, "AttackDodgeChance", "BASE", false, 0.35),
-	["Passives granting Cold Resistance or all Elemental Resistances in Radius also grant Chance to Suppress Spell Damage at 35% of its value"] = getSimpleConv({ "ColdResist","ElementalResist" }, "SpellSuppressionChance", "BASE", false, 0.35),
-	["Passives granting Cold Resistance or all Elemental Resistances in Radius also grant Chance to Suppress Spell Damage at 50% of its value"] = getSimpleConv({ "ColdResist","ElementalResist" }, "SpellSuppressionChance", "BASE", false, 0.5),
-	["Passives granting Fire Resistance or all Elemental Resistances in Radius also grant Chance to Block Attack Damage at 35% of its value"] = getSimpleConv({ "FireResist","ElementalResist" }, "BlockChance", "BASE", false, 0.35),
-	["Passives granting Fire Resistance or all Elemental Resistances in Radius also grant Chance to Block at 35% of its value"] = getSimpleConv({ "FireResist","ElementalResist" }, "BlockChance", "BASE", false, 0.35),
-	["Melee and Melee Weapon Type modifiers in Radius are Transformed to Bow Modifiers"] = function(node, out, data)
-		if node then
-			local mask1 = bor(ModFlag.Axe, ModFlag.Claw, ModFlag.Dagger, ModFlag.Mace, ModFlag.Staff, ModFlag.Sword, ModFlag.Melee)
-			local mask2 = bor(ModFlag.Weapon1H, ModFlag.WeaponMelee)
-			local mask3 = bor(ModFlag.Weapon2H, ModFlag.WeaponMelee)
-			for _, mod in ipairs(node.modList) do
-				if band(mod.flags, mask1) ~= 0 or band(mod.flags, mask2) == mask2 or band(mod.flags, mask3) == mask3 then
-					out:MergeNewMod(mod.name, mod.type, -mod.value, mod.source, mod.flags, mod.keywordFlags, unpack(mod))
-					out:MergeNewMod(mod.name, mod.type, mod.value, mod.source, bor(band(mod.flags, bnot(bor(mask1, mask2, mask3))), ModFlag.Bow), mod.keywordFlags, unpack(mod))
-				elseif mod[1] then
-					local using = { UsingAxe = true, UsingClaw = true, UsingDagger = true, UsingMace = true, UsingStaff = true, UsingSword = true, UsingMeleeWeapon = true }
-					for _, tag in ipairs(mod) do
-						if tag.type == "Condition" and using[tag.var] then
-							local newTagList = copyTable(mod)
-							for _, tag in ipairs(newTagList) do
-								if tag.type == "Condition" and using[tag.var] then
-									tag.var = "UsingBow"
-									break
-								end
-							end
-							out:MergeNewMod(mod.name, mod.type, -mod.value, mod.source, mod.flags, mod.keywordFlags, unpack(mod))
-							out:MergeNewMod(mod.name, mod.type, mod.value, mod.source, mod.flags, mod.keywordFlags, unpack(newTagList))
-							break
-						end
-					end
-				end
-			end
-		end
-	end,
-	["50% increased Effect of non-Keystone Passive Skills in Radius"] = function(node, out, data)
-		if node and node.type ~= "Keystone" then
-			out:NewMod("PassiveSkillEffect", "INC", 50, data.modSource)
-		end
-	end,
-	["Notable Passive Skills in Radius grant nothing"] = function(node, out, data)
-		if node and node.type == "Notable" then
-			out:NewMod("PassiveSkillHasNoEffect", "FLAG", true, data.modSource)
-		end
-	end,
-	["Allocated Small Passive Skills in Radius grant nothing"] = function(node, out, data)
-		if node and node.type == "Normal" then
-			out:NewMod("AllocatedPassiveSkillHasNoEffect", "FLAG", true, data.modSource)
-		end
-	end,
-	["Passive Skills in Radius also grant: Traps and Mines deal (\d+) to (\d+) added Physical Damage"] = function(min, max)
-		return function(node, out, data)
-			if node and node.type ~= "Keystone" then
-				out:NewMod("PhysicalMin", "BASE", min, data.modSource, 0, bor(KeywordFlag.Trap, KeywordFlag.Mine))
-				out:NewMod("PhysicalMax", "BASE", max, data.modSource, 0, bor(KeywordFlag.Trap, KeywordFlag.Mine))
-			end
-		end
-	end,
-	["Passive Skills in Radius also grant: (\d+)% increased Unarmed Attack Speed with Melee Skills"] = function(num)
-		return function(node, out, data)
-			if node and node.type ~= "Keystone" then
-				out:NewMod("Speed", "INC", num, data.modSource, bor(ModFlag.Unarmed, ModFlag.Attack, ModFlag.Melee))
-			end
-		end
-	end,
-	["Notable Passive Skills in Radius are Transformed to instead grant: 10% increased Mana Cost of Skills and 20% increased Spell Damage"] = function(node, out, data)
-		if node and node.type == "Notable" then
-			out:NewMod("PassiveSkillHasOtherEffect", "FLAG", true, data.modSource)
-			out:NewMOD("NodeModifier", "LIST", mod.NodeModifier{ Mod: MOD("ManaCost", "INC", 10, data.modSource) }, data.modSource)
-			out:NewMOD("NodeModifier", "LIST", mod.NodeModifier{ Mod: MOD("Damage", "INC", 20, data.modSource, ModFlag.Spell) }, data.modSource)
-		end
-	end,
-	["Notable Passive Skills in Radius are Transformed to instead grant: Minions take 20% increased Damage"] = function(node, out, data)
-		if node and node.type == "Notable" then
-			out:NewMod("PassiveSkillHasOtherEffect", "FLAG", true, data.modSource)
-			out:NewMOD("NodeModifier", "LIST", mod.NodeModifier{ Mod: MOD("MinionModifier", "LIST", mod.MinionModifier{ Mod: MOD("DamageTaken", "INC", 20, data.modSource) } ) }, data.modSource)
-		end
-	end,
-	["Notable Passive Skills in Radius are Transformed to instead grant: Minions have 25% reduced Movement Speed"] = function(node, out, data)
-		if node and node.type == "Notable" then
-			out:NewMod("PassiveSkillHasOtherEffect", "FLAG", true, data.modSource)
-			out:NewMOD("NodeModifier", "LIST", mod.NodeModifier{ Mod: MOD("MinionModifier", "LIST", mod.MinionModifier{ Mod: MOD("MovementSpeed", "INC", -25, data.modSource) } ) }, data.modSource)
-		end
-	end,
+// Radius jewels that modify other nodes
+func getSimpleConv(srcList []string, dst string, modType mod.Type, remove bool, factor float64) JewelFuncHandler {
+	return func(node *JewelFuncNode, out *moddb.ModList, data *JewelFuncData) {
+		if node == nil {
+			return
+		}
+
+		for _, src := range srcList {
+			for _, m := range node.ModList.Mods() {
+				if m.Name() != src || m.Type() != modType {
+					continue
+				}
+
+				if remove {
+					out.AddMod(copyModWith(m, src, m.Flags(), -m.Value().Float()))
+				}
+
+				if factor != 0 {
+					out.AddMod(copyModWith(m, dst, m.Flags(), math.Floor(m.Value().Float()*factor)))
+				} else {
+					out.AddMod(copyModWith(m, dst, m.Flags(), m.Value().Float()))
+				}
+			}
+		}
+	}
 }
 
-// TODO Radius jewels that modify the jewel itself based on nearby allocated nodes
-local function getPerStat(dst, modType, flags, stat, factor)
-	return function(node, out, data)
-		if node then
-			data[stat] = (data[stat] or 0) + out:Sum("BASE", nil, stat)
-		elseif data[stat] ~= 0 then
-			out:NewMod(dst, modType, math.floor((data[stat] or 0) * factor), data.modSource, flags)
-		end
-	end
-end
-local jewelSelfFuncs = {
-	["Adds 1 to maximum Life per 3 Intelligence in Radius"] = getPerStat("Life", "BASE", 0, "Int", 1 / 3),
-	["Adds 1 to Maximum Life per 3 Intelligence Allocated in Radius"] = getPerStat("Life", "BASE", 0, "Int", 1 / 3),
-	["1% increased Evasion Rating per 3 Dexterity Allocated in Radius"] = getPerStat("Evasion", "INC", 0, "Dex", 1 / 3),
-	["1% increased Claw Physical Damage per 3 Dexterity Allocated in Radius"] = getPerStat("PhysicalDamage", "INC", ModFlag.Claw, "Dex", 1 / 3),
-	["1% increased Melee Physical Damage while Unarmed per 3 Dexterity Allocated in Radius"] = getPerStat("PhysicalDamage", "INC", ModFlag.Unarmed, "Dex", 1 / 3),
-	["3% increased Totem Life per 10 Strength in Radius"] = getPerStat("TotemLife", "INC", 0, "Str", 3 / 10),
-	["3% increased Totem Life per 10 Strength Allocated in Radius"] = getPerStat("TotemLife", "INC", 0, "Str", 3 / 10),
-	["Adds 1 maximum Lightning Damage to Attacks per 1 Dexterity Allocated in Radius"] = getPerStat("LightningMax", "BASE", ModFlag.Attack, "Dex", 1),
-	["5% increased Chaos damage per 10 Intelligence from Allocated Passives in Radius"] = getPerStat("ChaosDamage", "INC", 0, "Int", 5 / 10),
-	["Dexterity and Intelligence from passives in Radius count towards Strength Melee Damage bonus"] = function(node, out, data)
-		if node then
-			data.Dex = (data.Dex or 0) + node.modList:Sum("BASE", nil, "Dex")
-			data.Int = (data.Int or 0) + node.modList:Sum("BASE", nil, "Int")
-		elseif data.Dex or data.Int then
-			out:NewMod("DexIntToMeleeBonus", "BASE", (data.Dex or 0) + (data.Int or 0), data.modSource)
-		end
-	end,
-	["-1 Strength per 1 Strength on Allocated Passives in Radius"] = getPerStat("Str", "BASE", 0, "Str", -1),
-	["1% additional Physical Damage Reduction per 10 Strength on Allocated Passives in Radius"] = getPerStat("PhysicalDamageReduction", "BASE", 0, "Str", 1 / 10),
-	["2% increased Life Recovery Rate per 10 Strength on Allocated Passives in Radius"] = getPerStat("LifeRecoveryRate", "INC", 0, "Str", 2 / 10),
-	["3% increased Life Recovery Rate per 10 Strength on Allocated Passives in Radius"] = getPerStat("LifeRecoveryRate", "INC", 0, "Str", 3 / 10),
-	["-1 Intelligence per 1 Intelligence on Allocated Passives in Radius"] = getPerStat("Int", "BASE", 0, "Int", -1),
-	["0.4% of Energy Shield Regenerated per Second for every 10 Intelligence on Allocated Passives in Radius"] = getPerStat("EnergyShieldRegenPercent", "BASE", 0, "Int", 0.4 / 10),
-	["2% increased Mana Recovery Rate per 10 Intelligence on Allocated Passives in Radius"] = getPerStat("ManaRecoveryRate", "INC", 0, "Int", 2 / 10),
-	["3% increased Mana Recovery Rate per 10 Intelligence on Allocated Passives in Radius"] = getPerStat("ManaRecoveryRate", "INC", 0, "Int", 3 / 10),
-	["-1 Dexterity per 1 Dexterity on Allocated Passives in Radius"] = getPerStat("Dex", "BASE", 0, "Dex", -1),
-	["2% increased Movement Speed per 10 Dexterity on Allocated Passives in Radius"] = getPerStat("MovementSpeed", "INC", 0, "Dex", 2 / 10),
-	["3% increased Movement Speed per 10 Dexterity on Allocated Passives in Radius"] = getPerStat("MovementSpeed", "INC", 0, "Dex", 3 / 10),
+// copyModWith returns a copy of the mod with a different name, flags and value
+func copyModWith(m mod.Mod, name string, flags mod.MFlag, value float64) mod.Mod {
+	return mod.NewFloat(name, m.Type(), value).
+		Source(m.GetSource()).
+		Flag(flags).
+		KeywordFlag(m.KeywordFlags()).
+		Tag(m.Tags()...)
 }
-local jewelSelfUnallocFuncs = {
-	["+5% to Critical Strike Multiplier per 10 Strength on Unallocated Passives in Radius"] = getPerStat("CritMultiplier", "BASE", 0, "Str", 5 / 10),
-	["+7% to Critical Strike Multiplier per 10 Strength on Unallocated Passives in Radius"] = getPerStat("CritMultiplier", "BASE", 0, "Str", 7 / 10),
-	["2% reduced Life Recovery Rate per 10 Strength on Unallocated Passives in Radius"] = getPerStat("LifeRecoveryRate", "INC", 0, "Str", -2 / 10),
-	["+15 to maximum Mana per 10 Dexterity on Unallocated Passives in Radius"] = getPerStat("Mana", "BASE", 0, "Dex", 15 / 10),
-	["+100 to Accuracy Rating per 10 Intelligence on Unallocated Passives in Radius"] = getPerStat("Accuracy", "BASE", 0, "Int", 100 / 10),
-	["+125 to Accuracy Rating per 10 Intelligence on Unallocated Passives in Radius"] = getPerStat("Accuracy", "BASE", 0, "Int", 125 / 10),
-	["2% reduced Mana Recovery Rate per 10 Intelligence on Unallocated Passives in Radius"] = getPerStat("ManaRecoveryRate", "INC", 0, "Int", -2 / 10),
-	["+3% to Damage over Time Multiplier per 10 Intelligence on Unallocated Passives in Radius"] = getPerStat("DotMultiplier", "BASE", 0, "Int", 3 / 10),
-	["2% reduced Movement Speed per 10 Dexterity on Unallocated Passives in Radius"] = getPerStat("MovementSpeed", "INC", 0, "Dex", -2 / 10),
-	["+125 to Accuracy Rating per 10 Dexterity on Unallocated Passives in Radius"] = getPerStat("Accuracy", "BASE", 0, "Dex", 125 / 10),
-	["Grants all bonuses of Unallocated Small Passive Skills in Radius"] = function(node, out, data)
-		if node then
-			if node.type == "Normal" then
-				data.modList = data.modList or new("ModList")
-				data.modList:AddList(out)
-			end
-		elseif data.modList then
-			out:AddList(data.modList)
-		end
-	end,
+
+// jewelNodeType returns the node type as used by the jewel functions
+func jewelNodeType(node data.Node) string {
+	switch {
+	case node.IsKeystone != nil && *node.IsKeystone:
+		return "Keystone"
+	case node.IsNotable != nil && *node.IsNotable:
+		return "Notable"
+	case node.IsMastery != nil && *node.IsMastery:
+		return "Mastery"
+	case node.IsJewelSocket != nil && *node.IsJewelSocket:
+		return "Socket"
+	case node.ClassStartIndex != nil:
+		return "ClassStart"
+	case node.IsAscendancyStart != nil && *node.IsAscendancyStart:
+		return "AscendClassStart"
+	default:
+		return "Normal"
+	}
 }
 
-// TODO Radius jewels with bonuses conditional upon attributes of nearby nodes
-local function getThreshold(attrib, name, modType, value, ...)
-	local baseMod = mod(name, modType, value, "", ...)
-	return function(node, out, data)
-		if node then
-			if type(attrib) == "table" then
-				for _, att in ipairs(attrib) do
-					local nodeVal = out:Sum("BASE", nil, att)
-					data[att] = (data[att] or 0) + nodeVal
-					data.total = (data.total or 0) + nodeVal
-				end
-			else
-				local nodeVal = out:Sum("BASE", nil, attrib)
-				data[attrib] = (data[attrib] or 0) + nodeVal
-				data.total = (data.total or 0) + nodeVal
-			end
-		elseif (data.total or 0) >= 40 then
-			local mod = copyTable(baseMod)
-			mod.source = data.modSource
-			if type(value) == "table" and value.mod then
-				value.mod.source = data.modSource
-			end
-			out:AddMod(mod)
-		end
-	end
-end
-local jewelThresholdFuncs = {
-	["With at least 40 Dexterity in Radius, Frost Blades Melee Damage Penetrates 15% Cold Resistance"] = getThreshold("Dex", "ColdPenetration", "BASE", 15, ModFlag.Melee, mod.SkillName("Frost Blades" )),
-	["With at least 40 Dexterity in Radius, Melee Damage dealt by Frost Blades Penetrates 15% Cold Resistance"] = getThreshold("Dex", "ColdPenetration", "BASE", 15, ModFlag.Melee, mod.SkillName("Frost Blades" )),
-	["With at least 40 Dexterity in Radius, Frost Blades has 25% increased Projectile Speed"] = getThreshold("Dex", "ProjectileSpeed", "INC", 25, mod.SkillName("Frost Blades" )),
-	["With at least 40 Dexterity in Radius, Ice Shot has 25% increased Area of Effect"] = getThreshold("Dex", "AreaOfEffect", "INC", 25, mod.SkillName("Ice Shot" )),
-	["Ice Shot Pierces 5 additional Targets with 40 Dexterity in Radius"] = getThreshold("Dex", "PierceCount", "BASE", 5, mod.SkillName("Ice Shot" )),
-	["With at least 40 Dexterity in Radius, Ice Shot Pierces 3 additional Targets"] = getThreshold("Dex", "PierceCount", "BASE", 3, mod.SkillName("Ice Shot" )),
-	["With at least 40 Dexterity in Radius, Ice Shot Pierces 5 additional Targets"] = getThreshold("Dex", "PierceCount", "BASE", 5, mod.SkillName("Ice Shot" )),
-	["With at least 40 Intelligence in Radius, Frostbolt fires 2 additional Projectiles"] = getThreshold("Int", "ProjectileCount", "BASE", 2, mod.SkillName("Frostbolt" )),
-	["With at least 40 Intelligence in Radius, Rolling Magma fires an additional Projectile"] = getThreshold("Int", "ProjectileCount", "BASE", 1, mod.SkillName("Rolling Magma" )),
-	["With at least 40 Intelligence in Radius, Rolling Magma has 10% increased Area of Effect per Chain"] = getThreshold("Int", "AreaOfEffect", "INC", 10, mod.SkillName("Rolling Magma")).Tag(mod.PerStat(0, "Chain")),
-	["With at least 40 Intelligence in Radius, Rolling Magma deals 40% more damage per chain"] = getThreshold("Int", "Damage", "MORE", 40, mod.SkillName("Rolling Magma")).Tag(mod.PerStat(0, "Chain")),
-	["With at least 40 Intelligence in Radius, Rolling Magma deals 50% less damage"] = getThreshold("Int", "Damage", "MORE", -50, mod.SkillName("Rolling Magma" )),
-	["With at least 40 Dexterity in Radius, Shrapnel Shot has 25% increased Area of Effect"] = getThreshold("Dex", "AreaOfEffect", "INC", 25, mod.SkillName("Shrapnel Shot" )),
-	["With at least 40 Dexterity in Radius, Shrapnel Shot's cone has a 50% chance to deal Double Damage"] = getThreshold("Dex", "DoubleDamageChance", "BASE", 50, mod.SkillName("Shrapnel Shot" ), mod.SkillPart(2)),
-	["With at least 40 Dexterity in Radius, Galvanic Arrow deals 50% increased Area Damage"] = getThreshold("Dex", "Damage", "INC", 50, mod.SkillName("Galvanic Arrow" ), mod.SkillPart(2)),
-	["With at least 40 Dexterity in Radius, Galvanic Arrow has 25% increased Area of Effect"] = getThreshold("Dex", "AreaOfEffect", "INC", 25, mod.SkillName("Galvanic Arrow" )),
-	["With at least 40 Intelligence in Radius, Freezing Pulse fires 2 additional Projectiles"] = getThreshold("Int", "ProjectileCount", "BASE", 2, mod.SkillName("Freezing Pulse" )),
-	["With at least 40 Intelligence in Radius, 25% increased Freezing Pulse Damage if you've Shattered an Enemy Recently"] = getThreshold("Int", "Damage", "INC", 25, mod.SkillName("Freezing Pulse" ), { type = "Condition", var = "ShatteredEnemyRecently" }),
-	["With at least 40 Dexterity in Radius, Ethereal Knives fires 10 additional Projectiles"] = getThreshold("Dex", "ProjectileCount", "BASE", 10, mod.SkillName("Ethereal Knives" )),
-	["With at least 40 Dexterity in Radius, Ethereal Knives fires 5 additional Projectiles"] = getThreshold("Dex", "ProjectileCount", "BASE", 5, mod.SkillName("Ethereal Knives" )),
-	["With at least 40 Strength in Radius, Molten Strike fires 2 additional Projectiles"] = getThreshold("Str", "ProjectileCount", "BASE", 2, mod.SkillName("Molten Strike" )),
-	["With at least 40 Strength in Radius, Molten Strike has 25% increased Area of Effect"] = getThreshold("Str", "AreaOfEffect", "INC", 25, mod.SkillName("Molten Strike" )),
-	["With at least 40 Strength in Radius, Molten Strike Projectiles Chain +1 time"] = getThreshold("Str", "ChainCountMax", "BASE", 1, mod.SkillName("Molten Strike" )),
-	["With at least 40 Strength in Radius, Molten Strike fires 50% less Projectiles"] = getThreshold("Str", "ProjectileCount", "MORE", -50, mod.SkillName("Molten Strike" )),
-	["With at least 40 Strength in Radius, 25% of Glacial Hammer Physical Damage converted to Cold Damage"] = getThreshold("Str", "SkillPhysicalDamageConvertToCold", "BASE", 25, mod.SkillName("Glacial Hammer" )),
-	["With at least 40 Strength in Radius, Heavy Strike has a 20% chance to deal Double Damage"] = getThreshold("Str", "DoubleDamageChance", "BASE", 20, mod.SkillName("Heavy Strike" )),
-	["With at least 40 Strength in Radius, Heavy Strike has a 20% chance to deal Double Damage."] = getThreshold("Str", "DoubleDamageChance", "BASE", 20, mod.SkillName("Heavy Strike" )),
-	["With at least 40 Strength in Radius, Cleave has +1 to Radius per Nearby Enemy, up to +10"] = getThreshold("Str", "AreaOfEffect", "BASE", 1, { type = "Multiplier", var = "NearbyEnemies", limit = 10 }, mod.SkillName("Cleave" )),
-	["With at least 40 Strength in Radius, Cleave grants Fortify on Hit"] = getThreshold("Str", "ExtraSkillMod", "LIST", { mod = FLAG("Condition:Fortified") }, mod.SkillName("Cleave" )),
-	["With at least 40 Strength in Radius, Hits with Cleave Fortify"] = getThreshold("Str", "ExtraSkillMod", "LIST", { mod = FLAG("Condition:Fortified") }, mod.SkillName("Cleave" )),
-	["With at least 40 Dexterity in Radius, Dual Strike has a 20% chance to deal Double Damage with the Main-Hand Weapon"] = getThreshold("Dex", "DoubleDamageChance", "BASE", 20, mod.SkillName("Dual Strike" ), { type = "Condition", var = "MainHandAttack" }),
-	["With at least 40 Dexterity in Radius, Dual Strike has (\d+)% increased Attack Speed while wielding a Claw"] = function(num) return getThreshold("Dex", "Speed", "INC", num, mod.SkillName("Dual Strike" ), { type = "Condition", var = "UsingClaw" }) end,
-	["With at least 40 Dexterity in Radius, Dual Strike has %+(\d+)% to Critical Strike Multiplier while wielding a Dagger"] = function(num) return getThreshold("Dex", "CritMultiplier", "BASE", num, mod.SkillName("Dual Strike" ), { type = "Condition", var = "UsingDagger" }) end,
-	["With at least 40 Dexterity in Radius, Dual Strike has (\d+)% increased Accuracy Rating while wielding a Sword"] = function(num) return getThreshold("Dex", "Accuracy", "INC", num, mod.SkillName("Dual Strike" ), { type = "Condition", var = "UsingSword" }) end,
-	["With at least 40 Dexterity in Radius, Dual Strike Hits Intimidate Enemies for 4 seconds while wielding an Axe"] = getThreshold("Dex", "EnemyModifier", "LIST", { mod = FLAG("Condition:Intimidated")}, { type = "Condition", var = "UsingAxe" }),
-	["With at least 40 Intelligence in Radius, Raised Zombies' Slam Attack has 100% increased Cooldown Recovery Speed"] = getThreshold("Int", "MinionModifier", "LIST", { mod = MOD("CooldownRecovery", "INC", 100, { type = "SkillId", SkillID: "ZombieSlam" }) }),
-	["With at least 40 Intelligence in Radius, Raised Zombies' Slam Attack deals 30% increased Damage"] = getThreshold("Int", "MinionModifier", "LIST", { mod = MOD("Damage", "INC", 30, { type = "SkillId", SkillID: "ZombieSlam" }) }),
-	["With at least 40 Dexterity in Radius, Viper Strike deals 2% increased Attack Damage for each Poison on the Enemy"] = getThreshold("Dex", "Damage", "INC", 2, ModFlag.Attack, mod.SkillName("Viper Strike" ), { type = "Multiplier", actor = "enemy", var = "PoisonStack" }),
-	["With at least 40 Dexterity in Radius, Viper Strike deals 2% increased Damage with Hits and Poison for each Poison on the Enemy"] = getThreshold("Dex", "Damage", "INC", 2, 0, bor(KeywordFlag.Hit, KeywordFlag.Poison), mod.SkillName("Viper Strike" ), { type = "Multiplier", actor = "enemy", var = "PoisonStack" }),
-	["With at least 40 Intelligence in Radius, Spark fires 2 additional Projectiles"] = getThreshold("Int", "ProjectileCount", "BASE", 2, mod.SkillName("Spark" )),
-	["With at least 40 Intelligence in Radius, Blight has 50% increased Hinder Duration"] = getThreshold("Int", "SecondaryDuration", "INC", 50, mod.SkillName("Blight" )),
-	["With at least 40 Intelligence in Radius, Enemies Hindered by Blight take 25% increased Chaos Damage"] = getThreshold("Int", "ExtraSkillMod", "LIST", { mod = MOD("ChaosDamageTaken", "INC", 25, mod.GlobalEffect("Debuff", effectName = "Hinder")) }, mod.SkillName("Blight")).Tag(mod.ActorCondition("enemy", "Hindered")),
-	["With 40 Intelligence in Radius, 20% of Glacial Cascade Physical Damage Converted to Cold Damage"] = getThreshold("Int", "SkillPhysicalDamageConvertToCold", "BASE", 20, mod.SkillName("Glacial Cascade" )),
-	["With at least 40 Intelligence in Radius, 20% of Glacial Cascade Physical Damage Converted to Cold Damage"] = getThreshold("Int", "SkillPhysicalDamageConvertToCold", "BASE", 20, mod.SkillName("Glacial Cascade" )),
-	["With 40 total Intelligence and Dexterity in Radius, Elemental Hit and Wild Strike deal 50% less Fire Damage"] = getThreshold({ "Int","Dex" }, "FireDamage", "MORE", -50, { type = "SkillName", skillNameList = { "Elemental Hit", "Wild Strike" } }),
-	["With 40 total Strength and Intelligence in Radius, Elemental Hit and Wild Strike deal 50% less Cold Damage"] = getThreshold({ "Str","Int" }, "ColdDamage", "MORE", -50, { type = "SkillName", skillNameList = { "Elemental Hit", "Wild Strike" } }),
-	["With 40 total Dexterity and Strength in Radius, Elemental Hit and Wild Strike deal 50% less Lightning Damage"] = getThreshold({ "Dex","Str" }, "LightningDamage", "MORE", -50, { type = "SkillName", skillNameList = { "Elemental Hit", "Wild Strike" } }),
-	["With 40 total Intelligence and Dexterity in Radius, Prismatic Skills deal 50% less Fire Damage"] = getThreshold({ "Int","Dex" }, "FireDamage", "MORE", -50, { type = "SkillType", skillType = SkillType.RandomElement }),
-	["With 40 total Strength and Intelligence in Radius, Prismatic Skills deal 50% less Cold Damage"] = getThreshold({ "Str","Int" }, "ColdDamage", "MORE", -50, { type = "SkillType", skillType = SkillType.RandomElement }),
-	["With 40 total Dexterity and Strength in Radius, Prismatic Skills deal 50% less Lightning Damage"] = getThreshold({ "Dex","Str" }, "LightningDamage", "MORE", -50, { type = "SkillType", skillType = SkillType.RandomElement }),
-	["With 40 total Dexterity and Strength in Radius, Spectral Shield Throw Chains +4 times"] = getThreshold({ "Dex","Str" }, "ChainCountMax", "BASE", 4, mod.SkillName("Spectral Shield Throw" )),
-	["With 40 total Dexterity and Strength in Radius, Spectral Shield Throw fires 75% less Shard Projectiles"] = getThreshold({ "Dex","Str" }, "ProjectileCount", "MORE", -75, mod.SkillName("Spectral Shield Throw" )),
-	["With at least 40 Intelligence in Radius, Blight inflicts Withered for 2 seconds"] = getThreshold("Int", "ExtraSkillMod", "LIST", { mod = MOD("Condition:CanWither", "FLAG", true) }, mod.SkillName("Blight" )),
-	["With at least 40 Intelligence in Radius, Blight has 30% reduced Cast Speed"] = getThreshold("Int", "Speed", "INC", -30, mod.SkillName("Blight" )),
-	["With at least 40 Intelligence in Radius, Fireball cannot ignite"] = getThreshold("Int", "ExtraSkillMod", "LIST", { mod = FLAG("CannotIgnite") }, mod.SkillName("Fireball" )),
-	["With at least 40 Intelligence in Radius, Fireball has %+(\d+)% chance to inflict scorch"] = function(num) return getThreshold("Int", "EnemyScorchChance", "BASE", num, mod.SkillName("Fireball" )) end,
-	["With at least 40 Intelligence in Radius, Discharge has 60% less Area of Effect"] = getThreshold("Int", "AreaOfEffect", "MORE", -60, {type = "SkillName", skillName = "Discharge" }),
-	["With at least 40 Intelligence in Radius, Discharge Cooldown is 250 ms"] = getThreshold("Int", "CooldownRecovery", "OVERRIDE", 0.25, mod.SkillName("Discharge" )),
-	["With at least 40 Intelligence in Radius, Discharge deals 60% less Damage"] = getThreshold("Int", "Damage", "MORE", -60, {type = "SkillName", skillName = "Discharge" }),
-	// [""] = getThreshold("", "", "", , mod.SkillName("" )),
+func meleeToBowConv(node *JewelFuncNode, out *moddb.ModList, data *JewelFuncData) {
+	if node == nil {
+		return
+	}
+
+	mask1 := mod.MFlagAxe | mod.MFlagClaw | mod.MFlagDagger | mod.MFlagMace | mod.MFlagStaff | mod.MFlagSword | mod.MFlagMelee
+	mask2 := mod.MFlagWeapon1H | mod.MFlagWeaponMelee
+	mask3 := mod.MFlagWeapon2H | mod.MFlagWeaponMelee
+	using := map[string]bool{"UsingAxe": true, "UsingClaw": true, "UsingDagger": true, "UsingMace": true, "UsingStaff": true, "UsingSword": true, "UsingMeleeWeapon": true}
+
+	for _, m := range node.ModList.Mods() {
+		if m.Value().Type() != mod.ModValueMultiTypeFloat {
+			continue
+		}
+
+		if m.Flags()&mask1 != 0 || m.Flags()&mask2 == mask2 || m.Flags()&mask3 == mask3 {
+			out.AddMod(copyModWith(m, m.Name(), m.Flags(), -m.Value().Float()))
+			out.AddMod(copyModWith(m, m.Name(), m.Flags()&^(mask1|mask2|mask3)|mod.MFlagBow, m.Value().Float()))
+			continue
+		}
+
+		for i, tag := range m.Tags() {
+			condition, ok := tag.(*mod.ConditionTag)
+			if !ok || len(condition.VarList) != 1 || !using[condition.VarList[0]] {
+				continue
+			}
+
+			tags := slices.Clone(m.Tags())
+			tags[i] = &mod.ConditionTag{TagType: condition.TagType, VarList: []string{"UsingBow"}, Negative: condition.Negative}
+
+			out.AddMod(copyModWith(m, m.Name(), m.Flags(), -m.Value().Float()))
+			bow := copyModWith(m, m.Name(), m.Flags(), m.Value().Float())
+			bow.ClearTags()
+			out.AddMod(bow.Tag(tags...))
+			break
+		}
+	}
+}
+
+// transformNotables replaces the mods of notables in radius with the provided mods
+func transformNotables(mods ...mod.Mod) JewelFuncHandler {
+	return func(node *JewelFuncNode, out *moddb.ModList, data *JewelFuncData) {
+		if node != nil && jewelNodeType(node.Node) == "Notable" {
+			out.AddMod(FLAG("PassiveSkillHasOtherEffect").Source(data.ModSource))
+			for _, m := range mods {
+				out.AddMod(MOD("NodeModifier", "LIST", mod.NodeModifier{Mod: m.Clone().Source(data.ModSource)}).Source(data.ModSource))
+			}
+		}
+	}
+}
+
+var jewelOtherFuncs = map[string]JewelFuncHandler{
+	"Strength from Passives in Radius is Transformed to Dexterity":                                                                              getSimpleConv([]string{"Str"}, "Dex", mod.TypeBase, true, 0),
+	"Dexterity from Passives in Radius is Transformed to Strength":                                                                              getSimpleConv([]string{"Dex"}, "Str", mod.TypeBase, true, 0),
+	"Strength from Passives in Radius is Transformed to Intelligence":                                                                           getSimpleConv([]string{"Str"}, "Int", mod.TypeBase, true, 0),
+	"Intelligence from Passives in Radius is Transformed to Strength":                                                                           getSimpleConv([]string{"Int"}, "Str", mod.TypeBase, true, 0),
+	"Dexterity from Passives in Radius is Transformed to Intelligence":                                                                          getSimpleConv([]string{"Dex"}, "Int", mod.TypeBase, true, 0),
+	"Intelligence from Passives in Radius is Transformed to Dexterity":                                                                          getSimpleConv([]string{"Int"}, "Dex", mod.TypeBase, true, 0),
+	"Increases and Reductions to Life in Radius are Transformed to apply to Energy Shield":                                                      getSimpleConv([]string{"Life"}, "EnergyShield", mod.TypeIncrease, true, 0),
+	"Increases and Reductions to Energy Shield in Radius are Transformed to apply to Armour at 200% of their value":                             getSimpleConv([]string{"EnergyShield"}, "Armour", mod.TypeIncrease, true, 2),
+	"Increases and Reductions to Life in Radius are Transformed to apply to Mana at 200% of their value":                                        getSimpleConv([]string{"Life"}, "Mana", mod.TypeIncrease, true, 2),
+	"Increases and Reductions to Physical Damage in Radius are Transformed to apply to Cold Damage":                                             getSimpleConv([]string{"PhysicalDamage"}, "ColdDamage", mod.TypeIncrease, true, 0),
+	"Increases and Reductions to Cold Damage in Radius are Transformed to apply to Physical Damage":                                             getSimpleConv([]string{"ColdDamage"}, "PhysicalDamage", mod.TypeIncrease, true, 0),
+	"Increases and Reductions to other Damage Types in Radius are Transformed to apply to Fire Damage":                                          getSimpleConv([]string{"PhysicalDamage", "ColdDamage", "LightningDamage", "ChaosDamage"}, "FireDamage", mod.TypeIncrease, true, 0),
+	"Passives granting Lightning Resistance or all Elemental Resistances in Radius also grant Chance to Block Spells at 35% of its value":       getSimpleConv([]string{"LightningResist", "ElementalResist"}, "SpellBlockChance", mod.TypeBase, false, 0.35),
+	"Passives granting Lightning Resistance or all Elemental Resistances in Radius also grant Chance to Block Spell Damage at 35% of its value": getSimpleConv([]string{"LightningResist", "ElementalResist"}, "SpellBlockChance", mod.TypeBase, false, 0.35),
+	"Passives granting Cold Resistance or all Elemental Resistances in Radius also grant Chance to Dodge Attacks at 35% of its value":           getSimpleConv([]string{"ColdResist", "ElementalResist"}, "AttackDodgeChance", mod.TypeBase, false, 0.35),
+	"Passives granting Cold Resistance or all Elemental Resistances in Radius also grant Chance to Dodge Attack Hits at 35% of its value":       getSimpleConv([]string{"ColdResist", "ElementalResist"}, "AttackDodgeChance", mod.TypeBase, false, 0.35),
+	"Passives granting Cold Resistance or all Elemental Resistances in Radius also grant Chance to Suppress Spell Damage at 35% of its value":   getSimpleConv([]string{"ColdResist", "ElementalResist"}, "SpellSuppressionChance", mod.TypeBase, false, 0.35),
+	"Passives granting Cold Resistance or all Elemental Resistances in Radius also grant Chance to Suppress Spell Damage at 50% of its value":   getSimpleConv([]string{"ColdResist", "ElementalResist"}, "SpellSuppressionChance", mod.TypeBase, false, 0.5),
+	"Passives granting Fire Resistance or all Elemental Resistances in Radius also grant Chance to Block Attack Damage at 35% of its value":     getSimpleConv([]string{"FireResist", "ElementalResist"}, "BlockChance", mod.TypeBase, false, 0.35),
+	"Passives granting Fire Resistance or all Elemental Resistances in Radius also grant Chance to Block at 35% of its value":                   getSimpleConv([]string{"FireResist", "ElementalResist"}, "BlockChance", mod.TypeBase, false, 0.35),
+	"Melee and Melee Weapon Type modifiers in Radius are Transformed to Bow Modifiers":                                                          meleeToBowConv,
+	"50% increased Effect of non-Keystone Passive Skills in Radius": func(node *JewelFuncNode, out *moddb.ModList, data *JewelFuncData) {
+		if node != nil && jewelNodeType(node.Node) != "Keystone" {
+			out.AddMod(MOD("PassiveSkillEffect", "INC", 50).Source(data.ModSource))
+		}
+	},
+	"Notable Passive Skills in Radius grant nothing": func(node *JewelFuncNode, out *moddb.ModList, data *JewelFuncData) {
+		if node != nil && jewelNodeType(node.Node) == "Notable" {
+			out.AddMod(FLAG("PassiveSkillHasNoEffect").Source(data.ModSource))
+		}
+	},
+	"Allocated Small Passive Skills in Radius grant nothing": func(node *JewelFuncNode, out *moddb.ModList, data *JewelFuncData) {
+		if node != nil && jewelNodeType(node.Node) == "Normal" {
+			out.AddMod(FLAG("AllocatedPassiveSkillHasNoEffect").Source(data.ModSource))
+		}
+	},
+	"Notable Passive Skills in Radius are Transformed to instead grant: 10% increased Mana Cost of Skills and 20% increased Spell Damage": transformNotables(
+		MOD("ManaCost", "INC", 10),
+		MOD("Damage", "INC", 20).Flag(mod.MFlagSpell),
+	),
+	"Notable Passive Skills in Radius are Transformed to instead grant: Minions take 20% increased Damage": transformNotables(
+		MOD("MinionModifier", "LIST", mod.MinionModifier{Mod: MOD("DamageTaken", "INC", 20)}),
+	),
+	"Notable Passive Skills in Radius are Transformed to instead grant: Minions have 25% reduced Movement Speed": transformNotables(
+		MOD("MinionModifier", "LIST", mod.MinionModifier{Mod: MOD("MovementSpeed", "INC", -25)}),
+	),
+}
+
+// Radius jewels that modify other nodes, parametrised by the captures of the pattern
+var jewelOtherFuncPatterns = map[string]func(captures []string) JewelFuncHandler{
+	`passive skills in radius also grant: traps and mines deal (\d+) to (\d+) added physical damage`: func(captures []string) JewelFuncHandler {
+		return func(node *JewelFuncNode, out *moddb.ModList, data *JewelFuncData) {
+			if node != nil && jewelNodeType(node.Node) != "Keystone" {
+				out.AddMod(MOD("PhysicalMin", "BASE", utils.Float(captures[0])).Source(data.ModSource).KeywordFlag(mod.KeywordFlagTrap | mod.KeywordFlagMine))
+				out.AddMod(MOD("PhysicalMax", "BASE", utils.Float(captures[1])).Source(data.ModSource).KeywordFlag(mod.KeywordFlagTrap | mod.KeywordFlagMine))
+			}
+		}
+	},
+	`passive skills in radius also grant: (\d+)% increased unarmed attack speed with melee skills`: func(captures []string) JewelFuncHandler {
+		return func(node *JewelFuncNode, out *moddb.ModList, data *JewelFuncData) {
+			if node != nil && jewelNodeType(node.Node) != "Keystone" {
+				out.AddMod(MOD("Speed", "INC", utils.Float(captures[0])).Source(data.ModSource).Flag(mod.MFlagUnarmed | mod.MFlagAttack | mod.MFlagMelee))
+			}
+		}
+	},
+}
+
+// Radius jewels that modify the jewel itself based on nearby allocated nodes
+func getPerStat(dst string, modType mod.Type, flags mod.MFlag, stat string, factor float64) JewelFuncHandler {
+	return func(node *JewelFuncNode, out *moddb.ModList, data *JewelFuncData) {
+		if node != nil {
+			data.Stats[stat] += out.Sum(mod.TypeBase, nil, stat)
+		} else if data.Stats[stat] != 0 {
+			out.AddMod(MOD(dst, modType, math.Floor(data.Stats[stat]*factor)).Source(data.ModSource).Flag(flags))
+		}
+	}
+}
+
+var jewelSelfFuncs = map[string]JewelFuncHandler{
+	"Adds 1 to maximum Life per 3 Intelligence in Radius":                                                    getPerStat("Life", mod.TypeBase, 0, "Int", 1.0/3),
+	"Adds 1 to Maximum Life per 3 Intelligence Allocated in Radius":                                          getPerStat("Life", mod.TypeBase, 0, "Int", 1.0/3),
+	"1% increased Evasion Rating per 3 Dexterity Allocated in Radius":                                        getPerStat("Evasion", mod.TypeIncrease, 0, "Dex", 1.0/3),
+	"1% increased Claw Physical Damage per 3 Dexterity Allocated in Radius":                                  getPerStat("PhysicalDamage", mod.TypeIncrease, mod.MFlagClaw, "Dex", 1.0/3),
+	"1% increased Melee Physical Damage while Unarmed per 3 Dexterity Allocated in Radius":                   getPerStat("PhysicalDamage", mod.TypeIncrease, mod.MFlagUnarmed, "Dex", 1.0/3),
+	"3% increased Totem Life per 10 Strength in Radius":                                                      getPerStat("TotemLife", mod.TypeIncrease, 0, "Str", 3.0/10),
+	"3% increased Totem Life per 10 Strength Allocated in Radius":                                            getPerStat("TotemLife", mod.TypeIncrease, 0, "Str", 3.0/10),
+	"Adds 1 maximum Lightning Damage to Attacks per 1 Dexterity Allocated in Radius":                         getPerStat("LightningMax", mod.TypeBase, mod.MFlagAttack, "Dex", 1),
+	"5% increased Chaos damage per 10 Intelligence from Allocated Passives in Radius":                        getPerStat("ChaosDamage", mod.TypeIncrease, 0, "Int", 5.0/10),
+	"-1 Strength per 1 Strength on Allocated Passives in Radius":                                             getPerStat("Str", mod.TypeBase, 0, "Str", -1),
+	"1% additional Physical Damage Reduction per 10 Strength on Allocated Passives in Radius":                getPerStat("PhysicalDamageReduction", mod.TypeBase, 0, "Str", 1.0/10),
+	"2% increased Life Recovery Rate per 10 Strength on Allocated Passives in Radius":                        getPerStat("LifeRecoveryRate", mod.TypeIncrease, 0, "Str", 2.0/10),
+	"3% increased Life Recovery Rate per 10 Strength on Allocated Passives in Radius":                        getPerStat("LifeRecoveryRate", mod.TypeIncrease, 0, "Str", 3.0/10),
+	"-1 Intelligence per 1 Intelligence on Allocated Passives in Radius":                                     getPerStat("Int", mod.TypeBase, 0, "Int", -1),
+	"0.4% of Energy Shield Regenerated per Second for every 10 Intelligence on Allocated Passives in Radius": getPerStat("EnergyShieldRegenPercent", mod.TypeBase, 0, "Int", 0.4/10),
+	"2% increased Mana Recovery Rate per 10 Intelligence on Allocated Passives in Radius":                    getPerStat("ManaRecoveryRate", mod.TypeIncrease, 0, "Int", 2.0/10),
+	"3% increased Mana Recovery Rate per 10 Intelligence on Allocated Passives in Radius":                    getPerStat("ManaRecoveryRate", mod.TypeIncrease, 0, "Int", 3.0/10),
+	"-1 Dexterity per 1 Dexterity on Allocated Passives in Radius":                                           getPerStat("Dex", mod.TypeBase, 0, "Dex", -1),
+	"2% increased Movement Speed per 10 Dexterity on Allocated Passives in Radius":                           getPerStat("MovementSpeed", mod.TypeIncrease, 0, "Dex", 2.0/10),
+	"3% increased Movement Speed per 10 Dexterity on Allocated Passives in Radius":                           getPerStat("MovementSpeed", mod.TypeIncrease, 0, "Dex", 3.0/10),
+	"Dexterity and Intelligence from passives in Radius count towards Strength Melee Damage bonus": func(node *JewelFuncNode, out *moddb.ModList, data *JewelFuncData) {
+		if node != nil {
+			data.Stats["Dex"] += node.ModList.Sum(mod.TypeBase, nil, "Dex")
+			data.Stats["Int"] += node.ModList.Sum(mod.TypeBase, nil, "Int")
+		} else if data.Stats["Dex"] != 0 || data.Stats["Int"] != 0 {
+			out.AddMod(MOD("DexIntToMeleeBonus", "BASE", data.Stats["Dex"]+data.Stats["Int"]).Source(data.ModSource))
+		}
+	},
+}
+
+var jewelSelfUnallocFuncs = map[string]JewelFuncHandler{
+	"+5% to Critical Strike Multiplier per 10 Strength on Unallocated Passives in Radius":      getPerStat("CritMultiplier", mod.TypeBase, 0, "Str", 5.0/10),
+	"+7% to Critical Strike Multiplier per 10 Strength on Unallocated Passives in Radius":      getPerStat("CritMultiplier", mod.TypeBase, 0, "Str", 7.0/10),
+	"2% reduced Life Recovery Rate per 10 Strength on Unallocated Passives in Radius":          getPerStat("LifeRecoveryRate", mod.TypeIncrease, 0, "Str", -2.0/10),
+	"+15 to maximum Mana per 10 Dexterity on Unallocated Passives in Radius":                   getPerStat("Mana", mod.TypeBase, 0, "Dex", 15.0/10),
+	"+100 to Accuracy Rating per 10 Intelligence on Unallocated Passives in Radius":            getPerStat("Accuracy", mod.TypeBase, 0, "Int", 100.0/10),
+	"+125 to Accuracy Rating per 10 Intelligence on Unallocated Passives in Radius":            getPerStat("Accuracy", mod.TypeBase, 0, "Int", 125.0/10),
+	"2% reduced Mana Recovery Rate per 10 Intelligence on Unallocated Passives in Radius":      getPerStat("ManaRecoveryRate", mod.TypeIncrease, 0, "Int", -2.0/10),
+	"+3% to Damage over Time Multiplier per 10 Intelligence on Unallocated Passives in Radius": getPerStat("DotMultiplier", mod.TypeBase, 0, "Int", 3.0/10),
+	"2% reduced Movement Speed per 10 Dexterity on Unallocated Passives in Radius":             getPerStat("MovementSpeed", mod.TypeIncrease, 0, "Dex", -2.0/10),
+	"+125 to Accuracy Rating per 10 Dexterity on Unallocated Passives in Radius":               getPerStat("Accuracy", mod.TypeBase, 0, "Dex", 125.0/10),
+	"Grants all bonuses of Unallocated Small Passive Skills in Radius": func(node *JewelFuncNode, out *moddb.ModList, data *JewelFuncData) {
+		if node != nil {
+			if jewelNodeType(node.Node) == "Normal" {
+				data.ModList.AddDB(out)
+			}
+		} else {
+			out.AddDB(data.ModList)
+		}
+	},
+}
+
+// Radius jewels with bonuses conditional upon attributes of nearby nodes
+func getThreshold(attributes []string, m mod.Mod) JewelFuncHandler {
+	return func(node *JewelFuncNode, out *moddb.ModList, data *JewelFuncData) {
+		if node != nil {
+			for _, attribute := range attributes {
+				nodeValue := out.Sum(mod.TypeBase, nil, attribute)
+				data.Stats[attribute] += nodeValue
+				data.Total += nodeValue
+			}
+		} else if data.Total >= 40 {
+			out.AddMod(m.Clone().Source(data.ModSource))
+		}
+	}
+}
+
+var jewelThresholdFuncs = map[string]JewelFuncHandler{
+	"With at least 40 Dexterity in Radius, Frost Blades Melee Damage Penetrates 15% Cold Resistance":                                 getThreshold([]string{"Dex"}, MOD("ColdPenetration", "BASE", 15).Flag(mod.MFlagMelee).Tag(mod.SkillName("Frost Blades"))),
+	"With at least 40 Dexterity in Radius, Melee Damage dealt by Frost Blades Penetrates 15% Cold Resistance":                        getThreshold([]string{"Dex"}, MOD("ColdPenetration", "BASE", 15).Flag(mod.MFlagMelee).Tag(mod.SkillName("Frost Blades"))),
+	"With at least 40 Dexterity in Radius, Frost Blades has 25% increased Projectile Speed":                                          getThreshold([]string{"Dex"}, MOD("ProjectileSpeed", "INC", 25).Tag(mod.SkillName("Frost Blades"))),
+	"With at least 40 Dexterity in Radius, Ice Shot has 25% increased Area of Effect":                                                getThreshold([]string{"Dex"}, MOD("AreaOfEffect", "INC", 25).Tag(mod.SkillName("Ice Shot"))),
+	"Ice Shot Pierces 5 additional Targets with 40 Dexterity in Radius":                                                              getThreshold([]string{"Dex"}, MOD("PierceCount", "BASE", 5).Tag(mod.SkillName("Ice Shot"))),
+	"With at least 40 Dexterity in Radius, Ice Shot Pierces 3 additional Targets":                                                    getThreshold([]string{"Dex"}, MOD("PierceCount", "BASE", 3).Tag(mod.SkillName("Ice Shot"))),
+	"With at least 40 Dexterity in Radius, Ice Shot Pierces 5 additional Targets":                                                    getThreshold([]string{"Dex"}, MOD("PierceCount", "BASE", 5).Tag(mod.SkillName("Ice Shot"))),
+	"With at least 40 Intelligence in Radius, Frostbolt fires 2 additional Projectiles":                                              getThreshold([]string{"Int"}, MOD("ProjectileCount", "BASE", 2).Tag(mod.SkillName("Frostbolt"))),
+	"With at least 40 Intelligence in Radius, Rolling Magma fires an additional Projectile":                                          getThreshold([]string{"Int"}, MOD("ProjectileCount", "BASE", 1).Tag(mod.SkillName("Rolling Magma"))),
+	"With at least 40 Intelligence in Radius, Rolling Magma has 10% increased Area of Effect per Chain":                              getThreshold([]string{"Int"}, MOD("AreaOfEffect", "INC", 10).Tag(mod.SkillName("Rolling Magma")).Tag(mod.PerStat(0, "Chain"))),
+	"With at least 40 Intelligence in Radius, Rolling Magma deals 40% more damage per chain":                                         getThreshold([]string{"Int"}, MOD("Damage", "MORE", 40).Tag(mod.SkillName("Rolling Magma")).Tag(mod.PerStat(0, "Chain"))),
+	"With at least 40 Intelligence in Radius, Rolling Magma deals 50% less damage":                                                   getThreshold([]string{"Int"}, MOD("Damage", "MORE", -50).Tag(mod.SkillName("Rolling Magma"))),
+	"With at least 40 Dexterity in Radius, Shrapnel Shot has 25% increased Area of Effect":                                           getThreshold([]string{"Dex"}, MOD("AreaOfEffect", "INC", 25).Tag(mod.SkillName("Shrapnel Shot"))),
+	"With at least 40 Dexterity in Radius, Shrapnel Shot's cone has a 50% chance to deal Double Damage":                              getThreshold([]string{"Dex"}, MOD("DoubleDamageChance", "BASE", 50).Tag(mod.SkillName("Shrapnel Shot"), mod.SkillPart(2))),
+	"With at least 40 Dexterity in Radius, Galvanic Arrow deals 50% increased Area Damage":                                           getThreshold([]string{"Dex"}, MOD("Damage", "INC", 50).Tag(mod.SkillName("Galvanic Arrow"), mod.SkillPart(2))),
+	"With at least 40 Dexterity in Radius, Galvanic Arrow has 25% increased Area of Effect":                                          getThreshold([]string{"Dex"}, MOD("AreaOfEffect", "INC", 25).Tag(mod.SkillName("Galvanic Arrow"))),
+	"With at least 40 Intelligence in Radius, Freezing Pulse fires 2 additional Projectiles":                                         getThreshold([]string{"Int"}, MOD("ProjectileCount", "BASE", 2).Tag(mod.SkillName("Freezing Pulse"))),
+	"With at least 40 Intelligence in Radius, 25% increased Freezing Pulse Damage if you've Shattered an Enemy Recently":             getThreshold([]string{"Int"}, MOD("Damage", "INC", 25).Tag(mod.SkillName("Freezing Pulse"), mod.Condition("ShatteredEnemyRecently"))),
+	"With at least 40 Dexterity in Radius, Ethereal Knives fires 10 additional Projectiles":                                          getThreshold([]string{"Dex"}, MOD("ProjectileCount", "BASE", 10).Tag(mod.SkillName("Ethereal Knives"))),
+	"With at least 40 Dexterity in Radius, Ethereal Knives fires 5 additional Projectiles":                                           getThreshold([]string{"Dex"}, MOD("ProjectileCount", "BASE", 5).Tag(mod.SkillName("Ethereal Knives"))),
+	"With at least 40 Strength in Radius, Molten Strike fires 2 additional Projectiles":                                              getThreshold([]string{"Str"}, MOD("ProjectileCount", "BASE", 2).Tag(mod.SkillName("Molten Strike"))),
+	"With at least 40 Strength in Radius, Molten Strike has 25% increased Area of Effect":                                            getThreshold([]string{"Str"}, MOD("AreaOfEffect", "INC", 25).Tag(mod.SkillName("Molten Strike"))),
+	"With at least 40 Strength in Radius, Molten Strike Projectiles Chain +1 time":                                                   getThreshold([]string{"Str"}, MOD("ChainCountMax", "BASE", 1).Tag(mod.SkillName("Molten Strike"))),
+	"With at least 40 Strength in Radius, Molten Strike fires 50% less Projectiles":                                                  getThreshold([]string{"Str"}, MOD("ProjectileCount", "MORE", -50).Tag(mod.SkillName("Molten Strike"))),
+	"With at least 40 Strength in Radius, 25% of Glacial Hammer Physical Damage converted to Cold Damage":                            getThreshold([]string{"Str"}, MOD("SkillPhysicalDamageConvertToCold", "BASE", 25).Tag(mod.SkillName("Glacial Hammer"))),
+	"With at least 40 Strength in Radius, Heavy Strike has a 20% chance to deal Double Damage":                                       getThreshold([]string{"Str"}, MOD("DoubleDamageChance", "BASE", 20).Tag(mod.SkillName("Heavy Strike"))),
+	"With at least 40 Strength in Radius, Heavy Strike has a 20% chance to deal Double Damage.":                                      getThreshold([]string{"Str"}, MOD("DoubleDamageChance", "BASE", 20).Tag(mod.SkillName("Heavy Strike"))),
+	"With at least 40 Strength in Radius, Cleave has +1 to Radius per Nearby Enemy, up to +10":                                       getThreshold([]string{"Str"}, MOD("AreaOfEffect", "BASE", 1).Tag(mod.Multiplier("NearbyEnemies").Limit(10), mod.SkillName("Cleave"))),
+	"With at least 40 Strength in Radius, Cleave grants Fortify on Hit":                                                              getThreshold([]string{"Str"}, MOD("ExtraSkillMod", "LIST", mod.ExtraSkillMod{Mod: FLAG("Condition:Fortified")}).Tag(mod.SkillName("Cleave"))),
+	"With at least 40 Strength in Radius, Hits with Cleave Fortify":                                                                  getThreshold([]string{"Str"}, MOD("ExtraSkillMod", "LIST", mod.ExtraSkillMod{Mod: FLAG("Condition:Fortified")}).Tag(mod.SkillName("Cleave"))),
+	"With at least 40 Dexterity in Radius, Dual Strike has a 20% chance to deal Double Damage with the Main-Hand Weapon":             getThreshold([]string{"Dex"}, MOD("DoubleDamageChance", "BASE", 20).Tag(mod.SkillName("Dual Strike"), mod.Condition("MainHandAttack"))),
+	"With at least 40 Dexterity in Radius, Dual Strike Hits Intimidate Enemies for 4 seconds while wielding an Axe":                  getThreshold([]string{"Dex"}, MOD("EnemyModifier", "LIST", mod.EnemyModifier{Mod: FLAG("Condition:Intimidated")}).Tag(mod.Condition("UsingAxe"))),
+	"With at least 40 Intelligence in Radius, Raised Zombies' Slam Attack has 100% increased Cooldown Recovery Speed":                getThreshold([]string{"Int"}, MOD("MinionModifier", "LIST", mod.MinionModifier{Mod: MOD("CooldownRecovery", "INC", 100).Tag(mod.SkillId("ZombieSlam"))})),
+	"With at least 40 Intelligence in Radius, Raised Zombies' Slam Attack deals 30% increased Damage":                                getThreshold([]string{"Int"}, MOD("MinionModifier", "LIST", mod.MinionModifier{Mod: MOD("Damage", "INC", 30).Tag(mod.SkillId("ZombieSlam"))})),
+	"With at least 40 Dexterity in Radius, Viper Strike deals 2% increased Attack Damage for each Poison on the Enemy":               getThreshold([]string{"Dex"}, MOD("Damage", "INC", 2).Flag(mod.MFlagAttack).Tag(mod.SkillName("Viper Strike"), mod.Multiplier("PoisonStack").Actor("enemy"))),
+	"With at least 40 Dexterity in Radius, Viper Strike deals 2% increased Damage with Hits and Poison for each Poison on the Enemy": getThreshold([]string{"Dex"}, MOD("Damage", "INC", 2).KeywordFlag(mod.KeywordFlagHit|mod.KeywordFlagPoison).Tag(mod.SkillName("Viper Strike"), mod.Multiplier("PoisonStack").Actor("enemy"))),
+	"With at least 40 Intelligence in Radius, Spark fires 2 additional Projectiles":                                                  getThreshold([]string{"Int"}, MOD("ProjectileCount", "BASE", 2).Tag(mod.SkillName("Spark"))),
+	"With at least 40 Intelligence in Radius, Blight has 50% increased Hinder Duration":                                              getThreshold([]string{"Int"}, MOD("SecondaryDuration", "INC", 50).Tag(mod.SkillName("Blight"))),
+	"With at least 40 Intelligence in Radius, Enemies Hindered by Blight take 25% increased Chaos Damage":                            getThreshold([]string{"Int"}, MOD("ExtraSkillMod", "LIST", mod.ExtraSkillMod{Mod: MOD("ChaosDamageTaken", "INC", 25).Tag(mod.GlobalEffect("Debuff").Name("Hinder"))}).Tag(mod.SkillName("Blight"), mod.ActorCondition("enemy", "Hindered"))),
+	"With 40 Intelligence in Radius, 20% of Glacial Cascade Physical Damage Converted to Cold Damage":                                getThreshold([]string{"Int"}, MOD("SkillPhysicalDamageConvertToCold", "BASE", 20).Tag(mod.SkillName("Glacial Cascade"))),
+	"With at least 40 Intelligence in Radius, 20% of Glacial Cascade Physical Damage Converted to Cold Damage":                       getThreshold([]string{"Int"}, MOD("SkillPhysicalDamageConvertToCold", "BASE", 20).Tag(mod.SkillName("Glacial Cascade"))),
+	"With 40 total Intelligence and Dexterity in Radius, Elemental Hit and Wild Strike deal 50% less Fire Damage":                    getThreshold([]string{"Int", "Dex"}, MOD("FireDamage", "MORE", -50).Tag(mod.SkillName("Elemental Hit", "Wild Strike"))),
+	"With 40 total Strength and Intelligence in Radius, Elemental Hit and Wild Strike deal 50% less Cold Damage":                     getThreshold([]string{"Str", "Int"}, MOD("ColdDamage", "MORE", -50).Tag(mod.SkillName("Elemental Hit", "Wild Strike"))),
+	"With 40 total Dexterity and Strength in Radius, Elemental Hit and Wild Strike deal 50% less Lightning Damage":                   getThreshold([]string{"Dex", "Str"}, MOD("LightningDamage", "MORE", -50).Tag(mod.SkillName("Elemental Hit", "Wild Strike"))),
+	"With 40 total Intelligence and Dexterity in Radius, Prismatic Skills deal 50% less Fire Damage":                                 getThreshold([]string{"Int", "Dex"}, MOD("FireDamage", "MORE", -50).Tag(mod.SkillType(string(data.SkillTypeRandomElement)))),
+	"With 40 total Strength and Intelligence in Radius, Prismatic Skills deal 50% less Cold Damage":                                  getThreshold([]string{"Str", "Int"}, MOD("ColdDamage", "MORE", -50).Tag(mod.SkillType(string(data.SkillTypeRandomElement)))),
+	"With 40 total Dexterity and Strength in Radius, Prismatic Skills deal 50% less Lightning Damage":                                getThreshold([]string{"Dex", "Str"}, MOD("LightningDamage", "MORE", -50).Tag(mod.SkillType(string(data.SkillTypeRandomElement)))),
+	"With 40 total Dexterity and Strength in Radius, Spectral Shield Throw Chains +4 times":                                          getThreshold([]string{"Dex", "Str"}, MOD("ChainCountMax", "BASE", 4).Tag(mod.SkillName("Spectral Shield Throw"))),
+	"With 40 total Dexterity and Strength in Radius, Spectral Shield Throw fires 75% less Shard Projectiles":                         getThreshold([]string{"Dex", "Str"}, MOD("ProjectileCount", "MORE", -75).Tag(mod.SkillName("Spectral Shield Throw"))),
+	"With at least 40 Intelligence in Radius, Blight inflicts Withered for 2 seconds":                                                getThreshold([]string{"Int"}, MOD("ExtraSkillMod", "LIST", mod.ExtraSkillMod{Mod: FLAG("Condition:CanWither")}).Tag(mod.SkillName("Blight"))),
+	"With at least 40 Intelligence in Radius, Blight has 30% reduced Cast Speed":                                                     getThreshold([]string{"Int"}, MOD("Speed", "INC", -30).Tag(mod.SkillName("Blight"))),
+	"With at least 40 Intelligence in Radius, Fireball cannot ignite":                                                                getThreshold([]string{"Int"}, MOD("ExtraSkillMod", "LIST", mod.ExtraSkillMod{Mod: FLAG("CannotIgnite")}).Tag(mod.SkillName("Fireball"))),
+	"With at least 40 Intelligence in Radius, Discharge has 60% less Area of Effect":                                                 getThreshold([]string{"Int"}, MOD("AreaOfEffect", "MORE", -60).Tag(mod.SkillName("Discharge"))),
+	"With at least 40 Intelligence in Radius, Discharge Cooldown is 250 ms":                                                          getThreshold([]string{"Int"}, MOD("CooldownRecovery", "OVERRIDE", 0.25).Tag(mod.SkillName("Discharge"))),
+	"With at least 40 Intelligence in Radius, Discharge deals 60% less Damage":                                                       getThreshold([]string{"Int"}, MOD("Damage", "MORE", -60).Tag(mod.SkillName("Discharge"))),
+}
+
+// Threshold jewels parametrised by the captures of the pattern
+var jewelThresholdFuncPatterns = map[string]func(captures []string) JewelFuncHandler{
+	`with at least 40 dexterity in radius, dual strike has (\d+)% increased attack speed while wielding a claw`: func(captures []string) JewelFuncHandler {
+		return getThreshold([]string{"Dex"}, MOD("Speed", "INC", utils.Float(captures[0])).Tag(mod.SkillName("Dual Strike"), mod.Condition("UsingClaw")))
+	},
+	`with at least 40 dexterity in radius, dual strike has \+(\d+)% to critical strike multiplier while wielding a dagger`: func(captures []string) JewelFuncHandler {
+		return getThreshold([]string{"Dex"}, MOD("CritMultiplier", "BASE", utils.Float(captures[0])).Tag(mod.SkillName("Dual Strike"), mod.Condition("UsingDagger")))
+	},
+	`with at least 40 dexterity in radius, dual strike has (\d+)% increased accuracy rating while wielding a sword`: func(captures []string) JewelFuncHandler {
+		return getThreshold([]string{"Dex"}, MOD("Accuracy", "INC", utils.Float(captures[0])).Tag(mod.SkillName("Dual Strike"), mod.Condition("UsingSword")))
+	},
+	`with at least 40 intelligence in radius, fireball has \+(\d+)% chance to inflict scorch`: func(captures []string) JewelFuncHandler {
+		return getThreshold([]string{"Int"}, MOD("EnemyScorchChance", "BASE", utils.Float(captures[0])).Tag(mod.SkillName("Fireball")))
+	},
+}
+
+type jewelFuncPattern struct {
+	regex    *regexp.Regexp
+	funcType JewelFuncType
+	fn       func(captures []string) JewelFuncHandler
 }
-*/
 
 // Unified list of jewel functions
-var jewelFuncList = make(map[string]interface{})
+var jewelFuncList = make(map[string]JewelFunc)
+var jewelFuncPatternList = make([]jewelFuncPattern, 0)
 
-/*
-// TODO Jewels that modify nodes
-for k, v in pairs(jewelOtherFuncs) do
-	jewelFuncList[k:lower()] = { func = function(cap1, cap2, cap3, cap4, cap5)
-		// TODO Need to not modify any nodes already modified by timeless jewels
-		// TODO Some functions return a function instead of simply adding mods, so if
-		// TODO we don't see a node right away, run the outer function first
-		if cap1 and type(cap1) == "table" and cap1.conqueredBy then
-			return
-		end
-		local innerFuncOrNil = v(cap1, cap2, cap3, cap4, cap5)
-		// TODO In all (current) cases, there is only one nested layer, so no need for recursion
-		return function(node, out, other)
-			if node and type(node) == "table" and node.conqueredBy then
-				return
-			end
-			return innerFuncOrNil(node, out, other)
-		end
-	end, type = "Other" }
-end
-for k, v in pairs(jewelSelfFuncs) do
-	jewelFuncList[k:lower()] = { func = v, type = "Self" }
-end
-for k, v in pairs(jewelSelfUnallocFuncs) do
-	jewelFuncList[k:lower()] = { func = v, type = "SelfUnalloc" }
-end
-// TODO Threshold Jewels
-for k, v in pairs(jewelThresholdFuncs) do
-	jewelFuncList[k:lower()] = { func = v, type = "Threshold" }
-end
+func init() {
+	// TODO Need to not modify any nodes already modified by timeless jewels
+	for line, fn := range jewelOtherFuncs {
+		jewelFuncList[strings.ToLower(line)] = JewelFunc{Type: JewelFuncTypeOther, Func: fn}
+	}
+	for line, fn := range jewelSelfFuncs {
+		jewelFuncList[strings.ToLower(line)] = JewelFunc{Type: JewelFuncTypeSelf, Func: fn}
+	}
+	for line, fn := range jewelSelfUnallocFuncs {
+		jewelFuncList[strings.ToLower(line)] = JewelFunc{Type: JewelFuncTypeSelfUnalloc, Func: fn}
+	}
+	for line, fn := range jewelThresholdFuncs {
+		jewelFuncList[strings.ToLower(line)] = JewelFunc{Type: JewelFuncTypeThreshold, Func: fn}
+	}
 
-// TODO Generate list of cluster jewel skills
-local clusterJewelSkills = {}
-for baseName, jewel in pairs(data.clusterJewels.jewels) do
-	for skillId, skill in pairs(jewel.skills) do
-		clusterJewelSkills[table.concat(skill.enchant, " "):lower()] = { MOD("JewelData", "LIST", mod.JewelData{ Key: "clusterJewelSkill", Value: skillId }) }
-	end
-end
-for notable in pairs(data.clusterJewels.notableSortOrder) do
-	clusterJewelSkills["1 added passive skill is "..notable:lower()] = { MOD("ClusterJewelNotable", "LIST", notable) }
-end
-for _, keystone in ipairs(data.clusterJewels.keystones) do
-	clusterJewelSkills["adds "..keystone:lower()] = { MOD("JewelData", "LIST", mod.JewelData{ Key: "clusterJewelKeystone", Value: keystone }) }
-end
-*/
+	for pattern, fn := range jewelOtherFuncPatterns {
+		jewelFuncPatternList = append(jewelFuncPatternList, jewelFuncPattern{regex: regexp.MustCompile(pattern), funcType: JewelFuncTypeOther, fn: fn})
+	}
+	for pattern, fn := range jewelThresholdFuncPatterns {
+		jewelFuncPatternList = append(jewelFuncPatternList, jewelFuncPattern{regex: regexp.MustCompile(pattern), funcType: JewelFuncTypeThreshold, fn: fn})
+	}
+}
 
 // Scan a line for the earliest and longest match from the pattern list
 // If a match is found, returns the corresponding value from the pattern list, plus the remainder of the line and a table of captures
@@ -5940,23 +5977,16 @@ func scan[T any](line string, patternList map[string]CompiledList[T], plain bool
 
 func parseMod(line string, order int) ([]mod.Mod, string) {
 	lineLower := strings.ToLower(line)
-	/*
-		// TODO Check if this is a special modifier
-		for pattern, patternVal in pairs(jewelFuncList) do
-			local _, _, cap1, cap2, cap3, cap4, cap5 = lineLower:find(pattern, 1)
-			if cap1 then
-				return {MOD("JewelFunc", "LIST", mod.JewelFunc{func = patternVal.func(cap1, cap2, cap3, cap4, cap5), type = patternVal.type}) }
-			end
-		end
-		local jewelFunc = jewelFuncList[lineLower]
-		if jewelFunc then
-			return { MOD("JewelFunc", "LIST", jewelFunc) }
-		end
-		local clusterJewelSkill = clusterJewelSkills[lineLower]
-		if clusterJewelSkill then
-			return clusterJewelSkill
-		end
-	*/
+	// Check if this is a special modifier
+	for _, pattern := range jewelFuncPatternList {
+		if captures := pattern.regex.FindStringSubmatch(lineLower); captures != nil {
+			return []mod.Mod{MOD("JewelFunc", "LIST", JewelFunc{Type: pattern.funcType, Func: pattern.fn(captures[1:])})}, ""
+		}
+	}
+
+	if jewelFunc, ok := jewelFuncList[lineLower]; ok {
+		return []mod.Mod{MOD("JewelFunc", "LIST", jewelFunc)}, ""
+	}
 
 	if _, ok := unsupportedModList[lineLower]; ok {
 		return nil, line
@@ -5974,13 +6004,10 @@ func parseMod(line string, order int) ([]mod.Mod, string) {
 		return (*specialMod).([]mod.Mod), ""
 	}
 
-	/*
-		// TODO Check for add-to-cluster-jewel special
-		local addToCluster = line:match("^Added Small Passive Skills also grant: (.+)$")
-		if addToCluster then
-			return { MOD("AddToClusterJewelNode", "LIST", addToCluster) }
-		end
-	*/
+	// Check for add-to-cluster-jewel special
+	if addToCluster, ok := strings.CutPrefix(line, "Added Small Passive Skills also grant: "); ok {
+		return []mod.Mod{MOD("AddToClusterJewelNode", "LIST", addToCluster)}, ""
+	}
 
 	line = line + " "
 
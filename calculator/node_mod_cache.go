@@ -0,0 +1,135 @@
+package calculator
+
+import (
+	"bytes"
+	"fmt"
+	"log/slog"
+	"runtime/debug"
+	"strconv"
+
+	"github.com/tinylib/msgp/msgp"
+
+	"github.com/Vilsol/go-pob/cache"
+	"github.com/Vilsol/go-pob/data"
+	"github.com/Vilsol/go-pob/moddb"
+)
+
+// modParserVersion must be bumped whenever a change to the mod parser changes its output.
+// It is part of the disk cache key, so bumping it invalidates all persisted node mods.
+const modParserVersion = 4
+
+func nodeModCacheKey(treeVersion data.TreeVersion) string {
+	key := "go-pob/node-mods/" + string(treeVersion) + "/" + strconv.Itoa(modParserVersion)
+
+	// Release builds carry their revision, which catches parser changes without a version bump
+	if info, ok := debug.ReadBuildInfo(); ok {
+		for _, setting := range info.Settings {
+			if setting.Key == "vcs.revision" {
+				key += "/" + setting.Value
+			}
+		}
+	}
+
+	return key
+}
+
+// loadNodeMods returns the persisted node mod table for the tree version, or an empty table if there is none
+func loadNodeMods(treeVersion data.TreeVersion) map[string]moddb.ModList {
+	key := nodeModCacheKey(treeVersion)
+	if cache.Disk().Exists(key) {
+		modsForNodes, err := readNodeMods(key)
+		if err == nil {
+			return modsForNodes
+		}
+
+		slog.Warn("failed to load node mods from cache", slog.String("key", key), slog.Any("err", err))
+	}
+
+	return make(map[string]moddb.ModList, len(data.TreeVersions[treeVersion].Tree().Nodes))
+}
+
+// saveNodeMods persists the node mod table if any nodes were parsed since it was last loaded or saved
+func (c *EnvironmentCache) saveNodeMods() {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	if !c.modsForNodesDirty {
+		return
+	}
+
+	b, err := encodeNodeMods(c.modsForNodes)
+	if err == nil {
+		err = cache.Disk().Set(nodeModCacheKey(c.TreeVersion), b)
+	}
+
+	if err != nil {
+		slog.Warn("failed to save node mods to cache", slog.Any("err", err))
+		return
+	}
+
+	c.modsForNodesDirty = false
+}
+
+func encodeNodeMods(modsForNodes map[string]moddb.ModList) ([]byte, error) {
+	var buf bytes.Buffer
+	w := msgp.NewWriter(&buf)
+
+	if err := w.WriteMapHeader(uint32(len(modsForNodes))); err != nil {
+		return nil, fmt.Errorf("failed to write node mods header: %w", err)
+	}
+
+	for nodeID, modList := range modsForNodes {
+		if err := w.WriteString(nodeID); err != nil {
+			return nil, fmt.Errorf("failed to write node id: %w", err)
+		}
+
+		if err := modList.EncodeMsg(w); err != nil {
+			return nil, fmt.Errorf("failed to write mods of node %s: %w", nodeID, err)
+		}
+	}
+
+	if err := w.Flush(); err != nil {
+		return nil, fmt.Errorf("failed to flush node mods: %w", err)
+	}
+
+	return buf.Bytes(), nil
+}
+
+func readNodeMods(key string) (map[string]moddb.ModList, error) {
+	b, err := cache.Disk().Get(key)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read node mods: %w", err)
+	}
+
+	if b == nil {
+		return nil, fmt.Errorf("node mods missing from cache")
+	}
+
+	return decodeNodeMods(b)
+}
+
+func decodeNodeMods(b []byte) (map[string]moddb.ModList, error) {
+	r := msgp.NewReader(bytes.NewReader(b))
+
+	size, err := r.ReadMapHeader()
+	if err != nil {
+		return nil, fmt.Errorf("failed to read node mods header: %w", err)
+	}
+
+	modsForNodes := make(map[string]moddb.ModList, size)
+	for i := uint32(0); i < size; i++ {
+		nodeID, err := r.ReadString()
+		if err != nil {
+			return nil, fmt.Errorf("failed to read node id: %w", err)
+		}
+
+		modList := moddb.NewModList()
+		if err := modList.DecodeMsg(r); err != nil {
+			return nil, fmt.Errorf("failed to read mods of node %s: %w", nodeID, err)
+		}
+
+		modsForNodes[nodeID] = *modList
+	}
+
+	return modsForNodes, nil
+}
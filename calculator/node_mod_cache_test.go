@@ -0,0 +1,52 @@
+package calculator
+
+import (
+	"context"
+	"strconv"
+	"testing"
+
+	"github.com/MarvinJWendt/testza"
+	"github.com/Vilsol/go-pob-data/poe"
+
+	"github.com/Vilsol/go-pob/cache"
+	"github.com/Vilsol/go-pob/data"
+	"github.com/Vilsol/go-pob/data/raw"
+	"github.com/Vilsol/go-pob/moddb"
+)
+
+func TestNodeModsEncodeDecode(t *testing.T) {
+	err := poe.InitializeAll(context.Background(), raw.LatestVersion, cache.Disk(), nil)
+	testza.AssertNoError(t, err)
+
+	env := &Environment{}
+	tree := data.TreeVersions[data.LatestTreeVersion].Tree()
+
+	modsForNodes := make(map[string]moddb.ModList, len(tree.Nodes))
+	for nodeID, node := range tree.Nodes {
+		modsForNodes[nodeID] = *parseModListForNode(env, node)
+	}
+
+	b, err := encodeNodeMods(modsForNodes)
+	testza.AssertNoError(t, err)
+
+	decoded, err := decodeNodeMods(b)
+	testza.AssertNoError(t, err)
+	testza.AssertLen(t, decoded, len(modsForNodes))
+
+	// Re-encoding the decoded table must yield the same bytes for every node
+	for nodeID, modList := range modsForNodes {
+		expected, err := encodeNodeMods(map[string]moddb.ModList{nodeID: modList})
+		testza.AssertNoError(t, err)
+
+		got, err := encodeNodeMods(map[string]moddb.ModList{nodeID: decoded[nodeID]})
+		testza.AssertNoError(t, err)
+
+		testza.AssertEqual(t, expected, got, nodeID)
+	}
+}
+
+func TestNodeModCacheKey(t *testing.T) {
+	key := nodeModCacheKey(data.LatestTreeVersion)
+	testza.AssertContains(t, key, string(data.LatestTreeVersion))
+	testza.AssertContains(t, key, "/"+strconv.Itoa(modParserVersion))
+}
@@ -0,0 +1,286 @@
+package calculator
+
+import (
+	"context"
+	"fmt"
+	"runtime"
+	"slices"
+	"strconv"
+	"sync"
+
+	"github.com/Vilsol/go-pob/data"
+	"github.com/Vilsol/go-pob/pob"
+)
+
+const defaultNodePowerBatchSize = 16
+
+type NodePowerOptions struct {
+	// Output stat to evaluate, e.g. TotalDPS or Life
+	Stat string
+
+	// Nodes to evaluate, all unallocated nodes of the tree if empty
+	Nodes []int64
+
+	// Amount of parallel calculations, GOMAXPROCS if zero
+	Workers int
+
+	// Amount of nodes per progress update
+	BatchSize int
+}
+
+type NodePower struct {
+	NodeID int64
+
+	// Change of the stat when allocating only this node
+	Delta float64
+
+	// Only set for notables and keystones: the unallocated nodes on the shortest path to the node (including it),
+	// the change of the stat when allocating all of them, and that change divided by the points spent
+	PathNodes []int64
+	PathDelta float64
+	PerPoint  float64
+}
+
+type NodePowerProgress struct {
+	Done  int
+	Total int
+	Batch []NodePower
+}
+
+// CalculateNodePower evaluates the node power of the calculated build, see CalculateNodePower.
+//
+// The calculation stops with an error once the progress function (if any) returns true.
+//
+// crystalline:promise
+func (c *Calculator) CalculateNodePower(stat string, progress func(progress NodePowerProgress) bool) ([]NodePower, error) {
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	var onProgress func(progress NodePowerProgress)
+	if progress != nil {
+		onProgress = func(p NodePowerProgress) {
+			if progress(p) {
+				cancel()
+			}
+		}
+	}
+
+	return CalculateNodePower(ctx, c.PoB, NodePowerOptions{Stat: stat}, onProgress)
+}
+
+// CalculateNodePower evaluates the marginal change of an output stat for allocating each unallocated node.
+//
+// Nodes are evaluated in parallel batches. After each batch the progress function (if any) is invoked
+// with the batch results from the calling goroutine. Results are returned in the order of the evaluated nodes.
+func CalculateNodePower(ctx context.Context, build *pob.PathOfBuilding, options NodePowerOptions, progress func(progress NodePowerProgress)) ([]NodePower, error) {
+	treeVersion := data.TreeVersions[data.LatestTreeVersion]
+	tree := treeVersion.Tree()
+
+	// Evaluating the current build first also warms up all shared caches before going parallel
+	base := evaluateStat(build, build.Build.PassiveNodes, options.Stat)
+
+	allocated := make(map[int64]bool, len(build.Build.PassiveNodes))
+	for _, id := range build.Build.PassiveNodes {
+		allocated[id] = true
+	}
+
+	nodes := options.Nodes
+	if len(nodes) == 0 {
+		nodes = nodePowerCandidates(tree, allocated, data.AscendancyName(build.Build.AscendClassName))
+	}
+
+	warmNodeMods(tree, nodes)
+
+	paths := treeVersion.CalculateAllocationPaths(
+		build.Build.PassiveNodes,
+		treeVersion.RootNodes(data.ClassName(build.Build.ClassName), data.AscendancyName(build.Build.AscendClassName)),
+		newSocketedPassiveSpec(build, data.LatestTreeVersion).GraphExtensions()...,
+	)
+
+	batchSize := options.BatchSize
+	if batchSize <= 0 {
+		batchSize = defaultNodePowerBatchSize
+	}
+
+	workers := options.Workers
+	if workers <= 0 {
+		workers = runtime.GOMAXPROCS(0)
+	}
+
+	type batchResult struct {
+		offset int
+		powers []NodePower
+	}
+
+	ctx, cancel := context.WithCancel(ctx)
+	defer cancel()
+
+	offsets := make(chan int)
+	results := make(chan batchResult)
+
+	var wg sync.WaitGroup
+	for i := 0; i < workers; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for offset := range offsets {
+				batch := nodes[offset:min(offset+batchSize, len(nodes))]
+				powers := make([]NodePower, 0, len(batch))
+				for _, nodeID := range batch {
+					if ctx.Err() != nil {
+						return
+					}
+					powers = append(powers, calculateSingleNodePower(build, tree, paths, allocated, base, nodeID, options.Stat))
+				}
+
+				select {
+				case results <- batchResult{offset: offset, powers: powers}:
+				case <-ctx.Done():
+					return
+				}
+			}
+		}()
+	}
+
+	go func() {
+		defer close(offsets)
+		for offset := 0; offset < len(nodes); offset += batchSize {
+			select {
+			case offsets <- offset:
+			case <-ctx.Done():
+				return
+			}
+		}
+	}()
+
+	go func() {
+		wg.Wait()
+		close(results)
+	}()
+
+	powers := make([]NodePower, len(nodes))
+	done := 0
+	for result := range results {
+		copy(powers[result.offset:], result.powers)
+		done += len(result.powers)
+
+		if progress != nil {
+			progress(NodePowerProgress{
+				Done:  done,
+				Total: len(nodes),
+				Batch: result.powers,
+			})
+		}
+	}
+
+	if err := ctx.Err(); err != nil && done < len(nodes) {
+		return nil, fmt.Errorf("node power calculation cancelled: %w", err)
+	}
+
+	return powers, nil
+}
+
+func calculateSingleNodePower(build *pob.PathOfBuilding, tree *data.Tree, paths map[int64]int64, allocated map[int64]bool, base float64, nodeID int64, stat string) NodePower {
+	power := NodePower{
+		NodeID: nodeID,
+		Delta:  evaluateStat(build, append(slices.Clip(build.Build.PassiveNodes), nodeID), stat) - base,
+	}
+
+	node := tree.Nodes[strconv.FormatInt(nodeID, 10)]
+	if (node.IsNotable == nil || !*node.IsNotable) && (node.IsKeystone == nil || !*node.IsKeystone) {
+		return power
+	}
+
+	power.PathNodes = allocationPath(paths, allocated, nodeID)
+	if len(power.PathNodes) == 0 {
+		return power
+	}
+
+	if len(power.PathNodes) == 1 {
+		power.PathDelta = power.Delta
+	} else {
+		power.PathDelta = evaluateStat(build, append(slices.Clip(build.Build.PassiveNodes), power.PathNodes...), stat) - base
+	}
+
+	power.PerPoint = power.PathDelta / float64(len(power.PathNodes))
+
+	return power
+}
+
+// allocationPath follows the next hops from CalculateAllocationPaths from the target node towards
+// the allocated tree, returning all unallocated nodes on the way. Returns nil if the node is unreachable.
+func allocationPath(paths map[int64]int64, allocated map[int64]bool, target int64) []int64 {
+	path := make([]int64, 0)
+	for current := target; current != -1; {
+		next, ok := paths[current]
+		if !ok {
+			return nil
+		}
+
+		if !allocated[current] {
+			path = append(path, current)
+		}
+
+		current = next
+	}
+	return path
+}
+
+// nodePowerCandidates returns all unallocated nodes with stats that can be allocated by the ascendancy
+func nodePowerCandidates(tree *data.Tree, allocated map[int64]bool, ascendancy data.AscendancyName) []int64 {
+	candidates := make([]int64, 0, len(tree.Nodes))
+	for _, node := range tree.Nodes {
+		if node.Skill == nil || allocated[*node.Skill] || len(node.Stats) == 0 {
+			continue
+		}
+
+		if node.ClassStartIndex != nil || (node.IsMastery != nil && *node.IsMastery) || (node.IsProxy != nil && *node.IsProxy) {
+			continue
+		}
+
+		if node.AscendancyName != nil && *node.AscendancyName != string(ascendancy) {
+			continue
+		}
+
+		candidates = append(candidates, *node.Skill)
+	}
+
+	slices.Sort(candidates)
+	return candidates
+}
+
+// warmNodeMods parses all provided nodes up front, so the node mod table
+// is persisted once instead of after every calculation
+func warmNodeMods(tree *data.Tree, nodes []int64) {
+	warmup := make(map[string]data.Node, len(nodes))
+	for _, id := range nodes {
+		nodeID := strconv.FormatInt(id, 10)
+		warmup[nodeID] = tree.Nodes[nodeID]
+	}
+
+	buildModListForNodeList(&Environment{Cache: envCache}, warmup, false)
+	envCache.saveNodeMods()
+}
+
+// evaluateStat calculates the build with the provided passive nodes allocated and returns the player output stat
+func evaluateStat(build *pob.PathOfBuilding, passiveNodes []int64, stat string) float64 {
+	return evaluateOutput(build, passiveNodes)[stat]
+}
+
+// evaluateOutput calculates the build with the provided passive nodes allocated and returns the player output
+func evaluateOutput(build *pob.PathOfBuilding, passiveNodes []int64) map[string]float64 {
+	return evaluateAllocation(build, passiveNodes, build.Build.MasteryEffects)
+}
+
+// evaluateAllocation calculates the build with the provided passive nodes allocated and mastery effects selected
+// and returns the player output
+func evaluateAllocation(build *pob.PathOfBuilding, passiveNodes []int64, masteryEffects map[int64]int64) map[string]float64 {
+	modified := *build
+	modified.Build.PassiveNodes = passiveNodes
+	modified.Build.MasteryEffects = masteryEffects
+
+	env, _, _, _ := InitEnv(&modified, envCache, OutputModeMain)
+	PerformCalc(env)
+
+	return env.Player.Output
+}
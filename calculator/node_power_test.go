@@ -0,0 +1,85 @@
+package calculator
+
+import (
+	"context"
+	"os"
+	"strconv"
+	"testing"
+
+	"github.com/MarvinJWendt/testza"
+
+	"github.com/Vilsol/go-pob/builds"
+	"github.com/Vilsol/go-pob/data"
+)
+
+func TestCalculateNodePower(t *testing.T) {
+	file, err := os.ReadFile("../testdata/builds/Fireball.xml")
+	testza.AssertNoError(t, err)
+
+	build, err := builds.ParseBuild(file)
+	testza.AssertNoError(t, err)
+
+	// Three small strength nodes and the Path of the Warrior notable
+	strNodes := []int64{14930, 34400, 49412}
+	pathOfTheWarrior := int64(12702)
+
+	tree := data.TreeVersions[data.LatestTreeVersion].Tree()
+	for _, id := range strNodes {
+		testza.AssertEqual(t, []string{"+10 to Strength"}, tree.Nodes[strconv.FormatInt(id, 10)].Stats)
+	}
+
+	updates := 0
+	powers, err := CalculateNodePower(context.Background(), build, NodePowerOptions{
+		Stat:      "Str",
+		Nodes:     append(strNodes, pathOfTheWarrior),
+		BatchSize: 1,
+	}, func(progress NodePowerProgress) {
+		updates++
+		testza.AssertEqual(t, updates, progress.Done)
+		testza.AssertEqual(t, 4, progress.Total)
+		testza.AssertLen(t, progress.Batch, 1)
+	})
+	testza.AssertNoError(t, err)
+	testza.AssertEqual(t, 4, updates)
+	testza.AssertLen(t, powers, 4)
+
+	for i, id := range strNodes {
+		testza.AssertEqual(t, id, powers[i].NodeID)
+		testza.AssertEqual(t, float64(10), powers[i].Delta)
+		testza.AssertNil(t, powers[i].PathNodes)
+	}
+
+	notable := powers[3]
+	testza.AssertEqual(t, pathOfTheWarrior, notable.NodeID)
+	testza.AssertGreater(t, len(notable.PathNodes), 1)
+	testza.AssertEqual(t, pathOfTheWarrior, notable.PathNodes[0])
+	testza.AssertGreater(t, notable.PathDelta, notable.Delta)
+	testza.AssertEqual(t, notable.PathDelta/float64(len(notable.PathNodes)), notable.PerPoint)
+}
+
+func TestCalculateNodePowerCancel(t *testing.T) {
+	file, err := os.ReadFile("../testdata/builds/Fireball.xml")
+	testza.AssertNoError(t, err)
+
+	build, err := builds.ParseBuild(file)
+	testza.AssertNoError(t, err)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	_, err = CalculateNodePower(ctx, build, NodePowerOptions{Stat: "Str", Workers: 1, BatchSize: 1}, func(progress NodePowerProgress) {
+		cancel()
+	})
+	testza.AssertErrorIs(t, err, context.Canceled)
+}
+
+func TestCalculatorCalculateNodePowerStop(t *testing.T) {
+	file, err := os.ReadFile("../testdata/builds/Fireball.xml")
+	testza.AssertNoError(t, err)
+
+	build, err := builds.ParseBuild(file)
+	testza.AssertNoError(t, err)
+
+	_, err = NewCalculator(*build).CalculateNodePower("Str", func(progress NodePowerProgress) bool {
+		return true
+	})
+	testza.AssertErrorIs(t, err, context.Canceled)
+}
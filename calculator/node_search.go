@@ -0,0 +1,407 @@
+package calculator
+
+import (
+	"cmp"
+	"fmt"
+	"regexp"
+	"slices"
+	"strconv"
+	"strings"
+	"sync"
+	"unicode"
+
+	"github.com/Vilsol/go-pob/data"
+	"github.com/Vilsol/go-pob/mod"
+	"github.com/Vilsol/go-pob/pob"
+	"github.com/Vilsol/go-pob/utils"
+)
+
+// Scores of a search term matching a token of a node, matches in the node name count double
+const (
+	searchScoreExact  = 3
+	searchScorePrefix = 2
+	searchScoreFuzzy  = 1
+	searchScoreStat   = 4
+)
+
+// Leading phrases of natural stat queries, e.g. "nodes granting increased fire damage"
+var statQueryPrefixes = []string{"nodes granting ", "nodes with ", "nodes that grant ", "granting ", "grants "}
+
+type NodeSearchResult struct {
+	NodeID int64
+
+	// Unallocated nodes to allocate to reach the node, zero if allocated and -1 if it can't be reached
+	Distance int
+
+	// Relevance of the node for the query
+	Score int
+
+	// Name, stat, reminder and mastery effect lines of the node that matched the query
+	Matches []string
+}
+
+type nodeSearchLine struct {
+	text   string
+	tokens []string
+	mods   []mod.Mod
+}
+
+type nodeSearchEntry struct {
+	id    int64
+	lines []nodeSearchLine
+}
+
+// nodeSearchIndex is an inverted index of the words of all node lines
+type nodeSearchIndex struct {
+	entries []nodeSearchEntry
+	tokens  map[string][]int
+}
+
+var (
+	nodeSearchIndexesMu sync.Mutex
+	nodeSearchIndexes   = make(map[data.TreeVersion]*nodeSearchIndex)
+)
+
+// crystalline:promise
+func (c *Calculator) SearchNodes(query string) []NodeSearchResult {
+	result, _ := SearchNodes(c.PoB, query)
+	return result
+}
+
+// SearchNodes finds the nodes of the tree matching the query, or the search string of the tree view if the query is empty.
+//
+// Queries wrapped in slashes are case-insensitive regular expressions matched against every line of a node. Other
+// queries match nodes that contain every word of the query, allowing typos in longer words, as well as nodes whose
+// mods match the mods the query parses into, e.g. "increased fire damage" or "nodes granting +# to maximum life".
+//
+// Results are ordered by the amount of points required to reach them from the current allocation, then by score.
+func SearchNodes(build *pob.PathOfBuilding, query string) ([]NodeSearchResult, error) {
+	if strings.TrimSpace(query) == "" {
+		query = build.TreeView.SearchStr
+	}
+
+	query = strings.TrimSpace(query)
+	if query == "" {
+		return []NodeSearchResult{}, nil
+	}
+
+	index := getNodeSearchIndex(data.LatestTreeVersion)
+
+	var scores map[int]int
+	var matches map[int][]string
+	if len(query) >= 2 && strings.HasPrefix(query, "/") && strings.HasSuffix(query, "/") {
+		pattern, err := regexp.Compile("(?i)" + query[1:len(query)-1])
+		if err != nil {
+			return nil, fmt.Errorf("invalid search pattern: %w", err)
+		}
+
+		scores, matches = index.searchRegex(pattern)
+	} else {
+		scores, matches = index.searchText(query)
+		statScores, statMatches := index.searchStats(query)
+		for i, score := range statScores {
+			scores[i] += score
+			matches[i] = append(matches[i], statMatches[i]...)
+		}
+	}
+
+	spec := newSocketedPassiveSpec(build, data.LatestTreeVersion)
+	treeVersion := data.TreeVersions[data.LatestTreeVersion]
+
+	allocated := make(map[int64]bool, len(build.Build.PassiveNodes))
+	for _, id := range build.Build.PassiveNodes {
+		allocated[id] = true
+	}
+
+	roots := treeVersion.RootNodes(spec.ClassName, spec.AscendancyName)
+	paths := treeVersion.CalculateAllocationPaths(build.Build.PassiveNodes, roots, spec.GraphExtensions()...)
+
+	results := make([]NodeSearchResult, 0, len(scores))
+	for i, score := range scores {
+		entry := index.entries[i]
+
+		distance := -1
+		if path := allocationPath(paths, allocated, entry.id); path != nil {
+			distance = len(path)
+		}
+
+		lines := matches[i]
+		slices.Sort(lines)
+
+		results = append(results, NodeSearchResult{
+			NodeID:   entry.id,
+			Distance: distance,
+			Score:    score,
+			Matches:  slices.Compact(lines),
+		})
+	}
+
+	slices.SortFunc(results, func(a, b NodeSearchResult) int {
+		// Unreachable nodes go last
+		if (a.Distance < 0) != (b.Distance < 0) {
+			if a.Distance < 0 {
+				return 1
+			}
+			return -1
+		}
+
+		return cmp.Or(
+			cmp.Compare(a.Distance, b.Distance),
+			cmp.Compare(b.Score, a.Score),
+			cmp.Compare(a.NodeID, b.NodeID),
+		)
+	})
+
+	return results, nil
+}
+
+func getNodeSearchIndex(treeVersion data.TreeVersion) *nodeSearchIndex {
+	nodeSearchIndexesMu.Lock()
+	defer nodeSearchIndexesMu.Unlock()
+
+	if index, ok := nodeSearchIndexes[treeVersion]; ok {
+		return index
+	}
+
+	index := newNodeSearchIndex(data.TreeVersions[treeVersion].Tree())
+	nodeSearchIndexes[treeVersion] = index
+	return index
+}
+
+func newNodeSearchIndex(tree *data.Tree) *nodeSearchIndex {
+	index := &nodeSearchIndex{
+		entries: make([]nodeSearchEntry, 0, len(tree.Nodes)),
+		tokens:  make(map[string][]int),
+	}
+
+	ids := make([]int64, 0, len(tree.Nodes))
+	for _, node := range tree.Nodes {
+		if node.Skill == nil || node.Name == nil || (node.IsProxy != nil && *node.IsProxy) {
+			continue
+		}
+		ids = append(ids, *node.Skill)
+	}
+	slices.Sort(ids)
+
+	// Many nodes share the same stats, so every distinct line is only parsed once
+	parsed := make(map[string][]mod.Mod)
+
+	for _, id := range ids {
+		node := tree.Nodes[strconv.FormatInt(id, 10)]
+		entry := nodeSearchEntry{id: id}
+
+		addLine := func(text string, parse bool) {
+			line := nodeSearchLine{text: text, tokens: searchTokens(text)}
+			if parse {
+				mods, ok := parsed[text]
+				if !ok {
+					mods, _ = parseMod(text, 1)
+					parsed[text] = mods
+				}
+				line.mods = mods
+			}
+			entry.lines = append(entry.lines, line)
+		}
+
+		// The name is always the first line
+		addLine(*node.Name, false)
+		for _, stat := range node.Stats {
+			addLine(stat, true)
+		}
+		for _, reminder := range node.ReminderText {
+			addLine(reminder, false)
+		}
+		for _, effect := range node.MasteryEffects {
+			for _, stat := range effect.Stats {
+				addLine(stat, true)
+			}
+		}
+
+		i := len(index.entries)
+		index.entries = append(index.entries, entry)
+
+		for _, line := range entry.lines {
+			for _, token := range line.tokens {
+				if postings := index.tokens[token]; len(postings) == 0 || postings[len(postings)-1] != i {
+					index.tokens[token] = append(postings, i)
+				}
+			}
+		}
+	}
+
+	return index
+}
+
+// searchTokens splits the text into lowercase words
+func searchTokens(text string) []string {
+	tokens := strings.FieldsFunc(strings.ToLower(text), func(r rune) bool {
+		return !unicode.IsLetter(r) && !unicode.IsDigit(r) && r != '\''
+	})
+
+	slices.Sort(tokens)
+	return slices.Compact(tokens)
+}
+
+// matchTerm returns the score of the token for the search term, or zero if it does not match
+func matchTerm(term string, token string) int {
+	switch {
+	case term == token:
+		return searchScoreExact
+	case strings.HasPrefix(token, term):
+		return searchScorePrefix
+	}
+
+	// Typos are only allowed in longer words, as short words are similar to too many others
+	maxEdits := 0
+	switch length := len([]rune(term)); {
+	case length >= 8:
+		maxEdits = 2
+	case length >= 4:
+		maxEdits = 1
+	}
+
+	if maxEdits > 0 && utils.EditDistance(term, token) <= maxEdits {
+		return searchScoreFuzzy
+	}
+
+	return 0
+}
+
+// searchText finds the nodes containing every word of the query
+func (index *nodeSearchIndex) searchText(query string) (map[int]int, map[int][]string) {
+	scores := make(map[int]int)
+	matches := make(map[int][]string)
+
+	terms := searchTokens(query)
+	if len(terms) == 0 {
+		return scores, matches
+	}
+
+	// Scores of the matching tokens of every term
+	termTokens := make([]map[string]int, len(terms))
+	var candidates map[int]bool
+	for i, term := range terms {
+		termTokens[i] = make(map[string]int)
+		termCandidates := make(map[int]bool)
+		for token, postings := range index.tokens {
+			if score := matchTerm(term, token); score > 0 {
+				termTokens[i][token] = score
+				for _, entry := range postings {
+					if candidates == nil || candidates[entry] {
+						termCandidates[entry] = true
+					}
+				}
+			}
+		}
+		candidates = termCandidates
+	}
+
+	for i := range candidates {
+		entry := index.entries[i]
+
+		score := 0
+		var matched []string
+		for _, tokens := range termTokens {
+			best := 0
+			for lineIndex, line := range entry.lines {
+				for _, token := range line.tokens {
+					lineScore, ok := tokens[token]
+					if !ok {
+						continue
+					}
+
+					if lineIndex == 0 {
+						lineScore *= 2
+					}
+
+					best = max(best, lineScore)
+					matched = append(matched, line.text)
+				}
+			}
+			score += best
+		}
+
+		scores[i] = score
+		matches[i] = matched
+	}
+
+	return scores, matches
+}
+
+// searchRegex finds the nodes with any line matching the pattern
+func (index *nodeSearchIndex) searchRegex(pattern *regexp.Regexp) (map[int]int, map[int][]string) {
+	scores := make(map[int]int)
+	matches := make(map[int][]string)
+
+	for i, entry := range index.entries {
+		for _, line := range entry.lines {
+			if pattern.MatchString(line.text) {
+				scores[i]++
+				matches[i] = append(matches[i], line.text)
+			}
+		}
+	}
+
+	return scores, matches
+}
+
+// searchStats finds the nodes with mods matching the mods of the query
+func (index *nodeSearchIndex) searchStats(query string) (map[int]int, map[int][]string) {
+	scores := make(map[int]int)
+	matches := make(map[int][]string)
+
+	queryMods, anyType := parseStatQuery(query)
+	if len(queryMods) == 0 {
+		return scores, matches
+	}
+
+	for i, entry := range index.entries {
+		for _, line := range entry.lines {
+			if slices.ContainsFunc(line.mods, func(m mod.Mod) bool {
+				return slices.ContainsFunc(queryMods, func(q mod.Mod) bool { return matchStatMod(q, m, anyType) })
+			}) {
+				scores[i] += searchScoreStat
+				matches[i] = append(matches[i], line.text)
+			}
+		}
+	}
+
+	return scores, matches
+}
+
+// parseStatQuery parses the query as a stat line, adding a value if the query has none. If the modifier type
+// had to be guessed, mods of any type match.
+func parseStatQuery(query string) ([]mod.Mod, bool) {
+	query = strings.ToLower(query)
+	for _, prefix := range statQueryPrefixes {
+		query = strings.TrimPrefix(query, prefix)
+	}
+
+	forms := []struct {
+		line    string
+		anyType bool
+	}{
+		{line: query},
+		{line: "1% " + query},
+		{line: "+1 to " + query},
+		{line: "1% increased " + query, anyType: true},
+	}
+
+	for _, form := range forms {
+		mods, extra := parseMod(form.line, 1)
+		if len(mods) > 0 && strings.TrimSpace(extra) == "" {
+			return mods, form.anyType
+		}
+	}
+
+	return nil, false
+}
+
+// matchStatMod returns whether the node mod is a more specific form of the query mod
+func matchStatMod(query mod.Mod, node mod.Mod, anyType bool) bool {
+	if query.Name() != node.Name() || (!anyType && query.Type() != node.Type()) {
+		return false
+	}
+
+	return node.Flags()&query.Flags() == query.Flags() && node.KeywordFlags()&query.KeywordFlags() == query.KeywordFlags()
+}
@@ -0,0 +1,118 @@
+package calculator
+
+import (
+	"os"
+	"slices"
+	"strings"
+	"testing"
+
+	"github.com/MarvinJWendt/testza"
+
+	"github.com/Vilsol/go-pob/builds"
+	"github.com/Vilsol/go-pob/pob"
+)
+
+func TestSearchNodes(t *testing.T) {
+	file, err := os.ReadFile("../testdata/builds/Fireball.xml")
+	testza.AssertNoError(t, err)
+
+	build, err := builds.ParseBuild(file)
+	testza.AssertNoError(t, err)
+
+	resultIDs := func(results []NodeSearchResult) []int64 {
+		ids := make([]int64, len(results))
+		for i, result := range results {
+			ids[i] = result.NodeID
+		}
+		return ids
+	}
+
+	tests := []struct {
+		name     string
+		query    string
+		modify   func(build *pob.PathOfBuilding)
+		expected func(t *testing.T, results []NodeSearchResult)
+	}{
+		{
+			name:  "Fuzzy",
+			query: "arcanist dominoin",
+			expected: func(t *testing.T, results []NodeSearchResult) {
+				testza.AssertEqual(t, []int64{11420}, resultIDs(results))
+				testza.AssertEqual(t, []string{"Arcanist's Dominion"}, results[0].Matches)
+			},
+		},
+		{
+			name:  "Regex",
+			query: `/^\d+% increased cast speed$/`,
+			expected: func(t *testing.T, results []NodeSearchResult) {
+				testza.AssertContains(t, resultIDs(results), int64(1957))
+				for _, result := range results {
+					testza.AssertEqual(t, 1, result.Score)
+				}
+			},
+		},
+		{
+			name:  "Stat",
+			query: "nodes granting increased fire damage",
+			expected: func(t *testing.T, results []NodeSearchResult) {
+				testza.AssertGreater(t, len(results), 0)
+				// Burning damage is fire damage over time
+				for _, result := range results {
+					testza.AssertTrue(t, slices.ContainsFunc(result.Matches, func(line string) bool {
+						line = strings.ToLower(line)
+						return strings.Contains(line, "fire damage") || strings.Contains(line, "burning damage")
+					}))
+				}
+			},
+		},
+		{
+			name:  "SearchString",
+			query: "",
+			modify: func(build *pob.PathOfBuilding) {
+				build.TreeView.SearchStr = "Arcanist's Dominion"
+			},
+			expected: func(t *testing.T, results []NodeSearchResult) {
+				testza.AssertEqual(t, []int64{11420}, resultIDs(results))
+			},
+		},
+		{
+			name:  "Distance",
+			query: "strength",
+			expected: func(t *testing.T, results []NodeSearchResult) {
+				testza.AssertGreater(t, len(results), 0)
+
+				unreachable := false
+				for i, result := range results {
+					if result.Distance < 0 {
+						unreachable = true
+						continue
+					}
+
+					testza.AssertFalse(t, unreachable, "Unreachable nodes should be ranked last")
+					if i > 0 {
+						testza.AssertGreaterOrEqual(t, result.Distance, results[i-1].Distance)
+					}
+				}
+
+				// Nodes next to the scion start only need a single point
+				testza.AssertEqual(t, 1, results[0].Distance)
+			},
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			build := *build
+			if test.modify != nil {
+				test.modify(&build)
+			}
+
+			results, err := SearchNodes(&build, test.query)
+			testza.AssertNoError(t, err)
+			test.expected(t, results)
+		})
+	}
+
+	_, err = SearchNodes(build, "/(/")
+	testza.AssertNotNil(t, err)
+}
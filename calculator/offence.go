@@ -3043,11 +3043,11 @@ func CalculateOffence(env *Environment, actor *Actor, activeSkill *ActiveSkill)
 			badIdea["CriticalStrike"] = true
 
 			dotCfg := &moddb.ListCfg{
-				// TODO SkillName, SkillPart, SkillTypes, SkillDist
+				// TODO SkillName, SkillTypes, SkillDist
 				// SkillName: skillCfg.SkillName,
-				// SkillPart: skillCfg.SkillPart,
 				// SkillTypes: skillCfg.SkillTypes,
 				SlotName:     skillCfg.SlotName,
+				SkillPart:    skillCfg.SkillPart,
 				Flags:        utils.Ptr(mod.MFlagDot | mod.MFlagAilment | (cfg.Flags.Get() & mod.MFlagWeaponMask) | utils.Ternary((cfg.Flags.Get()&mod.MFlagMelee) != 0, mod.MFlagMeleeHit, 0)),
 				KeywordFlags: utils.Ptr((cfg.KeywordFlags.Get() & ^mod.KeywordFlagHit) | mod.KeywordFlagBleed | mod.KeywordFlagAilment | mod.KeywordFlagPhysicalDot),
 				SkillCond:    badIdea,
@@ -4191,26 +4191,32 @@ func CalculateOffence(env *Environment, actor *Actor, activeSkill *ActiveSkill)
 			end
 		end
 	*/
+	// Calculate combined DPS estimate, including DoTs
+	baseDPS := output["TotalDPS"]
+	if skillFlags[SkillFlagShowAverage] {
+		baseDPS = output["AverageDamage"]
+	}
+	output["CombinedDPS"] = baseDPS
+	output["CombinedAvg"] = baseDPS
 	/*
-		TODO -- Calculate combined DPS estimate, including DoTs
-		local baseDPS = output[(skillData.showAverage and "AverageDamage") or "TotalDPS"]
-		output.CombinedDPS = baseDPS
-		output.CombinedAvg = baseDPS
+		TODO Non-ailment damage over time
 		if skillFlags.dot then
 			output.CombinedDPS = output.CombinedDPS + (output.TotalDot or 0)
 			output.WithDotDPS = baseDPS + (output.TotalDot or 0)
 		end
-		if quantityMultiplier > 1 and output.TotalPoisonDPS then
-			output.TotalPoisonDPS = output.TotalPoisonDPS * quantityMultiplier
-		end
-		if skillData.showAverage then
-			output.CombinedDPS = output.CombinedDPS + (output.TotalPoisonDPS or 0)
-			output.CombinedAvg = output.CombinedAvg + (output.PoisonDamage or 0)
-			output.WithPoisonDPS = baseDPS + (output.TotalPoisonAverageDamage or 0)
-		else
-			output.CombinedDPS = output.CombinedDPS + (output.TotalPoisonDPS or 0)
-			output.WithPoisonDPS = baseDPS + (output.TotalPoisonDPS or 0)
-		end
+	*/
+	if quantityMultiplier > 1 {
+		output["TotalPoisonDPS"] *= quantityMultiplier
+	}
+	output["CombinedDPS"] += output["TotalPoisonDPS"]
+	if skillFlags[SkillFlagShowAverage] {
+		output["CombinedAvg"] += output["PoisonDamage"]
+		output["WithPoisonDPS"] = baseDPS + output["TotalPoisonAverageDamage"]
+	} else {
+		output["WithPoisonDPS"] = baseDPS + output["TotalPoisonDPS"]
+	}
+	/*
+		TODO Ignite
 		if skillFlags.ignite then
 			if skillFlags.igniteCanStack then
 				if skillData.showAverage then
@@ -4231,18 +4237,19 @@ func CalculateOffence(env *Environment, actor *Actor, activeSkill *ActiveSkill)
 		else
 			output.WithIgniteDPS = baseDPS
 		end
-		if skillFlags.bleed then
-			if skillData.showAverage then
-				output.WithBleedDPS = baseDPS + output.BleedDamage
-				output.CombinedDPS = output.CombinedDPS + output.BleedDPS
-				output.CombinedAvg = output.CombinedAvg + output.BleedDamage
-			else
-				output.WithBleedDPS = baseDPS + output.BleedDPS
-				output.CombinedDPS = output.CombinedDPS + output.BleedDPS
-			end
-		else
-			output.WithBleedDPS = baseDPS
-		end
+	*/
+	output["WithBleedDPS"] = baseDPS
+	if skillFlags[SkillFlagBleed] {
+		output["CombinedDPS"] += output["BleedDPS"]
+		if skillFlags[SkillFlagShowAverage] {
+			output["WithBleedDPS"] += output["BleedDamage"]
+			output["CombinedAvg"] += output["BleedDamage"]
+		} else {
+			output["WithBleedDPS"] += output["BleedDPS"]
+		}
+	}
+	/*
+		TODO Decay, impale, mirage and culling
 		if skillFlags.decay then
 			output.CombinedDPS = output.CombinedDPS + output.DecayDPS
 		end
@@ -6,5 +6,9 @@ var envCache = &EnvironmentCache{}
 func (c *Calculator) BuildOutput(mode OutputMode) *Environment {
 	env, _, _, _ := InitEnv(c.PoB, envCache, mode)
 	PerformCalc(env)
+
+	// Batch calculations skip this and save once the whole batch is done
+	envCache.saveNodeMods()
+
 	return env
 }
@@ -1,7 +1,15 @@
 package calculator
 
 import (
+	"errors"
+	"fmt"
+	"maps"
+	"slices"
+	"strconv"
+
 	"github.com/Vilsol/go-pob/data"
+	"github.com/Vilsol/go-pob/mod"
+	"github.com/Vilsol/go-pob/moddb"
 	"github.com/Vilsol/go-pob/pob"
 )
 
@@ -13,11 +21,29 @@ type PassiveSpec struct {
 
 	Nodes              map[string]interface{} // TODO Implement
 	AllocNodes         map[string]data.Node
-	AllocSubgraphNodes map[string]interface{} // TODO Implement
 	AllocExtendedNodes map[string]interface{} // TODO Implement
 	Jewels             map[string]interface{} // TODO Implement
-	SubGraphs          map[string]interface{} // TODO Implement
-	MasterySelections  map[string]interface{} // TODO Implement
+
+	// Cluster jewel subgraphs by the ID of the socket they are socketed in
+	SubGraphs map[string]*data.Subgraph
+
+	// Allocated subgraph nodes whose cluster jewel is not socketed yet
+	AllocSubgraphNodes map[string]bool
+
+	// Selected effect of allocated masteries by mastery node ID
+	MasterySelections map[string]int64
+
+	// Timeless jewels by the ID of the socket they are socketed in
+	TimelessJewels map[string]data.TimelessJewel
+
+	// Socket ID of the timeless jewel that changed the node, by node ID. Includes unallocated nodes in radius.
+	ConqueredNodes map[string]string
+
+	// Nodes as changed by timeless jewels, by node ID
+	TransformedNodes map[string]data.Node
+
+	// Nodes allocatable without being connected to the tree, by the ID of the socket of the jewel allowing it
+	RadiusExtensions map[string]*data.RadiusExtension
 
 	ClassName      data.ClassName
 	AscendancyName data.AscendancyName
@@ -28,73 +54,511 @@ type PassiveSpec struct {
 
 func NewPassiveSpec(build *pob.PathOfBuilding, treeVersion data.TreeVersion) *PassiveSpec {
 	passiveSpec := &PassiveSpec{
-		Build:       build,
-		TreeVersion: treeVersion,
+		Build:              build,
+		TreeVersion:        treeVersion,
+		AllocNodes:         make(map[string]data.Node, len(build.Build.PassiveNodes)+2),
+		SubGraphs:          make(map[string]*data.Subgraph),
+		AllocSubgraphNodes: make(map[string]bool),
+		MasterySelections:  make(map[string]int64),
+		TimelessJewels:     make(map[string]data.TimelessJewel),
+		ConqueredNodes:     make(map[string]string),
+		TransformedNodes:   make(map[string]data.Node),
+		RadiusExtensions:   make(map[string]*data.RadiusExtension),
+	}
+
+	className, ascendancyName := passiveSpec.buildClass()
+	passiveSpec.SelectClass(className)
+
+	tree := passiveSpec.Tree()
+	classIndex := int64(data.ClassIDs[className])
+	for _, id := range build.Build.PassiveNodes {
+		nodeID := strconv.FormatInt(id, 10)
+		if data.IsSubgraphNode(id) {
+			// Allocated once the cluster jewel gets socketed
+			passiveSpec.AllocSubgraphNodes[nodeID] = true
+			continue
+		}
+
+		node, ok := tree.Nodes[nodeID]
+		if !ok {
+			continue
+		}
+
+		// Start nodes of other classes can never be allocated
+		if node.ClassStartIndex != nil && *node.ClassStartIndex != classIndex {
+			continue
+		}
+
+		passiveSpec.AllocNodes[nodeID] = node
 	}
 
-	passiveSpec.SelectClass(data.Scion)
+	passiveSpec.SelectAscendancyClass(ascendancyName)
+
+	// Invalid selections are dropped, like nodes that don't exist in the tree
+	for _, id := range slices.Sorted(maps.Keys(build.Build.MasteryEffects)) {
+		_ = passiveSpec.SelectMasteryEffect(id, build.Build.MasteryEffects[id])
+	}
 
 	return passiveSpec
 }
 
+// newSocketedPassiveSpec returns the passive spec of the build with the jewels of the tree socketed, so that the
+// graph extensions of the spec include cluster jewel subgraphs and radius extensions
+func newSocketedPassiveSpec(build *pob.PathOfBuilding, treeVersion data.TreeVersion) *PassiveSpec {
+	spec := NewPassiveSpec(build, treeVersion)
+	_, _ = spec.socketTreeJewels()
+	return spec
+}
+
+// buildClass returns the class and ascendancy of the build, falling back to the
+// IDs of the active tree spec, and to Scion without an ascendancy if neither is set
+func (p *PassiveSpec) buildClass() (data.ClassName, data.AscendancyName) {
+	tree := p.Tree()
+
+	var spec *pob.Spec
+	if p.Build.Tree.ActiveSpec > 0 && p.Build.Tree.ActiveSpec <= len(p.Build.Tree.Specs) {
+		spec = &p.Build.Tree.Specs[p.Build.Tree.ActiveSpec-1]
+	}
+
+	className := data.ClassName(p.Build.Build.ClassName)
+	if _, ok := data.ClassIDs[className]; !ok {
+		className = data.Scion
+		if spec != nil && spec.ClassID >= 0 && spec.ClassID < len(tree.Classes) {
+			className = tree.Classes[spec.ClassID].Name
+		}
+	}
+
+	ascendancies := tree.Classes[data.ClassIDs[className]].Ascendancies
+
+	ascendancyName := data.AscendancyName(p.Build.Build.AscendClassName)
+	for _, ascendancy := range ascendancies {
+		if ascendancy.ID == ascendancyName || ascendancy.Name == ascendancyName {
+			return className, ascendancy.ID
+		}
+	}
+
+	// Ascendancy IDs of the spec start at 1, 0 means no ascendancy
+	if spec != nil && spec.AscendClassID > 0 && spec.AscendClassID <= len(ascendancies) && ascendancyName != "None" {
+		return className, ascendancies[spec.AscendClassID-1].ID
+	}
+
+	return className, ""
+}
+
 func (p *PassiveSpec) Tree() *data.Tree {
 	return data.TreeVersions[p.TreeVersion].Tree()
 }
 
+// Node returns the node of the tree as changed by timeless jewels
+func (p *PassiveSpec) Node(nodeID string) (data.Node, bool) {
+	if node, ok := p.TransformedNodes[nodeID]; ok {
+		return node, true
+	}
+
+	node, ok := p.Tree().Nodes[nodeID]
+	return node, ok
+}
+
 func (p *PassiveSpec) Class() data.Class {
 	return p.Tree().Classes[data.ClassIDs[p.ClassName]]
 }
 
 func (p *PassiveSpec) SelectClass(className data.ClassName) {
-	/*
-		TODO Implement
-		if self.curClassId then
-			-- Deallocate the current class's starting node
-			local oldStartNodeId = self.curClass.startNodeId
-			self.nodes[oldStartNodeId].alloc = false
-			self.allocNodes[oldStartNodeId] = nil
-		end
-	*/
+	if p.ClassName != "" {
+		// Deallocate the current class's starting node
+		for nodeID, node := range p.AllocNodes {
+			if node.ClassStartIndex != nil {
+				delete(p.AllocNodes, nodeID)
+			}
+		}
+	}
 
 	p.ClassName = className
 
-	/*
-		TODO Implement
-		-- Allocate the new class's starting node
-		local startNode = self.nodes[class.startNodeId]
-		startNode.alloc = true
-		self.allocNodes[startNode.id] = startNode
-	*/
+	// Allocate the new class's starting node
+	classIndex := int64(data.ClassIDs[className])
+	for nodeID, node := range p.Tree().Nodes {
+		if node.ClassStartIndex != nil && *node.ClassStartIndex == classIndex {
+			p.AllocNodes[nodeID] = node
+		}
+	}
 
-	p.SelectAscendancyClass(data.ClassAscendancies[className][0])
+	p.SelectAscendancyClass("")
 }
 
+// SelectAscendancyClass switches to the provided ascendancy of the current class, or to none if empty
 func (p *PassiveSpec) SelectAscendancyClass(ascendancyName data.AscendancyName) {
 	p.AscendancyName = ascendancyName
 
-	/*
-		TODO Implement
-		-- Deallocate any allocated ascendancy nodes that don't belong to the new ascendancy class
-		for id, node in pairs(self.allocNodes) do
-			if node.ascendancyName and node.ascendancyName ~= ascendClass.name then
-				node.alloc = false
-				self.allocNodes[id] = nil
-			end
-		end
-	*/
+	// Deallocate any allocated ascendancy nodes that don't belong to the new ascendancy class
+	for nodeID, node := range p.AllocNodes {
+		if node.AscendancyName != nil && *node.AscendancyName != string(ascendancyName) {
+			delete(p.AllocNodes, nodeID)
+		}
+	}
 
-	/*
-		TODO Implement
-		if ascendClass.startNodeId then
-			-- Allocate the new ascendancy class's start node
-			local startNode = self.nodes[ascendClass.startNodeId]
-			startNode.alloc = true
-			self.allocNodes[startNode.id] = startNode
-		end
-	*/
+	if ascendancyName != "" {
+		// Allocate the new ascendancy class's start node
+		for nodeID, node := range p.Tree().Nodes {
+			if node.IsAscendancyStart != nil && *node.IsAscendancyStart && node.AscendancyName != nil && *node.AscendancyName == string(ascendancyName) {
+				p.AllocNodes[nodeID] = node
+			}
+		}
+	}
 
 	/*
 		TODO Implement
 		self:BuildAllDependsAndPaths()
 	*/
 }
+
+// Subgraphs returns all cluster jewel subgraphs
+func (p *PassiveSpec) Subgraphs() []*data.Subgraph {
+	subgraphs := make([]*data.Subgraph, 0, len(p.SubGraphs))
+	for _, subgraph := range p.SubGraphs {
+		subgraphs = append(subgraphs, subgraph)
+	}
+	return subgraphs
+}
+
+// GraphExtensions returns the cluster jewel subgraphs and radius extensions of the spec, to path through them with
+// data.TreeVersionData.CalculateAllocationPaths and data.TreeVersionData.DisconnectedNodes
+func (p *PassiveSpec) GraphExtensions() []data.GraphExtension {
+	extensions := make([]data.GraphExtension, 0, len(p.SubGraphs)+len(p.RadiusExtensions))
+	for _, socketID := range slices.Sorted(maps.Keys(p.SubGraphs)) {
+		extensions = append(extensions, p.SubGraphs[socketID])
+	}
+	for _, socketID := range slices.Sorted(maps.Keys(p.RadiusExtensions)) {
+		extensions = append(extensions, p.RadiusExtensions[socketID])
+	}
+	return extensions
+}
+
+// GraphEdges returns the edges added by the graph extensions of the spec, as passed to the tree searches of the
+// frontend
+func (p *PassiveSpec) GraphEdges() [][2]int64 {
+	return data.NewEdgeExtension(p.GraphExtensions()...).Edges
+}
+
+// SocketIntuitiveLeapJewel sockets a jewel that allows allocating passives in its radius without being connected to
+// the tree, like Thread of Hope or Impossible Escape. The radius index is overridden by the mods of the jewel.
+func (p *PassiveSpec) SocketIntuitiveLeapJewel(socketID int64, radiusIndex int, jewelModList *moddb.ModList) error {
+	intuitiveLeapLike := false
+	var keystone string
+	for _, value := range jewelModList.List(nil, "JewelData") {
+		jewelData := value.(mod.JewelData)
+		switch jewelData.Key {
+		case "intuitiveLeapLike":
+			intuitiveLeapLike = true
+		case "impossibleEscapeKeystone":
+			keystone, _ = jewelData.Value.(string)
+		case "radiusIndex":
+			radiusIndex = jewelDataInt(jewelData.Value)
+		}
+	}
+
+	treeVersion := data.TreeVersions[p.TreeVersion]
+
+	// Impossible Escape uses the radius of the keystone instead of the socket
+	centerID := socketID
+	if keystone != "" {
+		keystoneID, ok := treeVersion.KeystoneByName(keystone)
+		if !ok {
+			return fmt.Errorf("unknown keystone: %s", keystone)
+		}
+		centerID = keystoneID
+	} else if !intuitiveLeapLike {
+		return errors.New("jewel does not allow allocating passives in radius")
+	}
+
+	extension, err := treeVersion.NewRadiusExtension(socketID, centerID, radiusIndex)
+	if err != nil {
+		return err
+	}
+
+	p.RadiusExtensions[strconv.FormatInt(socketID, 10)] = extension
+	return nil
+}
+
+// RemoveIntuitiveLeapJewel removes the radius extension of the jewel in the socket. Nodes allocated through it stay
+// allocated, but are disconnected.
+func (p *PassiveSpec) RemoveIntuitiveLeapJewel(socketID int64) {
+	delete(p.RadiusExtensions, strconv.FormatInt(socketID, 10))
+}
+
+// SocketClusterJewel generates the subgraph of the cluster jewel with the provided mods in the socket, replacing any
+// cluster jewel already socketed in it. Previously allocated nodes of the subgraph are allocated again.
+func (p *PassiveSpec) SocketClusterJewel(socketID int64, baseName string, jewelModList *moddb.ModList) error {
+	p.RemoveClusterJewel(socketID)
+
+	jewel := data.ClusterJewelSpec{BaseName: baseName}
+
+	socketCountOverride := 0
+	for _, value := range jewelModList.List(nil, "JewelData") {
+		jewelData := value.(mod.JewelData)
+		switch jewelData.Key {
+		case "clusterJewelNodeCount":
+			jewel.NodeCount = jewelDataInt(jewelData.Value)
+		case "clusterJewelSocketCount":
+			jewel.SocketCount = jewelDataInt(jewelData.Value)
+		case "clusterJewelSocketCountOverride":
+			socketCountOverride = jewelDataInt(jewelData.Value)
+		case "clusterJewelNothingnessCount":
+			jewel.NothingnessCount = jewelDataInt(jewelData.Value)
+		case "clusterJewelSmallsAreNothingness":
+			jewel.SmallsAreNothingness = true
+		case "clusterJewelIncEffect":
+			jewel.IncEffect += float64(jewelDataInt(jewelData.Value))
+		case "clusterJewelSkill":
+			jewel.Skill, _ = jewelData.Value.(string)
+		case "clusterJewelKeystone":
+			jewel.Keystone, _ = jewelData.Value.(string)
+		}
+	}
+
+	if socketCountOverride > 0 {
+		jewel.SocketCount = socketCountOverride
+		if jewel.NodeCount == 0 {
+			jewel.NodeCount = socketCountOverride + jewel.NothingnessCount
+		}
+	}
+
+	for _, value := range jewelModList.List(nil, "ClusterJewelNotable") {
+		jewel.Notables = append(jewel.Notables, value.(string))
+	}
+
+	for _, value := range jewelModList.List(nil, "AddToClusterJewelNode") {
+		jewel.AddedMods = append(jewel.AddedMods, value.(string))
+	}
+
+	subgraph, err := data.TreeVersions[p.TreeVersion].BuildSubgraph(socketID, jewel)
+	if err != nil {
+		return fmt.Errorf("failed to socket %s: %w", baseName, err)
+	}
+
+	p.SubGraphs[strconv.FormatInt(socketID, 10)] = subgraph
+
+	for id, node := range subgraph.Nodes {
+		nodeID := strconv.FormatInt(id, 10)
+		if p.AllocSubgraphNodes[nodeID] {
+			p.AllocNodes[nodeID] = node
+			delete(p.AllocSubgraphNodes, nodeID)
+		}
+	}
+
+	return nil
+}
+
+// RemoveClusterJewel removes the subgraph of the cluster jewel in the socket and of all cluster jewels socketed into
+// it. Allocated nodes of the removed subgraphs are kept in AllocSubgraphNodes to be allocated again once re-socketed.
+func (p *PassiveSpec) RemoveClusterJewel(socketID int64) {
+	socketNodeID := strconv.FormatInt(socketID, 10)
+	subgraph, ok := p.SubGraphs[socketNodeID]
+	if !ok {
+		return
+	}
+
+	delete(p.SubGraphs, socketNodeID)
+
+	for id := range subgraph.Nodes {
+		nodeID := strconv.FormatInt(id, 10)
+		if _, ok := p.AllocNodes[nodeID]; ok {
+			delete(p.AllocNodes, nodeID)
+			p.AllocSubgraphNodes[nodeID] = true
+		}
+	}
+
+	// Nested sockets are the only tree nodes linked to the subgraph besides its own socket
+	for _, edge := range subgraph.Edges {
+		for _, id := range edge {
+			if id != socketID && !data.IsSubgraphNode(id) {
+				p.RemoveClusterJewel(id)
+			}
+		}
+	}
+}
+
+// SocketTimelessJewel sockets the timeless jewel with the provided mods, replacing any timeless jewel already
+// socketed in it. Allocated nodes in radius of the socket are replaced or augmented by the conqueror's passives.
+func (p *PassiveSpec) SocketTimelessJewel(socketID int64, jewelModList *moddb.ModList) error {
+	var legionJewel *mod.LegionJewel
+	for _, value := range jewelModList.List(nil, "JewelData") {
+		if jewelData := value.(mod.JewelData); jewelData.Key == "conqueredBy" {
+			if conquered, ok := jewelData.Value.(mod.LegionJewel); ok {
+				legionJewel = &conquered
+			}
+		}
+	}
+
+	if legionJewel == nil {
+		return errors.New("jewel is not a timeless jewel")
+	}
+
+	jewelType, ok := data.TimelessJewelTypeByConqueror(legionJewel.Conqueror.Type)
+	if !ok {
+		return fmt.Errorf("unknown conqueror type: %s", legionJewel.Conqueror.Type)
+	}
+
+	conquerorIndex, conquerorVersion, err := data.ParseConquerorID(legionJewel.Conqueror.ID)
+	if err != nil {
+		return err
+	}
+
+	treeVersion := data.TreeVersions[p.TreeVersion]
+	socket, ok := p.Tree().Nodes[strconv.FormatInt(socketID, 10)]
+	if !ok || socket.IsJewelSocket == nil || !*socket.IsJewelSocket || socket.ExpansionJewel != nil {
+		return fmt.Errorf("node %d is not a jewel socket", socketID)
+	}
+
+	timelessJewels, err := treeVersion.TimelessJewels()
+	if err != nil {
+		return fmt.Errorf("failed to socket %s: %w", data.TimelessJewelTypes[jewelType].Name, err)
+	}
+
+	p.RemoveTimelessJewel(socketID)
+
+	jewel := data.TimelessJewel{
+		Type:             jewelType,
+		Seed:             legionJewel.ID,
+		ConquerorIndex:   conquerorIndex,
+		ConquerorVersion: conquerorVersion,
+	}
+
+	socketNodeID := strconv.FormatInt(socketID, 10)
+	p.TimelessJewels[socketNodeID] = jewel
+
+	for _, id := range treeVersion.NodesInRadius(socketID, data.JewelRadiusLarge) {
+		nodeID := strconv.FormatInt(id, 10)
+
+		// Passives can only be conquered by a single timeless jewel
+		if _, ok := p.ConqueredNodes[nodeID]; ok {
+			continue
+		}
+
+		node, ok := timelessJewels.TransformNode(id, jewel)
+		if !ok {
+			continue
+		}
+
+		p.ConqueredNodes[nodeID] = socketNodeID
+		p.TransformedNodes[nodeID] = node
+		if _, ok := p.AllocNodes[nodeID]; ok {
+			p.AllocNodes[nodeID] = node
+		}
+	}
+
+	return nil
+}
+
+// RemoveTimelessJewel removes the timeless jewel in the socket and restores the nodes it conquered
+func (p *PassiveSpec) RemoveTimelessJewel(socketID int64) {
+	socketNodeID := strconv.FormatInt(socketID, 10)
+	if _, ok := p.TimelessJewels[socketNodeID]; !ok {
+		return
+	}
+
+	delete(p.TimelessJewels, socketNodeID)
+
+	for nodeID, conqueredBy := range p.ConqueredNodes {
+		if conqueredBy != socketNodeID {
+			continue
+		}
+
+		delete(p.ConqueredNodes, nodeID)
+		delete(p.TransformedNodes, nodeID)
+		if _, ok := p.AllocNodes[nodeID]; ok {
+			p.AllocNodes[nodeID] = p.Tree().Nodes[nodeID]
+		}
+	}
+}
+
+func jewelDataInt(value any) int {
+	switch value := value.(type) {
+	case int:
+		return value
+	case float64:
+		return int(value)
+	}
+	return 0
+}
+
+// SelectMasteryEffect selects the effect of the allocated mastery, replacing any previous selection.
+// Every effect can only be selected on a single mastery.
+func (p *PassiveSpec) SelectMasteryEffect(nodeID int64, effect int64) error {
+	id := strconv.FormatInt(nodeID, 10)
+	node, ok := p.Tree().Nodes[id]
+	if !ok || node.IsMastery == nil || !*node.IsMastery {
+		return fmt.Errorf("node %d is not a mastery", nodeID)
+	}
+
+	if _, ok := p.AllocNodes[id]; !ok {
+		return fmt.Errorf("mastery %d is not allocated", nodeID)
+	}
+
+	index := slices.IndexFunc(node.MasteryEffects, func(masteryEffect data.MasteryEffect) bool {
+		return masteryEffect.Effect == effect
+	})
+	if index == -1 {
+		return fmt.Errorf("mastery %d does not have effect %d", nodeID, effect)
+	}
+
+	for otherID, otherEffect := range p.MasterySelections {
+		if otherID != id && otherEffect == effect {
+			return fmt.Errorf("effect %d is already selected on mastery %s", effect, otherID)
+		}
+	}
+
+	// Only the stats of the selected effect apply
+	node.Stats = node.MasteryEffects[index].Stats
+	node.ReminderText = node.MasteryEffects[index].ReminderText
+
+	p.MasterySelections[id] = effect
+	p.AllocNodes[id] = node
+	p.countAllocatedMasteries()
+
+	return nil
+}
+
+// DeselectMasteryEffect removes the selected effect of the mastery, if any
+func (p *PassiveSpec) DeselectMasteryEffect(nodeID int64) {
+	id := strconv.FormatInt(nodeID, 10)
+	if _, ok := p.MasterySelections[id]; !ok {
+		return
+	}
+
+	delete(p.MasterySelections, id)
+	if _, ok := p.AllocNodes[id]; ok {
+		p.AllocNodes[id] = p.Tree().Nodes[id]
+	}
+
+	p.countAllocatedMasteries()
+}
+
+// AvailableMasteryEffects returns the effects of the mastery that aren't selected on any other mastery
+func (p *PassiveSpec) AvailableMasteryEffects(nodeID int64) []data.MasteryEffect {
+	id := strconv.FormatInt(nodeID, 10)
+	available := make([]data.MasteryEffect, 0)
+	for _, masteryEffect := range p.Tree().Nodes[id].MasteryEffects {
+		selected := false
+		for otherID, otherEffect := range p.MasterySelections {
+			if otherID != id && otherEffect == masteryEffect.Effect {
+				selected = true
+				break
+			}
+		}
+
+		if !selected {
+			available = append(available, masteryEffect)
+		}
+	}
+	return available
+}
+
+func (p *PassiveSpec) countAllocatedMasteries() {
+	p.AllocatedMasteryCount = 0
+	for id := range p.MasterySelections {
+		if _, ok := p.AllocNodes[id]; ok {
+			p.AllocatedMasteryCount++
+		}
+	}
+}
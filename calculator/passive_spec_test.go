@@ -0,0 +1,281 @@
+package calculator
+
+import (
+	"os"
+	"testing"
+
+	"github.com/MarvinJWendt/testza"
+
+	"github.com/Vilsol/go-pob/builds"
+	"github.com/Vilsol/go-pob/data"
+	"github.com/Vilsol/go-pob/items"
+	"github.com/Vilsol/go-pob/mod"
+	"github.com/Vilsol/go-pob/moddb"
+)
+
+func TestNewPassiveSpec(t *testing.T) {
+	file, err := os.ReadFile("../testdata/builds/Fireball.xml")
+	testza.AssertNoError(t, err)
+
+	tests := []struct {
+		name            string
+		className       string
+		ascendClassName string
+		classID         int
+		ascendClassID   int
+		class           data.ClassName
+		ascendancy      data.AscendancyName
+		allocated       []string
+		dropped         []string
+	}{
+		{
+			name:            "Scion",
+			className:       "Scion",
+			ascendClassName: "None",
+			class:           data.Scion,
+			allocated:       []string{"58833", "14930"},
+			dropped:         []string{"35754", "24755", "25309"},
+		},
+		{
+			name:            "Ascendant",
+			className:       "Scion",
+			ascendClassName: "Ascendant",
+			class:           data.Scion,
+			ascendancy:      data.Ascendant,
+			allocated:       []string{"58833", "14930", "35754", "24755"},
+			dropped:         []string{"25309"},
+		},
+		{
+			name:            "Occultist",
+			className:       "Witch",
+			ascendClassName: "Occultist",
+			class:           data.Witch,
+			ascendancy:      data.Occultist,
+			allocated:       []string{"54447", "14930", "18378", "25309"},
+			dropped:         []string{"58833", "35754", "24755"},
+		},
+		{
+			name:          "SpecIDs",
+			classID:       3,
+			ascendClassID: 1,
+			class:         data.Witch,
+			ascendancy:    data.Occultist,
+			allocated:     []string{"54447", "18378", "25309"},
+			dropped:       []string{"58833", "24755"},
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			build, err := builds.ParseBuild(file)
+			testza.AssertNoError(t, err)
+
+			build.Build.ClassName = test.className
+			build.Build.AscendClassName = test.ascendClassName
+			build.Tree.Specs[build.Tree.ActiveSpec-1].ClassID = test.classID
+			build.Tree.Specs[build.Tree.ActiveSpec-1].AscendClassID = test.ascendClassID
+
+			// Small strength node, Ascendant and Occultist notables
+			build.Build.PassiveNodes = append(build.Build.PassiveNodes, 14930, 24755, 25309)
+
+			spec := NewPassiveSpec(build, data.LatestTreeVersion)
+			testza.AssertEqual(t, test.class, spec.ClassName)
+			testza.AssertEqual(t, test.ascendancy, spec.AscendancyName)
+			testza.AssertEqual(t, test.class, spec.Class().Name)
+
+			for _, nodeID := range test.allocated {
+				testza.AssertTrue(t, spec.AllocNodes[nodeID].Skill != nil, nodeID)
+			}
+
+			for _, nodeID := range test.dropped {
+				_, ok := spec.AllocNodes[nodeID]
+				testza.AssertFalse(t, ok, nodeID)
+			}
+		})
+	}
+}
+
+func TestInitEnvClassStats(t *testing.T) {
+	file, err := os.ReadFile("../testdata/builds/Fireball.xml")
+	testza.AssertNoError(t, err)
+
+	build, err := builds.ParseBuild(file)
+	testza.AssertNoError(t, err)
+
+	build.Build.ClassName = "Witch"
+
+	env, _, _, _ := InitEnv(build, envCache, OutputModeMain)
+	testza.AssertEqual(t, 14.0, env.ModDB.Sum(mod.TypeBase, nil, "Str"))
+	testza.AssertEqual(t, 32.0, env.ModDB.Sum(mod.TypeBase, nil, "Int"))
+}
+
+func TestSocketClusterJewel(t *testing.T) {
+	file, err := os.ReadFile("../testdata/builds/Fireball.xml")
+	testza.AssertNoError(t, err)
+
+	build, err := builds.ParseBuild(file)
+	testza.AssertNoError(t, err)
+
+	// Large socket and the first two small passives of the cluster socketed in it
+	build.Build.PassiveNodes = append(build.Build.PassiveNodes, 32763, 65824, 65826)
+
+	spec := NewPassiveSpec(build, data.LatestTreeVersion)
+	testza.AssertTrue(t, spec.AllocSubgraphNodes["65824"])
+	testza.AssertTrue(t, spec.AllocSubgraphNodes["65826"])
+
+	clusterJewels, err := data.TreeVersions[data.LatestTreeVersion].ClusterJewels()
+	testza.AssertNoError(t, err)
+
+	jewel := &EquippedItem{Item: &items.Item{
+		Enchants: []items.ItemMod{
+			{Line: "Added Small Passive Skills grant: 12% increased Fire Damage"},
+		},
+		Explicits: []items.ItemMod{
+			{Line: "Adds 8 Passive Skills"},
+			{Line: "2 Added Passive Skills are Jewel Sockets"},
+			{Line: "Added Small Passive Skills have 25% increased Effect"},
+			{Line: "1 Added Passive Skill is Cooked Alive"},
+		},
+	}}
+
+	mods, errs := jewel.parseMods(clusterJewels)
+	testza.AssertLen(t, errs, 0)
+
+	jewelModList := moddb.NewModList()
+	for _, m := range mods {
+		jewelModList.AddMod(m)
+	}
+
+	testza.AssertNoError(t, spec.SocketClusterJewel(32763, "Large Cluster Jewel", jewelModList))
+	testza.AssertLen(t, spec.AllocSubgraphNodes, 0)
+	testza.AssertEqual(t, "Fire Damage", *spec.AllocNodes["65824"].Name)
+
+	env := newNodeModEnv(spec, map[string]data.Node{
+		"65824": spec.AllocNodes["65824"],
+		"65826": spec.AllocNodes["65826"],
+	})
+
+	// 12% increased Fire Damage with 25% increased effect on both small passives
+	modList := buildModListForNodeList(env, env.AllocatedNodes, true)
+	testza.AssertEqual(t, 30.0, sumModValues(modList, "FireDamage"))
+
+	spec.RemoveClusterJewel(32763)
+	testza.AssertLen(t, spec.SubGraphs, 0)
+	testza.AssertTrue(t, spec.AllocSubgraphNodes["65824"], "Allocation should be restored once socketed again")
+
+	_, ok := spec.AllocNodes["65824"]
+	testza.AssertFalse(t, ok)
+}
+
+func TestMasteryEffects(t *testing.T) {
+	file, err := os.ReadFile("../testdata/builds/Fireball.xml")
+	testza.AssertNoError(t, err)
+
+	build, err := builds.ParseBuild(file)
+	testza.AssertNoError(t, err)
+
+	// Two allocated life masteries, and an unallocated elemental mastery
+	build.Build.PassiveNodes = append(build.Build.PassiveNodes, 12382, 38235)
+	build.SelectMasteryEffect(12382, 47642)
+	build.SelectMasteryEffect(5348, 30502)
+
+	spec := NewPassiveSpec(build, data.LatestTreeVersion)
+	testza.AssertEqual(t, map[string]int64{"12382": 47642}, spec.MasterySelections, "Selections of unallocated masteries should be dropped")
+	testza.AssertEqual(t, []string{"+50 to maximum Life"}, spec.AllocNodes["12382"].Stats)
+	testza.AssertEqual(t, 1, spec.AllocatedMasteryCount)
+
+	testza.AssertNotNil(t, spec.SelectMasteryEffect(38235, 47642), "Effects can only be selected once")
+	testza.AssertNotNil(t, spec.SelectMasteryEffect(38235, 30502), "Effects of other masteries can't be selected")
+	testza.AssertNotNil(t, spec.SelectMasteryEffect(5348, 30502), "Unallocated masteries can't be selected")
+	testza.AssertLen(t, spec.AvailableMasteryEffects(38235), 5)
+
+	testza.AssertNoError(t, spec.SelectMasteryEffect(38235, 34242))
+	testza.AssertEqual(t, 2, spec.AllocatedMasteryCount)
+
+	env := newNodeModEnv(spec, spec.AllocNodes)
+
+	modList := buildModListForNodeList(env, spec.AllocNodes, true)
+	testza.AssertEqual(t, 50.0, sumModValues(modList, "Life"))
+	testza.AssertEqual(t, 2.0, sumModValues(modList, "LifeRegenPercent"))
+
+	// Switching the effect must not reuse the mods cached for the previous one
+	testza.AssertNoError(t, spec.SelectMasteryEffect(12382, 31822))
+	modList = buildModListForNodeList(env, spec.AllocNodes, true)
+	testza.AssertEqual(t, 0.0, sumModValues(modList, "Life"))
+
+	spec.DeselectMasteryEffect(38235)
+	testza.AssertLen(t, spec.AllocNodes["38235"].Stats, 0)
+	testza.AssertEqual(t, 1, spec.AllocatedMasteryCount)
+}
+
+func TestSocketTimelessJewel(t *testing.T) {
+	file, err := os.ReadFile("../testdata/builds/Fireball.xml")
+	testza.AssertNoError(t, err)
+
+	build, err := builds.ParseBuild(file)
+	testza.AssertNoError(t, err)
+
+	// Strength and Barbarism in radius of the socket
+	build.Build.PassiveNodes = append(build.Build.PassiveNodes, 26725, 62363, 53118)
+
+	spec := NewPassiveSpec(build, data.LatestTreeVersion)
+
+	jewelModList := parseTestModList(t, "Carved to glorify 5000 new faithful converted by High Templar Venarius")
+
+	testza.AssertNotNil(t, spec.SocketTimelessJewel(26725, moddb.NewModList()), "Regular jewels can't be socketed")
+	testza.AssertNoError(t, spec.SocketTimelessJewel(26725, jewelModList))
+	testza.AssertEqual(t, "Devotion", *spec.AllocNodes["62363"].Name)
+	testza.AssertEqual(t, "26725", spec.ConqueredNodes["62363"])
+
+	// Unallocated nodes in radius are conquered as well
+	unallocated, _ := spec.Node("22285")
+	testza.AssertEqual(t, "Devotion", *unallocated.Name)
+	testza.AssertEqual(t, "26725", spec.ConqueredNodes["22285"])
+	_, ok := spec.AllocNodes["22285"]
+	testza.AssertFalse(t, ok)
+
+	env := newNodeModEnv(spec, spec.AllocNodes)
+
+	nodes := map[string]data.Node{"62363": spec.AllocNodes["62363"]}
+	modList := buildModListForNodeList(env, nodes, true)
+	testza.AssertEqual(t, 10.0, sumModValues(modList, "Devotion"))
+	testza.AssertEqual(t, 0.0, sumModValues(modList, "Str"))
+
+	// Removing the jewel must not reuse the mods cached for the conquered node
+	spec.RemoveTimelessJewel(26725)
+	testza.AssertLen(t, spec.ConqueredNodes, 0)
+	unallocated, _ = spec.Node("22285")
+	testza.AssertEqual(t, "Strength", *unallocated.Name)
+
+	nodes["62363"] = spec.AllocNodes["62363"]
+	modList = buildModListForNodeList(env, nodes, true)
+	testza.AssertEqual(t, 0.0, sumModValues(modList, "Devotion"))
+	testza.AssertEqual(t, 10.0, sumModValues(modList, "Str"))
+}
+
+func TestSocketIntuitiveLeapJewel(t *testing.T) {
+	file, err := os.ReadFile("../testdata/builds/Fireball.xml")
+	testza.AssertNoError(t, err)
+
+	build, err := builds.ParseBuild(file)
+	testza.AssertNoError(t, err)
+
+	spec := NewPassiveSpec(build, data.LatestTreeVersion)
+	treeVersion := data.TreeVersions[data.LatestTreeVersion]
+
+	threadOfHope := parseTestModList(t, "Only affects Passives in Large Ring", "Passives in Radius can be Allocated without being connected to your tree")
+	testza.AssertNoError(t, spec.SocketIntuitiveLeapJewel(26725, data.JewelRadiusSmall, threadOfHope))
+	testza.AssertEqual(t, treeVersion.NodesInRadius(26725, data.JewelRadiusLargeRing), spec.RadiusExtensions["26725"].Nodes)
+
+	impossibleEscape := parseTestModList(t, "Passives in Radius of Ghost Reaver can be Allocated without being connected to your tree")
+	testza.AssertNoError(t, spec.SocketIntuitiveLeapJewel(61834, data.JewelRadiusSmall, impossibleEscape))
+	testza.AssertEqual(t, int64(24426), spec.RadiusExtensions["61834"].Nodes[0])
+
+	testza.AssertLen(t, spec.GraphExtensions(), 2)
+
+	testza.AssertNotNil(t, spec.SocketIntuitiveLeapJewel(26725, data.JewelRadiusSmall, moddb.NewModList()), "Regular jewels don't allow allocating passives in radius")
+
+	spec.RemoveIntuitiveLeapJewel(26725)
+	spec.RemoveIntuitiveLeapJewel(61834)
+	testza.AssertLen(t, spec.GraphExtensions(), 0)
+}
@@ -2204,77 +2204,51 @@ func doActorAttribsPoolsConditions(env *Environment, actor *Actor) {
 		local breakdown = actor.breakdown
 		local condList = modDB.conditions
 	*/
+	condList := actor.ModDB.Conditions
+
+	// Set conditions
+	// TODO Necromantic Aegis
+	weapon2 := actor.ItemList["Weapon 2"]
+	if weapon2 != nil && weapon2.Type == string(data.Shield) {
+		condList["UsingShield"] = true
+	}
+	if weapon2 == nil {
+		condList["OffHandIsEmpty"] = true
+	}
+
+	weapon1Type, _ := actor.WeaponData1["type"].(string)
+	weapon2Type, _ := actor.WeaponData2["type"].(string)
+	if weapon1Type == string(data.None) {
+		condList["Unarmed"] = true
+		if weapon2 == nil && actor.ItemList["Gloves"] == nil {
+			condList["Unencumbered"] = true
+		}
+	} else {
+		setWeaponConditions(condList, actor.WeaponData1)
+	}
+	setWeaponConditions(condList, actor.WeaponData2)
+
+	if weapon1Type != "" && weapon2Type != "" {
+		condList["DualWielding"] = true
+
+		weapon1All1H := utils.HasTrue(actor.WeaponData1, "countsAsAll1H")
+		weapon2All1H := utils.HasTrue(actor.WeaponData2, "countsAsAll1H")
+		if (weapon1Type == string(data.Claw) || weapon1All1H) && (weapon2Type == string(data.Claw) || weapon2All1H) {
+			condList["DualWieldingClaws"] = true
+		}
+		if (weapon1Type == string(data.Dagger) || weapon1All1H) && (weapon2Type == string(data.Dagger) || weapon2All1H) {
+			condList["DualWieldingDaggers"] = true
+		}
+
+		info1 := data.WeaponTypes[data.ItemClassName(weapon1Type)]
+		info2 := data.WeaponTypes[data.ItemClassName(weapon2Type)]
+		if info1 != nil && info2 != nil && weaponLabel(info1, weapon1Type) != weaponLabel(info2, weapon2Type) && info1.OneHand && info2.OneHand {
+			condList["WieldingDifferentWeaponTypes"] = true
+		}
+	}
+
 	/*
 		TODO -- Set conditions
-		if (actor.itemList["Weapon 2"] and actor.itemList["Weapon 2"].type == "Shield") or (actor == env.player and env.aegisModList) then
-			condList["UsingShield"] = true
-		end
-		if not actor.itemList["Weapon 2"] then
-			condList["OffHandIsEmpty"] = true
-		end
-		if actor.weaponData1.type == "None" then
-			condList["Unarmed"] = true
-			if not actor.itemList["Weapon 2"] and not actor.itemList["Gloves"] then
-				condList["Unencumbered"] = true
-			end
-		else
-			local info = env.data.weaponTypeInfo[actor.weaponData1.type]
-			condList["Using"..info.flag] = true
-			if actor.weaponData1.countsAsAll1H then
-				condList["UsingAxe"] = true
-				condList["UsingSword"] = true
-				condList["UsingDagger"] = true
-				condList["UsingMace"] = true
-				condList["UsingClaw"] = true
-				-- GGG stated that a single Varunastra satisfied requirement for wielding two different weapons
-				condList["WieldingDifferentWeaponTypes"] = true
-			end
-			if info.melee then
-				condList["UsingMeleeWeapon"] = true
-			end
-			if info.oneHand then
-				condList["UsingOneHandedWeapon"] = true
-			else
-				condList["UsingTwoHandedWeapon"] = true
-			end
-		end
-		if actor.weaponData2.type then
-			local info = env.data.weaponTypeInfo[actor.weaponData2.type]
-			condList["Using"..info.flag] = true
-			if actor.weaponData2.countsAsAll1H then
-				condList["UsingAxe"] = true
-				condList["UsingSword"] = true
-				condList["UsingDagger"] = true
-				condList["UsingMace"] = true
-				condList["UsingClaw"] = true
-				-- GGG stated that a single Varunastra satisfied requirement for wielding two different weapons
-				condList["WieldingDifferentWeaponTypes"] = true
-			end
-			if info.melee then
-				condList["UsingMeleeWeapon"] = true
-			end
-			if info.oneHand then
-				condList["UsingOneHandedWeapon"] = true
-			else
-				condList["UsingTwoHandedWeapon"] = true
-			end
-		end
-		if actor.weaponData1.type and actor.weaponData2.type then
-			condList["DualWielding"] = true
-			if (actor.weaponData1.type == "Claw" or actor.weaponData1.countsAsAll1H) and (actor.weaponData2.type == "Claw" or actor.weaponData2.countsAsAll1H) then
-				condList["DualWieldingClaws"] = true
-			end
-			if (actor.weaponData1.type == "Dagger" or actor.weaponData1.countsAsAll1H) and (actor.weaponData2.type == "Dagger" or actor.weaponData2.countsAsAll1H) then
-				condList["DualWieldingDaggers"] = true
-			end
-			if (env.data.weaponTypeInfo[actor.weaponData1.type].label or actor.weaponData1.type) ~= (env.data.weaponTypeInfo[actor.weaponData2.type].label or actor.weaponData2.type) then
-				local info1 = env.data.weaponTypeInfo[actor.weaponData1.type]
-				local info2 = env.data.weaponTypeInfo[actor.weaponData2.type]
-				if info1.oneHand and info2.oneHand then
-					condList["WieldingDifferentWeaponTypes"] = true
-				end
-			end
-		end
 		if env.mode_combat then
 			if not modDB:Flag(nil, "NeverCrit") then
 				condList["CritInPast8Sec"] = true
@@ -2530,6 +2504,46 @@ func doActorAttribsPoolsConditions(env *Environment, actor *Actor) {
 	*/
 }
 
+// setWeaponConditions sets the conditions for wielding the weapon described by the weapon data
+func setWeaponConditions(condList map[string]bool, weaponData map[string]interface{}) {
+	weaponType, ok := weaponData["type"].(string)
+	if !ok {
+		return
+	}
+
+	info := data.WeaponTypes[data.ItemClassName(weaponType)]
+	if info == nil {
+		return
+	}
+
+	condList["Using"+info.Flag] = true
+	if utils.HasTrue(weaponData, "countsAsAll1H") {
+		condList["UsingAxe"] = true
+		condList["UsingSword"] = true
+		condList["UsingDagger"] = true
+		condList["UsingMace"] = true
+		condList["UsingClaw"] = true
+		// GGG stated that a single Varunastra satisfied requirement for wielding two different weapons
+		condList["WieldingDifferentWeaponTypes"] = true
+	}
+	if info.Melee {
+		condList["UsingMeleeWeapon"] = true
+	}
+	if info.OneHand {
+		condList["UsingOneHandedWeapon"] = true
+	} else {
+		condList["UsingTwoHandedWeapon"] = true
+	}
+}
+
+// weaponLabel returns the label weapon types are grouped under
+func weaponLabel(info *data.WeaponTypeInfo, weaponType string) string {
+	if info.Label != "" {
+		return info.Label
+	}
+	return weaponType
+}
+
 func mergeKeystones(env *Environment) {
 	/*
 		TODO mergeKeystones
@@ -0,0 +1,82 @@
+package calculator
+
+import (
+	"github.com/Vilsol/go-pob-data/poe"
+
+	raw2 "github.com/Vilsol/go-pob/data/raw"
+	"github.com/Vilsol/go-pob/pob"
+)
+
+type ActiveSkillParts struct {
+	// Socket group and gem of the skill, as 1-based indices in the active skill set
+	SocketGroup int
+	Gem         int
+
+	SkillID string
+	Name    string
+	Parts   []string
+
+	// Part used for the main calculations, 1-based
+	Selected int
+
+	// Part used for the calcs tab, 1-based
+	SelectedCalcs int
+}
+
+// SkillPartNames returns the names of the parts of the granted effect, or nil if it only has a single part
+func SkillPartNames(grantedEffectID string) []string {
+	parts := raw2.SkillParts[grantedEffectID]
+	if len(parts) == 0 {
+		return nil
+	}
+
+	names := make([]string, len(parts))
+	for i, part := range parts {
+		names[i] = part.Name
+	}
+	return names
+}
+
+// crystalline:promise
+func (c *Calculator) SkillParts() []ActiveSkillParts {
+	return ListSkillParts(c.PoB)
+}
+
+// ListSkillParts returns the active skills of the active skill set that have multiple parts, along with the parts
+// selected in the build
+func ListSkillParts(build *pob.PathOfBuilding) []ActiveSkillParts {
+	result := make([]ActiveSkillParts, 0)
+
+	skillSet := max(build.Skills.ActiveSkillSet-1, 0)
+	if skillSet >= len(build.Skills.SkillSets) {
+		return result
+	}
+
+	for groupIndex, group := range build.Skills.SkillSets[skillSet].Skills {
+		for gemIndex, gem := range group.Gems {
+			baseItem := poe.BaseItemTypeByIDMap[gem.GemID]
+			if baseItem == nil || baseItem.SkillGem() == nil {
+				continue
+			}
+
+			for _, grantedEffect := range baseItem.SkillGem().GetGrantedEffects() {
+				parts := SkillPartNames(grantedEffect.ID)
+				if grantedEffect.IsSupport || parts == nil {
+					continue
+				}
+
+				result = append(result, ActiveSkillParts{
+					SocketGroup:   groupIndex + 1,
+					Gem:           gemIndex + 1,
+					SkillID:       grantedEffect.ID,
+					Name:          grantedEffect.GetActiveSkill().DisplayedName,
+					Parts:         parts,
+					Selected:      min(len(parts), max(gem.SkillPart, 1)),
+					SelectedCalcs: min(len(parts), max(gem.SkillPartCalcs, 1)),
+				})
+			}
+		}
+	}
+
+	return result
+}
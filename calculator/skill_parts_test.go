@@ -0,0 +1,68 @@
+package calculator
+
+import (
+	"os"
+	"testing"
+
+	"github.com/MarvinJWendt/testza"
+
+	"github.com/Vilsol/go-pob/builds"
+	"github.com/Vilsol/go-pob/pob"
+)
+
+func iceSpearBuild(t *testing.T, skillPart int) *pob.PathOfBuilding {
+	file, err := os.ReadFile("../testdata/builds/Fireball.xml")
+	testza.AssertNoError(t, err)
+
+	build, err := builds.ParseBuild(file)
+	testza.AssertNoError(t, err)
+
+	return withSocketGroupGems(build, 0, 2, []pob.Gem{{
+		GemID:         "Metadata/Items/Gems/SkillGemIceSpear",
+		SkillID:       "IceSpear",
+		NameSpec:      "Ice Spear",
+		Level:         20,
+		QualityID:     GemQualityDefault,
+		Enabled:       true,
+		EnableGlobal1: true,
+		EnableGlobal2: true,
+		Count:         1,
+		SkillPart:     skillPart,
+	}})
+}
+
+func TestListSkillParts(t *testing.T) {
+	parts := ListSkillParts(iceSpearBuild(t, 5))
+	testza.AssertEqual(t, []ActiveSkillParts{{
+		SocketGroup:   2,
+		Gem:           1,
+		SkillID:       "IceSpear",
+		Name:          "Ice Spear",
+		Parts:         []string{"First Form", "Second Form"},
+		Selected:      2,
+		SelectedCalcs: 1,
+	}}, parts)
+
+	testza.AssertNil(t, SkillPartNames("Fireball"))
+}
+
+func TestSkillPartCalculation(t *testing.T) {
+	env, _, _, _ := InitEnv(iceSpearBuild(t, 0), envCache, OutputModeMain)
+	testza.AssertEqual(t, 1, env.Player.MainSkill.SkillPart)
+	testza.AssertEqual(t, "First Form", env.Player.MainSkill.SkillPartName)
+	testza.AssertTrue(t, env.Player.MainSkill.SkillFlags[SkillFlagMultiPart])
+
+	env, _, _, _ = InitEnv(iceSpearBuild(t, 2), envCache, OutputModeMain)
+	testza.AssertEqual(t, 2, env.Player.MainSkill.SkillPart)
+	testza.AssertEqual(t, "Second Form", env.Player.MainSkill.SkillPartName)
+
+	// The second form has 600% increased critical strike chance and additional critical strike multiplier
+	firstForm := iceSpearBuild(t, 1)
+	secondForm := iceSpearBuild(t, 2)
+	first := evaluateOutput(firstForm, firstForm.Build.PassiveNodes)
+	second := evaluateOutput(secondForm, secondForm.Build.PassiveNodes)
+	testza.AssertEqual(t, float64(7), first["CritChance"])
+	testza.AssertEqual(t, float64(49), second["CritChance"])
+	testza.AssertGreater(t, second["CritMultiplier"], first["CritMultiplier"])
+	testza.AssertGreater(t, second["AverageHit"], first["AverageHit"])
+}
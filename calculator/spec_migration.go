@@ -0,0 +1,264 @@
+package calculator
+
+import (
+	"errors"
+	"fmt"
+	"maps"
+	"slices"
+	"strconv"
+	"strings"
+
+	"github.com/Vilsol/go-pob/data"
+	"github.com/Vilsol/go-pob/pob"
+)
+
+type SpecMigration struct {
+	From string
+	To   string
+
+	// Allocated nodes in the new version, sorted
+	Nodes []int64
+
+	// Selected mastery effects in the new version
+	MasteryEffects map[int64]int64
+
+	// Allocated node IDs that were replaced by a node with a new ID
+	Remapped map[int64]int64
+
+	// Allocated nodes that no longer exist, sorted
+	Dropped []int64
+
+	// Allocated nodes that could not be reconnected to the class start, sorted
+	Unreachable []int64
+
+	// Nodes allocated to reconnect the remaining nodes, in allocation order
+	Added []int64
+
+	// Mastery nodes whose selected effect no longer exists, sorted
+	DroppedMasteryEffects []int64
+
+	// Allocated nodes whose name or stats changed
+	Changed []data.NodeChange
+
+	// Full diff of both tree versions
+	Diff *data.TreeDiff
+
+	fromTree *data.Tree
+	toTree   *data.Tree
+}
+
+// crystalline:promise
+func (c *Calculator) MigrateSpec(to data.TreeVersion) (*SpecMigration, error) {
+	spec := activeSpec(c.PoB)
+	if spec == nil {
+		return nil, errors.New("build has no active spec")
+	}
+
+	from, ok := data.TreeVersions[spec.TreeVersion]
+	if !ok {
+		return nil, fmt.Errorf("unknown tree version: %s", spec.TreeVersion)
+	}
+
+	toVersion, ok := data.TreeVersions[to]
+	if !ok {
+		return nil, fmt.Errorf("unknown tree version: %s", to)
+	}
+
+	extensions := newSocketedPassiveSpec(c.PoB, spec.TreeVersion).GraphExtensions()
+	migration := MigrateSpec(c.PoB, from, toVersion, extensions...)
+	migration.Apply(c.PoB, to)
+	return migration, nil
+}
+
+// MigrateSpec moves the allocated nodes of the build to a newer tree version.
+//
+// Nodes that still exist are kept, removed nodes are replaced by their remapped node if any and dropped
+// otherwise. Nodes that lost their connection to the class start are reconnected via the cheapest paths,
+// which may require more points than the build has available. Nodes of socketed cluster jewels and nodes that were
+// already disconnected before the migration are kept as is.
+//
+// The graph extensions of the socketed jewels of the build are used to path through on both tree versions.
+func MigrateSpec(build *pob.PathOfBuilding, from *data.TreeVersionData, to *data.TreeVersionData, extensions ...data.GraphExtension) *SpecMigration {
+	diff := from.Diff(to)
+	fromTree, toTree := from.Tree(), to.Tree()
+
+	migration := &SpecMigration{
+		From:                  from.Display,
+		To:                    to.Display,
+		MasteryEffects:        make(map[int64]int64),
+		Remapped:              make(map[int64]int64),
+		Dropped:               make([]int64, 0),
+		Unreachable:           make([]int64, 0),
+		Added:                 make([]int64, 0),
+		DroppedMasteryEffects: make([]int64, 0),
+		Changed:               make([]data.NodeChange, 0),
+		Diff:                  diff,
+		fromTree:              fromTree,
+		toTree:                toTree,
+	}
+
+	var subgraphNodes, nodes []int64
+	for _, id := range build.Build.PassiveNodes {
+		if data.IsSubgraphNode(id) {
+			subgraphNodes = append(subgraphNodes, id)
+			continue
+		}
+
+		newID := id
+		if _, ok := toTree.Nodes[strconv.FormatInt(id, 10)]; !ok {
+			remapped, ok := diff.Remapped[id]
+			if !ok {
+				migration.Dropped = append(migration.Dropped, id)
+				continue
+			}
+
+			migration.Remapped[id] = remapped
+			newID = remapped
+		}
+
+		nodes = append(nodes, newID)
+
+		if effect, ok := build.Build.MasteryEffects[id]; ok {
+			node := toTree.Nodes[strconv.FormatInt(newID, 10)]
+			if slices.ContainsFunc(node.MasteryEffects, func(e data.MasteryEffect) bool { return e.Effect == effect }) {
+				migration.MasteryEffects[newID] = effect
+			} else {
+				migration.DroppedMasteryEffects = append(migration.DroppedMasteryEffects, id)
+			}
+		}
+	}
+
+	for _, change := range diff.Changed {
+		if slices.Contains(nodes, change.ID) {
+			migration.Changed = append(migration.Changed, change)
+		}
+	}
+
+	className, ascendancyName := data.ClassName(build.Build.ClassName), data.AscendancyName(build.Build.AscendClassName)
+
+	// Nodes that were already disconnected before are left as is
+	wasDisconnected := make(map[int64]bool)
+	for _, id := range from.DisconnectedNodes(build.Build.PassiveNodes, from.RootNodes(className, ascendancyName), extensions...) {
+		if remapped, ok := migration.Remapped[id]; ok {
+			id = remapped
+		}
+		wasDisconnected[id] = true
+	}
+
+	var disconnected []int64
+	for _, id := range to.DisconnectedNodes(nodes, to.RootNodes(className, ascendancyName), extensions...) {
+		if !wasDisconnected[id] {
+			disconnected = append(disconnected, id)
+		}
+	}
+
+	connected := slices.DeleteFunc(slices.Clone(nodes), func(id int64) bool {
+		return slices.Contains(disconnected, id)
+	})
+
+	steiner := to.CalculateSteinerTree(connected, to.RootNodes(className, ascendancyName), disconnected, extensions...)
+	for _, id := range steiner.Nodes {
+		if !slices.Contains(disconnected, id) {
+			migration.Added = append(migration.Added, id)
+		}
+	}
+
+	migration.Unreachable = steiner.Unreachable
+	for _, id := range steiner.Unreachable {
+		delete(migration.MasteryEffects, id)
+	}
+
+	migration.Nodes = slices.Concat(connected, steiner.Nodes, subgraphNodes)
+	slices.Sort(migration.Nodes)
+	migration.Nodes = slices.Compact(migration.Nodes)
+
+	slices.Sort(migration.Dropped)
+	slices.Sort(migration.DroppedMasteryEffects)
+
+	return migration
+}
+
+// Apply replaces the allocated nodes and mastery effects of the build and its active spec (if any) with the migrated ones
+func (m *SpecMigration) Apply(build *pob.PathOfBuilding, treeVersion data.TreeVersion) {
+	build.Build.PassiveNodes = slices.Clone(m.Nodes)
+	build.Build.MasteryEffects = maps.Clone(m.MasteryEffects)
+
+	nodes := make([]string, len(m.Nodes))
+	for i, id := range m.Nodes {
+		nodes[i] = strconv.FormatInt(id, 10)
+	}
+
+	effects := make([]string, 0, len(m.MasteryEffects))
+	for _, id := range slices.Sorted(maps.Keys(m.MasteryEffects)) {
+		effects = append(effects, fmt.Sprintf("{%d,%d}", id, m.MasteryEffects[id]))
+	}
+
+	spec := activeSpec(build)
+	if spec == nil {
+		return
+	}
+
+	spec.TreeVersion = treeVersion
+	spec.NodesAttr = strings.Join(nodes, ",")
+	spec.MasteryEffects = strings.Join(effects, ",")
+}
+
+// Report describes the changes to the build in a human-readable form
+func (m *SpecMigration) Report() string {
+	var sb strings.Builder
+	fmt.Fprintf(&sb, "Migrated passive tree from %s to %s\n", m.From, m.To)
+
+	writeNodes := func(title string, tree *data.Tree, ids []int64) {
+		if len(ids) == 0 {
+			return
+		}
+
+		fmt.Fprintf(&sb, "\n%s:\n", title)
+		for _, id := range ids {
+			fmt.Fprintf(&sb, "  - %s\n", describeNode(tree, id))
+		}
+	}
+
+	if len(m.Remapped) > 0 {
+		sb.WriteString("\nRemapped:\n")
+		for _, id := range slices.Sorted(maps.Keys(m.Remapped)) {
+			fmt.Fprintf(&sb, "  - %s -> %d\n", describeNode(m.fromTree, id), m.Remapped[id])
+		}
+	}
+
+	writeNodes("Dropped", m.fromTree, m.Dropped)
+	writeNodes("Unreachable", m.toTree, m.Unreachable)
+	writeNodes("Allocated to reconnect", m.toTree, m.Added)
+	writeNodes("Mastery effect no longer available", m.fromTree, m.DroppedMasteryEffects)
+
+	if len(m.Changed) > 0 {
+		sb.WriteString("\nChanged:\n")
+		for _, change := range m.Changed {
+			fmt.Fprintf(&sb, "  - %s\n", describeNode(m.toTree, change.ID))
+			if change.OldName != change.NewName {
+				fmt.Fprintf(&sb, "      renamed from %s\n", change.OldName)
+			}
+			for _, stat := range change.RemovedStats {
+				fmt.Fprintf(&sb, "      - %s\n", stat)
+			}
+			for _, stat := range change.AddedStats {
+				fmt.Fprintf(&sb, "      + %s\n", stat)
+			}
+		}
+	}
+
+	if len(m.Remapped) == 0 && len(m.Dropped) == 0 && len(m.Unreachable) == 0 && len(m.Added) == 0 &&
+		len(m.DroppedMasteryEffects) == 0 && len(m.Changed) == 0 {
+		sb.WriteString("\nNo allocated nodes changed\n")
+	}
+
+	return sb.String()
+}
+
+func describeNode(tree *data.Tree, id int64) string {
+	node, ok := tree.Nodes[strconv.FormatInt(id, 10)]
+	if !ok || node.Name == nil {
+		return strconv.FormatInt(id, 10)
+	}
+	return fmt.Sprintf("%s (%d)", *node.Name, id)
+}
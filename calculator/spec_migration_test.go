@@ -0,0 +1,181 @@
+package calculator
+
+import (
+	"encoding/json"
+	"os"
+	"slices"
+	"strconv"
+	"testing"
+
+	"github.com/MarvinJWendt/testza"
+
+	"github.com/Vilsol/go-pob/builds"
+	"github.com/Vilsol/go-pob/data"
+	"github.com/Vilsol/go-pob/pob"
+)
+
+// modifiedTreeVersion returns a copy of the latest tree version with the modifications applied
+func modifiedTreeVersion(t *testing.T, modify func(tree *data.Tree)) *data.TreeVersionData {
+	var tree data.Tree
+	testza.AssertNoError(t, json.Unmarshal(data.TreeVersions[data.LatestTreeVersion].RawTree(), &tree))
+
+	modify(&tree)
+
+	raw, err := json.Marshal(tree)
+	testza.AssertNoError(t, err)
+
+	return data.NewTreeVersionData("modified", 99, raw)
+}
+
+// replaceTreeNode moves the node to a new ID, or removes it if the new ID is empty
+func replaceTreeNode(tree *data.Tree, id string, newID string) {
+	replace := func(ids []string) []string {
+		ids = slices.Clone(ids)
+		for i, other := range ids {
+			if other == id {
+				ids[i] = newID
+			}
+		}
+		return slices.DeleteFunc(ids, func(other string) bool { return other == "" })
+	}
+
+	for key, node := range tree.Nodes {
+		node.Out = replace(node.Out)
+		node.In = replace(node.In)
+		tree.Nodes[key] = node
+	}
+
+	node := tree.Nodes[id]
+	for key, group := range tree.Groups {
+		group.Nodes = replace(group.Nodes)
+		tree.Groups[key] = group
+	}
+
+	delete(tree.Nodes, id)
+	if newID != "" {
+		skill, _ := strconv.ParseInt(newID, 10, 64)
+		node.Skill = &skill
+		tree.Nodes[newID] = node
+	}
+}
+
+func TestMigrateSpec(t *testing.T) {
+	from := data.TreeVersions[data.LatestTreeVersion]
+
+	// Witch start to Deep Wisdom via Arcanist's Dominion
+	witchNodes := []int64{54447, 57264, 33296, 1957, 739, 18866, 11420, 60554, 32024, 27929}
+
+	tests := []struct {
+		name     string
+		build    string
+		modify   func(build *pob.PathOfBuilding)
+		tree     func(tree *data.Tree)
+		expected func(t *testing.T, migration *SpecMigration)
+	}{
+		{
+			name:  "Unchanged",
+			build: "Fireball-full.xml",
+			tree:  func(tree *data.Tree) {},
+			expected: func(t *testing.T, migration *SpecMigration) {
+				testza.AssertLen(t, migration.Dropped, 0)
+				testza.AssertLen(t, migration.Added, 0)
+				testza.AssertEqual(t, map[int64]int64{12382: 47642, 5348: 30502}, migration.MasteryEffects)
+				testza.AssertContains(t, migration.Report(), "No allocated nodes changed")
+			},
+		},
+		{
+			name:  "Repath",
+			build: "Fireball.xml",
+			modify: func(build *pob.PathOfBuilding) {
+				build.Build.ClassName = "Witch"
+				build.Build.PassiveNodes = witchNodes
+			},
+			tree: func(tree *data.Tree) {
+				replaceTreeNode(tree, "739", "")
+				replaceTreeNode(tree, "27929", "127929")
+
+				node := tree.Nodes["1957"]
+				node.Stats = []string{"5% increased Cast Speed"}
+				tree.Nodes["1957"] = node
+			},
+			expected: func(t *testing.T, migration *SpecMigration) {
+				testza.AssertEqual(t, []int64{739}, migration.Dropped)
+				testza.AssertEqual(t, map[int64]int64{27929: 127929}, migration.Remapped)
+				testza.AssertLen(t, migration.Unreachable, 0)
+
+				// Arcanist's Dominion is reconnected through the Intelligence nodes
+				testza.AssertEqual(t, []int64{37569, 36542, 4397}, migration.Added)
+				testza.AssertEqual(t, []int64{1957}, []int64{migration.Changed[0].ID})
+
+				for _, id := range []int64{18866, 11420, 127929, 4397} {
+					testza.AssertContains(t, migration.Nodes, id)
+				}
+				testza.AssertNotContains(t, migration.Nodes, int64(739))
+				testza.AssertNotContains(t, migration.Nodes, int64(27929))
+
+				report := migration.Report()
+				testza.AssertContains(t, report, "Deep Wisdom (27929) -> 127929")
+				testza.AssertContains(t, report, "Cast Speed (739)")
+				testza.AssertContains(t, report, "+ 5% increased Cast Speed")
+			},
+		},
+		{
+			name:  "MasteryEffect",
+			build: "Fireball-full.xml",
+			tree: func(tree *data.Tree) {
+				node := tree.Nodes["12382"]
+				node.MasteryEffects = slices.DeleteFunc(slices.Clone(node.MasteryEffects), func(effect data.MasteryEffect) bool {
+					return effect.Effect == 47642
+				})
+				tree.Nodes["12382"] = node
+			},
+			expected: func(t *testing.T, migration *SpecMigration) {
+				testza.AssertEqual(t, []int64{12382}, migration.DroppedMasteryEffects)
+				testza.AssertEqual(t, map[int64]int64{5348: 30502}, migration.MasteryEffects)
+				testza.AssertContains(t, migration.Nodes, int64(12382))
+			},
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			file, err := os.ReadFile("../testdata/builds/" + test.build)
+			testza.AssertNoError(t, err)
+
+			build, err := builds.ParseBuild(file)
+			testza.AssertNoError(t, err)
+
+			if test.modify != nil {
+				test.modify(build)
+			}
+
+			migration := MigrateSpec(build, from, modifiedTreeVersion(t, test.tree))
+			test.expected(t, migration)
+
+			migration.Apply(build, data.LatestTreeVersion)
+			testza.AssertEqual(t, migration.Nodes, build.Build.PassiveNodes)
+			testza.AssertEqual(t, migration.MasteryEffects, build.Build.MasteryEffects)
+
+			// The active spec is written in the format read by the build parser
+			spec := build.Tree.Specs[build.Tree.ActiveSpec-1]
+			reparsed, err := builds.ParseBuild([]byte(`<PathOfBuilding><Tree activeSpec="1"><Spec nodes="` + spec.NodesAttr +
+				`" masteryEffects="` + spec.MasteryEffects + `"/></Tree></PathOfBuilding>`))
+			testza.AssertNoError(t, err)
+			testza.AssertEqual(t, migration.Nodes, reparsed.Build.PassiveNodes)
+			testza.AssertEqual(t, migration.MasteryEffects, reparsed.Build.MasteryEffects)
+		})
+	}
+}
+
+func TestCalculatorMigrateSpecErrors(t *testing.T) {
+	_, err := NewCalculator(pob.PathOfBuilding{}).MigrateSpec(data.LatestTreeVersion)
+	testza.AssertNotNil(t, err, "Builds without an active spec can't be migrated")
+
+	build := pob.PathOfBuilding{Tree: pob.Tree{ActiveSpec: 1, Specs: []pob.Spec{{TreeVersion: "0_1"}}}}
+	_, err = NewCalculator(build).MigrateSpec(data.LatestTreeVersion)
+	testza.AssertNotNil(t, err)
+
+	build.Tree.Specs[0].TreeVersion = data.LatestTreeVersion
+	_, err = NewCalculator(build).MigrateSpec("0_1")
+	testza.AssertNotNil(t, err)
+}
@@ -0,0 +1,194 @@
+package calculator
+
+import (
+	"slices"
+	"strconv"
+
+	"github.com/Vilsol/go-pob/data"
+)
+
+// Passive points granted outside of character levels
+const (
+	questPassivePoints  = 22
+	banditPassivePoints = 2
+)
+
+type SpecFindingKind string
+
+const (
+	// Allocated nodes that are not part of the tree or of a socketed cluster jewel
+	SpecFindingUnknownNode = SpecFindingKind("UNKNOWN_NODE")
+
+	// Allocated nodes that can't be reached from the class start
+	SpecFindingDisconnected = SpecFindingKind("DISCONNECTED")
+
+	// Allocated start nodes of other classes
+	SpecFindingWrongClass = SpecFindingKind("WRONG_CLASS")
+
+	// Allocated nodes of other ascendancies
+	SpecFindingWrongAscendancy = SpecFindingKind("WRONG_ASCENDANCY")
+
+	// More regular nodes allocated than passive points are available
+	SpecFindingTooManyPoints = SpecFindingKind("TOO_MANY_POINTS")
+
+	// More ascendancy nodes allocated than ascendancy points are available
+	SpecFindingTooManyAscendancyPoints = SpecFindingKind("TOO_MANY_ASCENDANCY_POINTS")
+
+	// Allocated masteries without a selected effect
+	SpecFindingUnselectedMastery = SpecFindingKind("UNSELECTED_MASTERY")
+
+	// Unallocated start nodes of the class or ascendancy
+	SpecFindingMissingStartNode = SpecFindingKind("MISSING_START_NODE")
+)
+
+type SpecFinding struct {
+	Kind SpecFindingKind
+
+	// Affected node IDs, sorted
+	Nodes []int64
+
+	// Used and available passive points of point findings
+	Used      int
+	Available int
+}
+
+// crystalline:promise
+func (c *Calculator) ValidateSpec() []SpecFinding {
+	return ValidateSpec(newSocketedPassiveSpec(c.PoB, data.LatestTreeVersion))
+}
+
+// ValidateSpec checks the allocated nodes of the build against the tree of the spec.
+//
+// NewPassiveSpec silently drops nodes that can't be allocated, so the node list and mastery
+// effects of the build are validated instead of the nodes of the spec. Returns no findings if
+// the allocation is valid.
+func ValidateSpec(spec *PassiveSpec) []SpecFinding {
+	tree := spec.Tree()
+	treeVersion := data.TreeVersions[spec.TreeVersion]
+	classIndex := int64(data.ClassIDs[spec.ClassName])
+
+	var unknown, wrongClass, wrongAscendancy, masteries, active []int64
+	classStart, ascendancyStart := false, false
+	regular, ascendancy, granted := 0, 0, 0
+
+	for _, id := range spec.Build.Build.PassiveNodes {
+		nodeID := strconv.FormatInt(id, 10)
+
+		if data.IsSubgraphNode(id) {
+			if _, ok := spec.AllocNodes[nodeID]; !ok {
+				unknown = append(unknown, id)
+				continue
+			}
+
+			regular++
+			active = append(active, id)
+			continue
+		}
+
+		node, ok := tree.Nodes[nodeID]
+		if !ok {
+			unknown = append(unknown, id)
+			continue
+		}
+
+		switch {
+		case node.ClassStartIndex != nil:
+			if *node.ClassStartIndex != classIndex {
+				wrongClass = append(wrongClass, id)
+			} else {
+				classStart = true
+			}
+			continue
+		case node.AscendancyName != nil && *node.AscendancyName != string(spec.AscendancyName):
+			wrongAscendancy = append(wrongAscendancy, id)
+			continue
+		case node.IsAscendancyStart != nil && *node.IsAscendancyStart:
+			ascendancyStart = true
+		case node.AscendancyName != nil:
+			ascendancy++
+		default:
+			regular++
+		}
+
+		if node.GrantedPassivePoints != nil {
+			granted += int(*node.GrantedPassivePoints)
+		}
+
+		if node.IsMastery != nil && *node.IsMastery {
+			if _, ok := spec.MasterySelections[nodeID]; !ok {
+				masteries = append(masteries, id)
+			}
+		}
+
+		active = append(active, id)
+	}
+
+	findings := make([]SpecFinding, 0)
+	addFinding := func(kind SpecFindingKind, nodes []int64) {
+		if len(nodes) > 0 {
+			slices.Sort(nodes)
+			findings = append(findings, SpecFinding{Kind: kind, Nodes: slices.Compact(nodes)})
+		}
+	}
+
+	addFinding(SpecFindingUnknownNode, unknown)
+	addFinding(SpecFindingWrongClass, wrongClass)
+	addFinding(SpecFindingWrongAscendancy, wrongAscendancy)
+
+	roots := treeVersion.RootNodes(spec.ClassName, spec.AscendancyName)
+	addFinding(SpecFindingDisconnected, treeVersion.DisconnectedNodes(active, roots, spec.GraphExtensions()...))
+	addFinding(SpecFindingUnselectedMastery, masteries)
+
+	var missingStart []int64
+	if !classStart && len(active) > 0 {
+		missingStart = append(missingStart, classStartNode(tree, classIndex))
+	}
+	if !ascendancyStart && spec.AscendancyName != "" {
+		missingStart = append(missingStart, ascendancyStartNode(tree, spec.AscendancyName))
+	}
+	addFinding(SpecFindingMissingStartNode, missingStart)
+
+	if available := availablePassivePoints(spec, tree) + granted; regular > available {
+		findings = append(findings, SpecFinding{Kind: SpecFindingTooManyPoints, Used: regular, Available: available})
+	}
+
+	if available := int(tree.Points.AscendancyPoints); ascendancy > available {
+		findings = append(findings, SpecFinding{Kind: SpecFindingTooManyAscendancyPoints, Used: ascendancy, Available: available})
+	}
+
+	return findings
+}
+
+// availablePassivePoints returns the passive points of the character level, quests and the bandit
+// quest, capped to the total points of the tree
+func availablePassivePoints(spec *PassiveSpec, tree *data.Tree) int {
+	level := min(max(spec.Build.Build.Level, 1), 100)
+
+	points := level - 1 + questPassivePoints
+	switch spec.Build.GetStringOption("bandit") {
+	case "Alira", "Kraityn", "Oak":
+	default:
+		points += banditPassivePoints
+	}
+
+	return min(points, int(tree.Points.TotalPoints))
+}
+
+func classStartNode(tree *data.Tree, classIndex int64) int64 {
+	for _, node := range tree.Nodes {
+		if node.ClassStartIndex != nil && *node.ClassStartIndex == classIndex && node.Skill != nil {
+			return *node.Skill
+		}
+	}
+	return 0
+}
+
+func ascendancyStartNode(tree *data.Tree, ascendancyName data.AscendancyName) int64 {
+	for _, node := range tree.Nodes {
+		if node.IsAscendancyStart != nil && *node.IsAscendancyStart && node.AscendancyName != nil &&
+			*node.AscendancyName == string(ascendancyName) && node.Skill != nil {
+			return *node.Skill
+		}
+	}
+	return 0
+}
@@ -0,0 +1,178 @@
+package calculator
+
+import (
+	"os"
+	"slices"
+	"testing"
+
+	"github.com/MarvinJWendt/testza"
+
+	"github.com/Vilsol/go-pob/builds"
+	"github.com/Vilsol/go-pob/data"
+	"github.com/Vilsol/go-pob/pob"
+)
+
+// connectedNodes returns up to count nodes connected to the start node, breadth first through the matching nodes
+func connectedNodes(start string, count int, matches func(node data.Node) bool) []int64 {
+	tree := data.TreeVersions[data.LatestTreeVersion].Tree()
+
+	result := make([]int64, 0, count)
+	visited := map[string]bool{start: true}
+	queue := []string{start}
+	for len(queue) > 0 && len(result) < count {
+		current := tree.Nodes[queue[0]]
+		queue = queue[1:]
+
+		for _, next := range slices.Concat(current.Out, current.In) {
+			node, ok := tree.Nodes[next]
+			if visited[next] || !ok || !matches(node) || len(result) == count {
+				continue
+			}
+
+			visited[next] = true
+			result = append(result, *node.Skill)
+			queue = append(queue, next)
+		}
+	}
+	return result
+}
+
+func TestValidateSpec(t *testing.T) {
+	regularNodes := func(count int) []int64 {
+		return connectedNodes("58833", count, func(node data.Node) bool {
+			return node.ClassStartIndex == nil && node.AscendancyName == nil
+		})
+	}
+
+	occultistNodes := connectedNodes("18378", 9, func(node data.Node) bool {
+		return node.AscendancyName != nil && *node.AscendancyName == "Occultist"
+	})
+
+	bandit := "Alira"
+
+	tests := []struct {
+		name     string
+		modify   func(build *pob.PathOfBuilding)
+		findings []SpecFinding
+	}{
+		{
+			name:     "Valid",
+			modify:   func(build *pob.PathOfBuilding) {},
+			findings: []SpecFinding{},
+		},
+		{
+			name: "Disconnected",
+			modify: func(build *pob.PathOfBuilding) {
+				// Heart of the Warrior is nowhere near the scion start
+				build.Build.PassiveNodes = append(build.Build.PassiveNodes, 47062, 61198)
+			},
+			findings: []SpecFinding{{Kind: SpecFindingDisconnected, Nodes: []int64{61198}}},
+		},
+		{
+			name: "UnknownNode",
+			modify: func(build *pob.PathOfBuilding) {
+				build.Build.PassiveNodes = append(build.Build.PassiveNodes, 1, 65537)
+			},
+			findings: []SpecFinding{{Kind: SpecFindingUnknownNode, Nodes: []int64{1, 65537}}},
+		},
+		{
+			name: "WrongClass",
+			modify: func(build *pob.PathOfBuilding) {
+				build.Build.PassiveNodes = append(build.Build.PassiveNodes, 54447)
+			},
+			findings: []SpecFinding{{Kind: SpecFindingWrongClass, Nodes: []int64{54447}}},
+		},
+		{
+			name: "WrongAscendancy",
+			modify: func(build *pob.PathOfBuilding) {
+				build.Build.PassiveNodes = append(build.Build.PassiveNodes, 18378, occultistNodes[0])
+			},
+			findings: []SpecFinding{{Kind: SpecFindingWrongAscendancy, Nodes: []int64{18378, occultistNodes[0]}}},
+		},
+		{
+			name: "UnselectedMastery",
+			modify: func(build *pob.PathOfBuilding) {
+				build.Build.PassiveNodes = append(build.Build.PassiveNodes, 12382, 38235)
+				build.SelectMasteryEffect(12382, 47642)
+			},
+			findings: []SpecFinding{
+				{Kind: SpecFindingDisconnected, Nodes: []int64{12382, 38235}},
+				{Kind: SpecFindingUnselectedMastery, Nodes: []int64{38235}},
+			},
+		},
+		{
+			name: "MissingClassStart",
+			modify: func(build *pob.PathOfBuilding) {
+				build.Build.PassiveNodes = []int64{47062}
+			},
+			findings: []SpecFinding{{Kind: SpecFindingMissingStartNode, Nodes: []int64{58833}}},
+		},
+		{
+			name: "MissingAscendancyStart",
+			modify: func(build *pob.PathOfBuilding) {
+				build.Build.AscendClassName = "Ascendant"
+				build.Build.PassiveNodes = append(build.Build.PassiveNodes, 22551)
+			},
+			findings: []SpecFinding{
+				{Kind: SpecFindingDisconnected, Nodes: []int64{22551}},
+				{Kind: SpecFindingMissingStartNode, Nodes: []int64{35754}},
+			},
+		},
+		{
+			name: "TooManyPoints",
+			modify: func(build *pob.PathOfBuilding) {
+				build.Build.PassiveNodes = append(build.Build.PassiveNodes, regularNodes(30)...)
+			},
+			findings: []SpecFinding{{Kind: SpecFindingTooManyPoints, Used: 30, Available: 24}},
+		},
+		{
+			name: "LevelPoints",
+			modify: func(build *pob.PathOfBuilding) {
+				build.Build.Level = 7
+				build.Build.PassiveNodes = append(build.Build.PassiveNodes, regularNodes(30)...)
+			},
+			findings: []SpecFinding{},
+		},
+		{
+			name: "BanditPoints",
+			modify: func(build *pob.PathOfBuilding) {
+				build.SetConfigOption(pob.Input{Name: "bandit", String: &bandit})
+				build.Build.PassiveNodes = append(build.Build.PassiveNodes, regularNodes(24)...)
+			},
+			findings: []SpecFinding{{Kind: SpecFindingTooManyPoints, Used: 24, Available: 22}},
+		},
+		{
+			name: "GrantedPassivePoints",
+			modify: func(build *pob.PathOfBuilding) {
+				// Passive Point of the Ascendant grants an additional point
+				build.Build.AscendClassName = "Ascendant"
+				build.Build.PassiveNodes = append(build.Build.PassiveNodes, 35754, 22551, 41534)
+				build.Build.PassiveNodes = append(build.Build.PassiveNodes, regularNodes(26)...)
+			},
+			findings: []SpecFinding{{Kind: SpecFindingTooManyPoints, Used: 26, Available: 25}},
+		},
+		{
+			name: "TooManyAscendancyPoints",
+			modify: func(build *pob.PathOfBuilding) {
+				build.Build.ClassName = "Witch"
+				build.Build.AscendClassName = "Occultist"
+				build.Build.PassiveNodes = append([]int64{54447, 18378}, occultistNodes...)
+			},
+			findings: []SpecFinding{{Kind: SpecFindingTooManyAscendancyPoints, Used: 9, Available: 8}},
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			file, err := os.ReadFile("../testdata/builds/Fireball.xml")
+			testza.AssertNoError(t, err)
+
+			build, err := builds.ParseBuild(file)
+			testza.AssertNoError(t, err)
+
+			test.modify(build)
+
+			testza.AssertEqual(t, test.findings, ValidateSpec(NewPassiveSpec(build, data.LatestTreeVersion)))
+		})
+	}
+}
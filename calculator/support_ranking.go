@@ -0,0 +1,283 @@
+package calculator
+
+import (
+	"cmp"
+	"context"
+	"fmt"
+	"runtime"
+	"slices"
+	"strconv"
+	"strings"
+	"sync"
+
+	"github.com/Vilsol/go-pob-data/poe"
+
+	raw2 "github.com/Vilsol/go-pob/data/raw"
+	"github.com/Vilsol/go-pob/pob"
+)
+
+const defaultSupportRankingStat = "CombinedDPS"
+
+type SupportRankingOptions struct {
+	// Socket group to rank the supports of, as 1-based index in the active skill set, or zero for the main socket group
+	SocketGroup int
+
+	// Output stat to rank by, the gem sort field of the build or CombinedDPS if empty
+	Stat string
+
+	// Gem to replace, as 1-based index in the socket group, or zero to add the supports as an additional gem
+	ReplaceGem int
+
+	// Amount of parallel calculations, GOMAXPROCS if zero
+	Workers int
+}
+
+type SupportRanking struct {
+	GemID   string
+	Name    string
+	Level   int
+	Quality int
+
+	// Value of the stat with the support in the socket group
+	Value float64
+
+	// Change of the stat compared to the current socket group
+	Delta float64
+}
+
+// crystalline:promise
+func (c *Calculator) RankSupports(socketGroup int, replaceGem int) ([]SupportRanking, error) {
+	return RankSupports(context.Background(), c.PoB, SupportRankingOptions{
+		SocketGroup: socketGroup,
+		ReplaceGem:  replaceGem,
+	})
+}
+
+// RankSupports calculates the build with every support gem that is compatible with the active skill of the socket
+// group added to it, or replacing one of its gems, and ranks them by the resulting value of the stat.
+//
+// Supports already in the socket group are skipped. Supports use the default gem level and quality of the build.
+func RankSupports(ctx context.Context, build *pob.PathOfBuilding, options SupportRankingOptions) ([]SupportRanking, error) {
+	skillSet := max(build.Skills.ActiveSkillSet-1, 0)
+	if skillSet >= len(build.Skills.SkillSets) {
+		return nil, fmt.Errorf("skill set %d does not exist", skillSet+1)
+	}
+
+	socketGroup := options.SocketGroup
+	if socketGroup <= 0 {
+		socketGroup = max(build.Build.MainSocketGroup, 1)
+	}
+
+	groups := build.Skills.SkillSets[skillSet].Skills
+	if socketGroup > len(groups) {
+		return nil, fmt.Errorf("socket group %d does not exist", socketGroup)
+	}
+
+	gems := groups[socketGroup-1].Gems
+	if options.ReplaceGem < 0 || options.ReplaceGem > len(gems) {
+		return nil, fmt.Errorf("gem %d does not exist in socket group %d", options.ReplaceGem, socketGroup)
+	}
+
+	activeGemID := ""
+	for _, gem := range gems {
+		if entry, ok := findGemCatalogueEntry(gem.GemID); ok && gem.Enabled && !entry.Support {
+			activeGemID = entry.ID
+			break
+		}
+	}
+
+	if activeGemID == "" {
+		return nil, fmt.Errorf("socket group %d has no active skill gem", socketGroup)
+	}
+
+	supports, err := CompatibleSupports(activeGemID)
+	if err != nil {
+		return nil, err
+	}
+
+	// Awakened gems can't be socketed together with their regular variant
+	socketed := make(map[string]bool)
+	for _, gem := range gems {
+		if entry, ok := findGemCatalogueEntry(gem.GemID); ok {
+			socketed[regularGemVariantID(entry)] = true
+		}
+	}
+
+	supports = slices.DeleteFunc(supports, func(entry GemCatalogueEntry) bool {
+		return socketed[regularGemVariantID(entry)]
+	})
+
+	stat := cmp.Or(options.Stat, build.Skills.SortGemsByDPSField, defaultSupportRankingStat)
+
+	// Evaluating the current socket group first also warms up all shared caches before going parallel
+	current := withSocketGroupGems(build, skillSet, socketGroup, gems)
+	base := evaluateOutput(current, current.Build.PassiveNodes)[stat]
+
+	// Every ranked build shares the passive nodes, so the node mods are all parsed by now
+	envCache.saveNodeMods()
+
+	workers := options.Workers
+	if workers <= 0 {
+		workers = runtime.GOMAXPROCS(0)
+	}
+
+	ctx, cancel := context.WithCancel(ctx)
+	defer cancel()
+
+	indices := make(chan int)
+	rankings := make([]SupportRanking, len(supports))
+	done := make([]bool, len(supports))
+
+	var wg sync.WaitGroup
+	for i := 0; i < workers; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for index := range indices {
+				if ctx.Err() != nil {
+					return
+				}
+
+				rankings[index] = rankSupport(build, skillSet, socketGroup, options.ReplaceGem, supports[index], stat, base)
+				done[index] = true
+			}
+		}()
+	}
+
+	func() {
+		defer close(indices)
+		for i := range supports {
+			select {
+			case indices <- i:
+			case <-ctx.Done():
+				return
+			}
+		}
+	}()
+
+	wg.Wait()
+
+	if err := ctx.Err(); err != nil && slices.Contains(done, false) {
+		return nil, fmt.Errorf("support ranking cancelled: %w", err)
+	}
+
+	slices.SortFunc(rankings, func(a, b SupportRanking) int {
+		return cmp.Or(cmp.Compare(b.Value, a.Value), cmp.Compare(a.Name, b.Name))
+	})
+
+	return rankings, nil
+}
+
+func rankSupport(build *pob.PathOfBuilding, skillSet int, socketGroup int, replaceGem int, support GemCatalogueEntry, stat string, base float64) SupportRanking {
+	gem := pob.Gem{
+		GemID:         support.ID,
+		SkillID:       support.GrantedEffectID,
+		NameSpec:      strings.TrimSuffix(support.Name, " Support"),
+		Level:         defaultGemLevel(build, support),
+		Quality:       defaultGemQuality(build),
+		QualityID:     "Default",
+		Enabled:       true,
+		EnableGlobal1: true,
+		EnableGlobal2: true,
+		Count:         1,
+	}
+
+	gems := slices.Clone(build.Skills.SkillSets[skillSet].Skills[socketGroup-1].Gems)
+	if replaceGem > 0 {
+		gems[replaceGem-1] = gem
+	} else {
+		gems = append(gems, gem)
+	}
+
+	modified := withSocketGroupGems(build, skillSet, socketGroup, gems)
+	value := evaluateOutput(modified, modified.Build.PassiveNodes)[stat]
+
+	return SupportRanking{
+		GemID:   support.ID,
+		Name:    support.Name,
+		Level:   gem.Level,
+		Quality: gem.Quality,
+		Value:   value,
+		Delta:   value - base,
+	}
+}
+
+// withSocketGroupGems returns a copy of the build with the gems of the socket group replaced and the socket group
+// selected as the main socket group
+func withSocketGroupGems(build *pob.PathOfBuilding, skillSet int, socketGroup int, gems []pob.Gem) *pob.PathOfBuilding {
+	modified := *build
+	modified.Build.MainSocketGroup = socketGroup
+
+	modified.Skills.SkillSets = slices.Clone(build.Skills.SkillSets)
+	modified.Skills.SkillSets[skillSet].Skills = slices.Clone(build.Skills.SkillSets[skillSet].Skills)
+	modified.Skills.SkillSets[skillSet].Skills[socketGroup-1].Gems = gems
+
+	return &modified
+}
+
+func findGemCatalogueEntry(id string) (GemCatalogueEntry, bool) {
+	catalogue := GemCatalogue()
+	index := slices.IndexFunc(catalogue, func(entry GemCatalogueEntry) bool { return entry.ID == id })
+	if index < 0 {
+		return GemCatalogueEntry{}, false
+	}
+	return catalogue[index], true
+}
+
+// regularGemVariantID returns the ID of the regular variant of an awakened gem, or the ID of the gem itself
+func regularGemVariantID(entry GemCatalogueEntry) string {
+	if entry.gem.RegularVariant != nil {
+		if regular := poe.SkillGems[*entry.gem.RegularVariant].GetBaseItemType(); regular != nil {
+			return regular.ID
+		}
+	}
+	return entry.ID
+}
+
+// defaultGemLevel resolves the default gem level setting of the build for the gem. The setting is either a level or
+// one of normalMaximum, corruptedMaximum, awakenedMaximum and characterLevel.
+func defaultGemLevel(build *pob.PathOfBuilding, gem GemCatalogueEntry) int {
+	levels := raw2.GetCalculatedGrantedEffect(gem.grantedEffect).GetCalculatedLevels()
+
+	setting := "normalMaximum"
+	if build.Skills.DefaultGemLevel != nil {
+		setting = *build.Skills.DefaultGemLevel
+	}
+
+	if build.Skills.MatchGemLevelToCharacterLevel {
+		setting = "characterLevel"
+	}
+
+	level := gem.MaxLevel
+	switch setting {
+	case "normalMaximum":
+	case "corruptedMaximum":
+		level = gem.MaxLevel + 1
+	case "awakenedMaximum":
+		// Corrupted with an Empower Support of the highest level
+		level = gem.MaxLevel + 1
+		if !gem.Support {
+			level += 4
+		}
+	case "characterLevel":
+		level = 1
+		for l := 1; l <= gem.MaxLevel; l++ {
+			if calculated, ok := levels[l]; ok && calculated.LevelRequirement <= build.Build.Level {
+				level = l
+			}
+		}
+	default:
+		if parsed, err := strconv.Atoi(setting); err == nil {
+			level = parsed
+		}
+	}
+
+	return min(max(level, 1), len(levels))
+}
+
+func defaultGemQuality(build *pob.PathOfBuilding) int {
+	if build.Skills.DefaultGemQuality == nil {
+		return 0
+	}
+	return *build.Skills.DefaultGemQuality
+}
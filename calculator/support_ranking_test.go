@@ -0,0 +1,94 @@
+package calculator
+
+import (
+	"cmp"
+	"context"
+	"os"
+	"slices"
+	"testing"
+
+	"github.com/MarvinJWendt/testza"
+
+	"github.com/Vilsol/go-pob/builds"
+)
+
+func TestRankSupports(t *testing.T) {
+	file, err := os.ReadFile("../testdata/builds/Fireball.xml")
+	testza.AssertNoError(t, err)
+
+	build, err := builds.ParseBuild(file)
+	testza.AssertNoError(t, err)
+
+	// [1] Fireball + [1] Added Cold Damage
+	rankings, err := RankSupports(context.Background(), build, SupportRankingOptions{SocketGroup: 4})
+	testza.AssertNoError(t, err)
+	testza.AssertGreater(t, len(rankings), 0)
+
+	testza.AssertTrue(t, slices.IsSortedFunc(rankings, func(a, b SupportRanking) int {
+		return cmp.Compare(b.Value, a.Value)
+	}))
+
+	testza.AssertFalse(t, slices.ContainsFunc(rankings, func(ranking SupportRanking) bool {
+		return ranking.Name == "Added Cold Damage Support" || ranking.Name == "Awakened Added Cold Damage Support"
+	}))
+
+	index := slices.IndexFunc(rankings, func(ranking SupportRanking) bool {
+		return ranking.Name == "Added Chaos Damage Support"
+	})
+	testza.AssertGreaterOrEqual(t, index, 0)
+
+	addedChaos := rankings[index]
+	testza.AssertGreater(t, addedChaos.Delta, float64(0))
+	testza.AssertEqual(t, 1, addedChaos.Level)
+	testza.AssertEqual(t, 0, addedChaos.Quality)
+}
+
+func TestRankSupportsReplace(t *testing.T) {
+	file, err := os.ReadFile("../testdata/builds/Fireball.xml")
+	testza.AssertNoError(t, err)
+
+	build, err := builds.ParseBuild(file)
+	testza.AssertNoError(t, err)
+
+	added, err := RankSupports(context.Background(), build, SupportRankingOptions{SocketGroup: 4, Stat: "AverageHit"})
+	testza.AssertNoError(t, err)
+
+	replaced, err := RankSupports(context.Background(), build, SupportRankingOptions{SocketGroup: 4, Stat: "AverageHit", ReplaceGem: 2})
+	testza.AssertNoError(t, err)
+
+	find := func(rankings []SupportRanking, name string) SupportRanking {
+		index := slices.IndexFunc(rankings, func(ranking SupportRanking) bool { return ranking.Name == name })
+		testza.AssertGreaterOrEqual(t, index, 0)
+		return rankings[index]
+	}
+
+	// Replacing Added Cold Damage loses its damage
+	testza.AssertGreater(t, find(added, "Added Chaos Damage Support").Value, find(replaced, "Added Chaos Damage Support").Value)
+}
+
+func TestRankSupportsErrors(t *testing.T) {
+	file, err := os.ReadFile("../testdata/builds/Fireball.xml")
+	testza.AssertNoError(t, err)
+
+	build, err := builds.ParseBuild(file)
+	testza.AssertNoError(t, err)
+
+	_, err = RankSupports(context.Background(), build, SupportRankingOptions{SocketGroup: 100})
+	testza.AssertNotNil(t, err)
+
+	_, err = NewCalculator(*build).RankSupports(100, 0)
+	testza.AssertNotNil(t, err)
+
+	// Empty socket group
+	_, err = RankSupports(context.Background(), build, SupportRankingOptions{SocketGroup: 1})
+	testza.AssertNotNil(t, err)
+
+	_, err = RankSupports(context.Background(), build, SupportRankingOptions{SocketGroup: 4, ReplaceGem: 3})
+	testza.AssertNotNil(t, err)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+
+	_, err = RankSupports(ctx, build, SupportRankingOptions{SocketGroup: 4})
+	testza.AssertErrorIs(t, err, context.Canceled)
+}
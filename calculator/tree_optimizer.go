@@ -0,0 +1,447 @@
+package calculator
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"maps"
+	"math"
+	"runtime"
+	"slices"
+	"strconv"
+	"sync"
+
+	"github.com/Vilsol/go-pob/data"
+	"github.com/Vilsol/go-pob/pob"
+)
+
+const (
+	defaultOptimizerMaxStepCost       = 5
+	defaultOptimizerImprovementRounds = 10
+)
+
+type OptimizerConstraint struct {
+	Stat string
+	Min  float64
+}
+
+type OptimizerOptions struct {
+	// Output stat to maximise, e.g. TotalDPS
+	Objective string
+
+	// Minimum values of other output stats, e.g. a life floor
+	Constraints []OptimizerConstraint
+
+	// Passive points available for regular nodes, including already allocated ones
+	Points int
+
+	// Passive points available for nodes of the build's ascendancy, including already allocated ones
+	AscendancyPoints int
+
+	// Longest path considered in a single greedy step, defaults to 5
+	MaxStepCost int
+
+	// Maximum amount of local improvement rounds after the greedy search, defaults to 10
+	ImprovementRounds int
+
+	// Amount of parallel calculations, GOMAXPROCS if zero
+	Workers int
+}
+
+type OptimizerStep struct {
+	Added     []int64
+	Removed   []int64
+	Objective float64
+	Feasible  bool
+}
+
+type OptimizerResult struct {
+	Nodes []int64
+
+	// Selected effect of every allocated mastery by node ID
+	MasteryEffects map[int64]int64
+
+	Objective  float64
+	Feasible   bool
+	Trajectory []OptimizerStep
+}
+
+// crystalline:promise
+func (c *Calculator) OptimizeTree(options OptimizerOptions) (*OptimizerResult, error) {
+	return OptimizeTree(context.Background(), c.PoB, options)
+}
+
+type treeOptimizer struct {
+	build       *pob.PathOfBuilding
+	options     OptimizerOptions
+	treeVersion *data.TreeVersionData
+	tree        *data.Tree
+	roots       []int64
+	extensions  []data.GraphExtension
+	candidates  []int64
+	masteries   []int64
+}
+
+type optimizerCandidate struct {
+	nodes     []int64
+	masteries map[int64]int64
+	added     []int64
+	removed   []int64
+	output    map[string]float64
+}
+
+// OptimizeTree proposes a connected passive allocation for the build's class and ascendancy within the point budget.
+//
+// Starting from the current allocation, it greedily allocates the path with the best gain per point until the budget
+// is spent, followed by local improvement that swaps single nodes. While constraints are not met, paths are ranked by
+// how much they reduce the constraint deficit instead of by objective.
+//
+// Masteries are allocated together with one of their effects that isn't selected on any other mastery yet,
+// once a notable of their group is allocated.
+func OptimizeTree(ctx context.Context, build *pob.PathOfBuilding, options OptimizerOptions) (*OptimizerResult, error) {
+	if options.MaxStepCost <= 0 {
+		options.MaxStepCost = defaultOptimizerMaxStepCost
+	}
+
+	if options.ImprovementRounds <= 0 {
+		options.ImprovementRounds = defaultOptimizerImprovementRounds
+	}
+
+	if options.Workers <= 0 {
+		options.Workers = runtime.GOMAXPROCS(0)
+	}
+
+	ascendancy := data.AscendancyName(build.Build.AscendClassName)
+
+	o := &treeOptimizer{
+		build:       build,
+		options:     options,
+		treeVersion: data.TreeVersions[data.LatestTreeVersion],
+	}
+	o.tree = o.treeVersion.Tree()
+	o.roots = o.treeVersion.RootNodes(data.ClassName(build.Build.ClassName), ascendancy)
+	o.extensions = newSocketedPassiveSpec(build, data.LatestTreeVersion).GraphExtensions()
+	o.candidates = nodePowerCandidates(o.tree, nil, ascendancy)
+	o.masteries = masteryCandidates(o.tree)
+
+	nodes := slices.Clone(build.Build.PassiveNodes)
+	for _, root := range o.roots {
+		// The ascendancy start node is free
+		if start := o.node(root).IsAscendancyStart; start != nil && *start && !slices.Contains(nodes, root) {
+			nodes = append(nodes, root)
+		}
+	}
+
+	regular, ascendancyPoints := o.points(nodes)
+	if regular > options.Points || ascendancyPoints > options.AscendancyPoints {
+		return nil, errors.New("current allocation exceeds the point budget")
+	}
+
+	masteries := make(map[int64]int64, len(build.Build.MasteryEffects))
+	for id, effect := range build.Build.MasteryEffects {
+		if slices.Contains(nodes, id) {
+			masteries[id] = effect
+		}
+	}
+
+	// Evaluating the current allocation first also warms up all shared caches before going parallel
+	current := evaluateAllocation(build, nodes, masteries)
+	result := &OptimizerResult{}
+
+	warmNodeMods(o.tree, o.candidates)
+
+	// Mastery effects are parsed as they are selected
+	defer envCache.saveNodeMods()
+
+	// Greedy search
+	for {
+		moves := o.moves(nodes, masteries, options.MaxStepCost)
+		if len(moves) == 0 {
+			break
+		}
+
+		best, err := o.best(ctx, current, moves)
+		if err != nil {
+			return nil, err
+		}
+
+		if best == nil {
+			break
+		}
+
+		nodes, masteries, current = best.nodes, best.masteries, best.output
+		result.Trajectory = append(result.Trajectory, o.step(best))
+	}
+
+	// Local improvement
+	for round := 0; round < options.ImprovementRounds; round++ {
+		swap, err := o.improve(ctx, nodes, masteries, current)
+		if err != nil {
+			return nil, err
+		}
+
+		if swap == nil {
+			break
+		}
+
+		nodes, masteries, current = swap.nodes, swap.masteries, swap.output
+		result.Trajectory = append(result.Trajectory, o.step(swap))
+	}
+
+	slices.Sort(nodes)
+	result.Nodes = nodes
+	result.MasteryEffects = masteries
+	result.Objective = current[options.Objective]
+	result.Feasible = o.deficit(current) == 0
+
+	return result, nil
+}
+
+// masteryCandidates returns all masteries of the tree that have effects to select
+func masteryCandidates(tree *data.Tree) []int64 {
+	masteries := make([]int64, 0)
+	for _, node := range tree.Nodes {
+		if node.Skill != nil && node.IsMastery != nil && *node.IsMastery && len(node.MasteryEffects) > 0 {
+			masteries = append(masteries, *node.Skill)
+		}
+	}
+
+	slices.Sort(masteries)
+	return masteries
+}
+
+func (o *treeOptimizer) node(id int64) data.Node {
+	return o.tree.Nodes[strconv.FormatInt(id, 10)]
+}
+
+// points returns the amount of regular and ascendancy points spent on the nodes
+func (o *treeOptimizer) points(nodes []int64) (int, int) {
+	regular, ascendancy := 0, 0
+	for _, id := range nodes {
+		node := o.node(id)
+		switch {
+		case node.ClassStartIndex != nil, node.IsAscendancyStart != nil && *node.IsAscendancyStart:
+		case node.AscendancyName != nil:
+			ascendancy++
+		default:
+			regular++
+		}
+	}
+	return regular, ascendancy
+}
+
+// moves returns all allocations that are reachable from the current one with a path of at most maxCost points.
+// Masteries are added once for every effect that can still be selected.
+func (o *treeOptimizer) moves(nodes []int64, masteries map[int64]int64, maxCost int) []*optimizerCandidate {
+	allocated := make(map[int64]bool, len(nodes))
+	for _, id := range nodes {
+		allocated[id] = true
+	}
+
+	regular, ascendancy := o.points(nodes)
+	paths := o.treeVersion.CalculateAllocationPaths(nodes, o.roots, o.extensions...)
+
+	moves := make([]*optimizerCandidate, 0)
+	for _, candidate := range o.candidates {
+		if allocated[candidate] {
+			continue
+		}
+
+		path := allocationPath(paths, allocated, candidate)
+		if len(path) == 0 || len(path) > maxCost {
+			continue
+		}
+
+		pathRegular, pathAscendancy := o.points(path)
+		if regular+pathRegular > o.options.Points || ascendancy+pathAscendancy > o.options.AscendancyPoints {
+			continue
+		}
+
+		moves = append(moves, &optimizerCandidate{
+			nodes:     append(slices.Clip(nodes), path...),
+			masteries: masteries,
+			added:     path,
+		})
+	}
+
+	selected := make(map[int64]bool, len(masteries))
+	for _, effect := range masteries {
+		selected[effect] = true
+	}
+
+	for _, mastery := range o.masteries {
+		if allocated[mastery] {
+			continue
+		}
+
+		path := allocationPath(paths, allocated, mastery)
+		if len(path) == 0 || len(path) > maxCost {
+			continue
+		}
+
+		pathRegular, _ := o.points(path)
+		if regular+pathRegular > o.options.Points {
+			continue
+		}
+
+		for _, masteryEffect := range o.node(mastery).MasteryEffects {
+			if selected[masteryEffect.Effect] {
+				continue
+			}
+
+			withEffect := maps.Clone(masteries)
+			withEffect[mastery] = masteryEffect.Effect
+
+			moves = append(moves, &optimizerCandidate{
+				nodes:     append(slices.Clip(nodes), path...),
+				masteries: withEffect,
+				added:     path,
+			})
+		}
+	}
+
+	return moves
+}
+
+// best evaluates all candidates and returns the one with the best gain per point over the current output,
+// or nil if none of them is an improvement
+func (o *treeOptimizer) best(ctx context.Context, current map[string]float64, candidates []*optimizerCandidate) (*optimizerCandidate, error) {
+	if err := o.evaluate(ctx, candidates); err != nil {
+		return nil, err
+	}
+
+	currentDeficit := o.deficit(current)
+
+	var best *optimizerCandidate
+	bestDeficitGain, bestObjectiveGain := 0.0, 0.0
+	for _, candidate := range candidates {
+		cost := float64(max(len(candidate.added)-len(candidate.removed), 1))
+		deficitGain := (currentDeficit - o.deficit(candidate.output)) / cost
+		objectiveGain := (candidate.output[o.options.Objective] - current[o.options.Objective]) / cost
+
+		if currentDeficit == 0 {
+			// Never trade feasibility for objective
+			if o.deficit(candidate.output) > 0 || objectiveGain <= bestObjectiveGain {
+				continue
+			}
+		} else if deficitGain < bestDeficitGain || (deficitGain == bestDeficitGain && objectiveGain <= bestObjectiveGain) {
+			continue
+		}
+
+		best, bestDeficitGain, bestObjectiveGain = candidate, deficitGain, objectiveGain
+	}
+
+	return best, nil
+}
+
+// improve tries to swap the least valuable removable node with a better single node
+func (o *treeOptimizer) improve(ctx context.Context, nodes []int64, masteries map[int64]int64, current map[string]float64) (*optimizerCandidate, error) {
+	removals := make([]*optimizerCandidate, 0)
+	for i, id := range nodes {
+		node := o.node(id)
+		if node.ClassStartIndex != nil || (node.IsAscendancyStart != nil && *node.IsAscendancyStart) {
+			continue
+		}
+
+		remaining := slices.Delete(slices.Clone(nodes), i, i+1)
+		if len(o.treeVersion.DisconnectedNodes(remaining, o.roots, o.extensions...)) > 0 {
+			continue
+		}
+
+		remainingMasteries := masteries
+		if _, ok := masteries[id]; ok {
+			remainingMasteries = maps.Clone(masteries)
+			delete(remainingMasteries, id)
+		}
+
+		removals = append(removals, &optimizerCandidate{
+			nodes:     remaining,
+			masteries: remainingMasteries,
+			removed:   []int64{id},
+		})
+	}
+
+	if err := o.evaluate(ctx, removals); err != nil {
+		return nil, err
+	}
+
+	var cheapest *optimizerCandidate
+	for _, removal := range removals {
+		if o.deficit(removal.output) > o.deficit(current) {
+			continue
+		}
+
+		if cheapest == nil || removal.output[o.options.Objective] > cheapest.output[o.options.Objective] {
+			cheapest = removal
+		}
+	}
+
+	if cheapest == nil {
+		return nil, nil
+	}
+
+	swaps := make([]*optimizerCandidate, 0)
+	for _, move := range o.moves(cheapest.nodes, cheapest.masteries, 1) {
+		if slices.Equal(move.added, cheapest.removed) {
+			continue
+		}
+
+		move.removed = cheapest.removed
+		swaps = append(swaps, move)
+	}
+
+	return o.best(ctx, current, swaps)
+}
+
+// deficit returns the sum of relative shortfalls of all constraints
+func (o *treeOptimizer) deficit(output map[string]float64) float64 {
+	deficit := 0.0
+	for _, constraint := range o.options.Constraints {
+		if value := output[constraint.Stat]; value < constraint.Min {
+			deficit += (constraint.Min - value) / math.Max(math.Abs(constraint.Min), 1)
+		}
+	}
+	return deficit
+}
+
+func (o *treeOptimizer) step(candidate *optimizerCandidate) OptimizerStep {
+	return OptimizerStep{
+		Added:     candidate.added,
+		Removed:   candidate.removed,
+		Objective: candidate.output[o.options.Objective],
+		Feasible:  o.deficit(candidate.output) == 0,
+	}
+}
+
+// evaluate calculates the output of all candidates in parallel
+func (o *treeOptimizer) evaluate(ctx context.Context, candidates []*optimizerCandidate) error {
+	indices := make(chan int)
+
+	var wg sync.WaitGroup
+	for i := 0; i < o.options.Workers; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for index := range indices {
+				candidates[index].output = evaluateAllocation(o.build, candidates[index].nodes, candidates[index].masteries)
+			}
+		}()
+	}
+
+	var err error
+	for i := range candidates {
+		if err = ctx.Err(); err != nil {
+			break
+		}
+		indices <- i
+	}
+
+	close(indices)
+	wg.Wait()
+
+	if err != nil {
+		return fmt.Errorf("tree optimization cancelled: %w", err)
+	}
+
+	return nil
+}
@@ -0,0 +1,140 @@
+package calculator
+
+import (
+	"context"
+	"os"
+	"testing"
+
+	"github.com/MarvinJWendt/testza"
+
+	"github.com/Vilsol/go-pob/builds"
+	"github.com/Vilsol/go-pob/data"
+)
+
+func TestOptimizeTree(t *testing.T) {
+	file, err := os.ReadFile("../testdata/builds/Fireball.xml")
+	testza.AssertNoError(t, err)
+
+	build, err := builds.ParseBuild(file)
+	testza.AssertNoError(t, err)
+
+	treeVersion := data.TreeVersions[data.LatestTreeVersion]
+	roots := treeVersion.RootNodes(data.ClassName(build.Build.ClassName), data.AscendancyName(build.Build.AscendClassName))
+	base := evaluateOutput(build, build.Build.PassiveNodes)
+
+	tests := []struct {
+		name        string
+		constraints []OptimizerConstraint
+	}{
+		{
+			name: "Unconstrained",
+		},
+		{
+			name:        "DexterityFloor",
+			constraints: []OptimizerConstraint{{Stat: "Dex", Min: base["Dex"] + 10}},
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			result, err := OptimizeTree(context.Background(), build, OptimizerOptions{
+				Objective:         "Str",
+				Constraints:       test.constraints,
+				Points:            4,
+				MaxStepCost:       4,
+				ImprovementRounds: 1,
+			})
+			testza.AssertNoError(t, err)
+
+			testza.AssertTrue(t, result.Feasible)
+			testza.AssertGreater(t, result.Objective, base["Str"])
+			testza.AssertLen(t, result.Nodes, len(build.Build.PassiveNodes)+4)
+			testza.AssertLen(t, treeVersion.DisconnectedNodes(result.Nodes, roots), 0)
+			testza.AssertNotZero(t, len(result.Trajectory))
+
+			for i := 1; i < len(result.Trajectory); i++ {
+				if result.Trajectory[i-1].Feasible {
+					testza.AssertTrue(t, result.Trajectory[i].Objective >= result.Trajectory[i-1].Objective)
+				}
+			}
+
+			final := evaluateOutput(build, result.Nodes)
+			testza.AssertEqual(t, result.Objective, final["Str"])
+			for _, constraint := range test.constraints {
+				testza.AssertTrue(t, final[constraint.Stat] >= constraint.Min)
+			}
+		})
+	}
+}
+
+func TestOptimizeTreeBudget(t *testing.T) {
+	file, err := os.ReadFile("../testdata/builds/Fireball.xml")
+	testza.AssertNoError(t, err)
+
+	treeVersion := data.TreeVersions[data.LatestTreeVersion]
+	o := &treeOptimizer{tree: treeVersion.Tree()}
+
+	tests := []struct {
+		name   string
+		extra  []int64
+		points int
+		err    bool
+	}{
+		{name: "SmallBudget", points: 2},
+		{name: "LargerBudget", points: 5},
+		{name: "ExceedsBudget", extra: []int64{14930}, points: 0, err: true},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			build, err := builds.ParseBuild(file)
+			testza.AssertNoError(t, err)
+
+			build.Build.PassiveNodes = append(build.Build.PassiveNodes, test.extra...)
+
+			result, err := OptimizeTree(context.Background(), build, OptimizerOptions{
+				Objective:         "Int",
+				Points:            test.points,
+				MaxStepCost:       3,
+				ImprovementRounds: 1,
+			})
+			if test.err {
+				testza.AssertNotNil(t, err)
+				return
+			}
+			testza.AssertNoError(t, err)
+
+			regular, ascendancy := o.points(result.Nodes)
+			testza.AssertEqual(t, test.points, regular)
+			testza.AssertEqual(t, 0, ascendancy)
+		})
+	}
+}
+
+func TestOptimizeTreeMastery(t *testing.T) {
+	file, err := os.ReadFile("../testdata/builds/Fireball.xml")
+	testza.AssertNoError(t, err)
+
+	build, err := builds.ParseBuild(file)
+	testza.AssertNoError(t, err)
+
+	// Path up to a notable of the Physical Mastery group
+	build.Build.PassiveNodes = append(build.Build.PassiveNodes, 28878, 59306, 34207, 16775, 63976, 6204, 48423, 37690, 2151)
+
+	result, err := OptimizeTree(context.Background(), build, OptimizerOptions{
+		Objective:         "TotalDPS",
+		Points:            10,
+		MaxStepCost:       1,
+		ImprovementRounds: 1,
+	})
+	testza.AssertNoError(t, err)
+
+	// 40% increased Physical Damage with Skills that Cost Life
+	testza.AssertContains(t, result.Nodes, int64(12239))
+	testza.AssertEqual(t, map[int64]int64{12239: 18859}, result.MasteryEffects)
+
+	masteryEffects := build.Build.MasteryEffects
+	build.Build.MasteryEffects = result.MasteryEffects
+	testza.AssertEqual(t, result.Objective, evaluateOutput(build, result.Nodes)["TotalDPS"])
+	build.Build.MasteryEffects = masteryEffects
+}
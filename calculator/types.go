@@ -1,6 +1,8 @@
 package calculator
 
 import (
+	"sync"
+
 	"github.com/Vilsol/go-pob-data/poe"
 	"github.com/Vilsol/go-pob/data"
 	"github.com/Vilsol/go-pob/data/raw"
@@ -54,8 +56,8 @@ type Environment struct {
 	RequirementsTableItems map[string]interface{}   // TODO Implement
 	RequirementsTableGems  []*RequirementsTableGems // TODO Implement
 
-	RadiusJewelList     map[string]interface{} // TODO Implement
-	ExtraRadiusNodeList map[string]interface{} // TODO Implement
+	RadiusJewelList     []*RadiusJewel
+	ExtraRadiusNodeList map[string]data.Node   // Unallocated nodes in radius of jewels that modify other nodes
 	GrantedSkills       map[string]interface{} // TODO Implement
 	GrantedSkillsNodes  map[string]interface{} // TODO Implement
 	GrantedSkillsItems  map[string]interface{} // TODO Implement
@@ -77,22 +79,25 @@ type Environment struct {
 }
 
 type EnvironmentCache struct {
-	TreeVersion  data.TreeVersion
-	modsForNodes map[string]moddb.ModList // Mods for all nodes cached after being parsed
+	mu sync.Mutex // Guards the node mod table, as calculations may run in parallel
+
+	TreeVersion       data.TreeVersion
+	modsForNodes      map[string]moddb.ModList // Mods for all nodes cached after being parsed
+	modsForNodesDirty bool                     // Whether modsForNodes has entries not yet persisted to disk
 }
 
 type Actor struct {
 	ModDB           *moddb.ModDB
 	Level           int
-	Enemy           *Actor                 `json:"-"`
-	ItemList        map[string]interface{} // TODO Implement
+	Enemy           *Actor `json:"-"`
+	ItemList        map[string]*EquippedItem
 	ActiveSkillList []*ActiveSkill
 	Output          map[string]float64
 	OutputTable     map[OutTable]map[string]float64
-	MainSkill       *ActiveSkill           // TODO Implement
-	Breakdown       interface{}            // TODO Implement
-	WeaponData1     map[string]interface{} // TODO Implement. Might be SomeSource?
-	WeaponData2     map[string]interface{} // TODO Implement. Might be SomeSource?
+	MainSkill       *ActiveSkill // TODO Implement
+	Breakdown       interface{}  // TODO Implement
+	WeaponData1     map[string]interface{}
+	WeaponData2     map[string]interface{}
 	StrDmgBonus     float64
 }
 
@@ -149,6 +154,10 @@ type ActiveSkill struct {
 	MinionSkillTypes map[data.SkillType]bool
 	BleedCfg         *moddb.ListCfg
 	OHBleedCfg       *moddb.ListCfg
+
+	// Selected part of multi-part skills, 1-based
+	SkillPart     int
+	SkillPartName string
 }
 
 type ConversionTable struct {
@@ -188,6 +197,7 @@ const (
 	SkillFlagBleed            = SkillFlag("bleed")
 	SkillFlagDuration         = SkillFlag("duration")
 	SkillFlagIgniteCanStack   = SkillFlag("igniteCanStack")
+	SkillFlagMultiPart        = SkillFlag("multiPart")
 )
 
 type SkillData struct {
@@ -210,16 +220,22 @@ type SkillData struct {
 }
 
 type GrantedEffect struct {
-	Raw        *poe.GrantedEffect
-	Parts      []interface{}
-	SkillTypes map[data.SkillType]bool
-	BaseFlags  map[SkillFlag]bool
+	Raw              *poe.GrantedEffect
+	Parts            []raw.SkillPart
+	SkillTypes       map[data.SkillType]bool
+	MinionSkillTypes map[data.SkillType]bool
+	BaseFlags        map[SkillFlag]bool
 }
 
+// WeaponTypes returns the weapon types the effect is restricted to, or nil if it has no restrictions
 func (g *GrantedEffect) WeaponTypes() []data.ItemClassName {
+	if len(g.Raw.WeaponRestrictions) == 0 {
+		return nil
+	}
+
 	out := make([]data.ItemClassName, len(g.Raw.WeaponRestrictions))
 	for i, restriction := range g.Raw.WeaponRestrictions {
-		out[i] = data.ItemClassName(poe.ItemClasses[restriction].Name)
+		out[i] = weaponClassName(poe.ItemClasses[restriction].ID)
 	}
 	return out
 }
@@ -268,3 +284,43 @@ type GemEffect struct {
 	IsSupporting map[*pob.Gem]bool
 	Values       map[string]float64
 }
+
+type JewelFuncType string
+
+const (
+	JewelFuncTypeOther       = JewelFuncType("Other")       // Modifies nodes in radius
+	JewelFuncTypeSelf        = JewelFuncType("Self")        // Modifies the jewel based on allocated nodes in radius
+	JewelFuncTypeSelfUnalloc = JewelFuncType("SelfUnalloc") // Modifies the jewel based on unallocated nodes in radius
+	JewelFuncTypeThreshold   = JewelFuncType("Threshold")   // Modifies the jewel based on attributes in radius
+)
+
+// JewelFuncNode is a node in radius of a jewel along with the mods it originally grants
+type JewelFuncNode struct {
+	ID string
+	data.Node
+	ModList *moddb.ModList
+}
+
+// JewelFuncData holds the state of a single radius jewel during mod list building
+type JewelFuncData struct {
+	ModSource mod.Source
+	Stats     map[string]float64
+	Total     float64
+	ModList   *moddb.ModList
+}
+
+// JewelFuncHandler is called with every node in radius, and once with a nil node to finalise the jewel
+type JewelFuncHandler func(node *JewelFuncNode, out *moddb.ModList, data *JewelFuncData)
+
+type JewelFunc struct {
+	Type JewelFuncType
+	Func JewelFuncHandler
+}
+
+type RadiusJewel struct {
+	NodeID string
+	Nodes  map[string]bool
+	Type   JewelFuncType
+	Func   JewelFuncHandler
+	Data   *JewelFuncData
+}
@@ -0,0 +1,194 @@
+package data
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"slices"
+	"strconv"
+	"strings"
+
+	"github.com/Vilsol/go-pob-data/loader"
+	"github.com/Vilsol/go-pob-data/poe"
+	"github.com/Vilsol/go-pob-data/raw"
+
+	"github.com/Vilsol/go-pob/cache"
+)
+
+type ClusterJewelSize int
+
+const (
+	ClusterJewelSizeSmall  = ClusterJewelSize(0)
+	ClusterJewelSizeMedium = ClusterJewelSize(1)
+	ClusterJewelSizeLarge  = ClusterJewelSize(2)
+)
+
+// ClusterJewelSkillPrefix is the prefix of the enchant lines that select the skill of the small passives
+const ClusterJewelSkillPrefix = "Added Small Passive Skills grant: "
+
+type ClusterJewelSkill struct {
+	// Tag of the skill, e.g. affliction_fire_damage
+	ID      string
+	Name    string
+	Icon    *string
+	Stats   []string
+	Enchant []string
+}
+
+type ClusterJewel struct {
+	Size           ClusterJewelSize
+	BaseName       string
+	MinNodes       int
+	MaxNodes       int
+	SmallIndices   []int
+	NotableIndices []int
+	SocketIndices  []int
+	TotalIndices   int
+	Skills         map[string]*ClusterJewelSkill
+}
+
+type ClusterJewelData struct {
+	// Cluster jewels by base name
+	Jewels map[string]*ClusterJewel
+
+	// Notables and keystones that can be added by cluster jewels, by name
+	Notables  map[string]Node
+	Keystones map[string]Node
+
+	// Order in which notables are placed on the jewel
+	NotableSortOrder map[string]int
+
+	// Skill IDs, notable and keystone names by the lowercase item text adding them. The lines of multi-line skill
+	// enchants are joined by spaces.
+	SkillLines    map[string]string
+	NotableLines  map[string]string
+	KeystoneLines map[string]string
+}
+
+// JewelBySize returns the cluster jewel of the provided size
+func (d *ClusterJewelData) JewelBySize(size ClusterJewelSize) *ClusterJewel {
+	for _, jewel := range d.Jewels {
+		if jewel.Size == size {
+			return jewel
+		}
+	}
+	return nil
+}
+
+// ClusterJewels returns the cluster jewel bases, skills and notables of the tree version.
+//
+// Requires the game data to be initialized, failures are not cached.
+func (v *TreeVersionData) ClusterJewels() (*ClusterJewelData, error) {
+	v.clusterJewelsMu.Lock()
+	defer v.clusterJewelsMu.Unlock()
+
+	if v.cachedClusterJewels != nil {
+		return v.cachedClusterJewels, nil
+	}
+
+	clusterJewels, err := v.loadClusterJewels(context.Background())
+	if err != nil {
+		return nil, err
+	}
+
+	v.cachedClusterJewels = clusterJewels
+	return clusterJewels, nil
+}
+
+func (v *TreeVersionData) loadClusterJewels(ctx context.Context) (*ClusterJewelData, error) {
+	if len(poe.PassiveTreeExpansionJewels) == 0 {
+		return nil, errors.New("game data is not initialized")
+	}
+
+	// Passive skills aren't part of the regular game data, but are required to find the tree nodes of the cluster skills
+	passiveSkills, err := loader.LoadRaw[*raw.PassiveSkill](ctx, v.Display, "PassiveSkills", nil, cache.Disk())
+	if err != nil {
+		return nil, fmt.Errorf("failed to load passive skills: %w", err)
+	}
+
+	tree := v.Tree()
+	passiveNodes := make(map[int]Node, len(passiveSkills))
+	for _, passive := range passiveSkills {
+		if node, ok := tree.Nodes[strconv.FormatInt(passive.Hash, 10)]; ok {
+			passiveNodes[int(passive.Key)] = node
+		}
+	}
+
+	clusterJewels := &ClusterJewelData{
+		Jewels:           make(map[string]*ClusterJewel),
+		Notables:         make(map[string]Node),
+		Keystones:        make(map[string]Node),
+		NotableSortOrder: make(map[string]int),
+		SkillLines:       make(map[string]string),
+		NotableLines:     make(map[string]string),
+		KeystoneLines:    make(map[string]string),
+	}
+
+	bySize := make(map[int]*ClusterJewel)
+	for _, jewel := range poe.PassiveTreeExpansionJewels {
+		if jewel.BaseItemTypesKey < 0 || jewel.BaseItemTypesKey >= len(poe.BaseItemTypes) {
+			continue
+		}
+
+		clusterJewel := &ClusterJewel{
+			Size:           ClusterJewelSize(jewel.PassiveTreeExpansionJewelSizesKey),
+			BaseName:       poe.BaseItemTypes[jewel.BaseItemTypesKey].Name,
+			MinNodes:       jewel.MinNodes,
+			MaxNodes:       jewel.MaxNodes,
+			SmallIndices:   jewel.SmallIndices,
+			NotableIndices: jewel.NotableIndices,
+			SocketIndices:  jewel.SocketIndices,
+			TotalIndices:   jewel.TotalIndices,
+			Skills:         make(map[string]*ClusterJewelSkill),
+		}
+
+		clusterJewels.Jewels[clusterJewel.BaseName] = clusterJewel
+		bySize[jewel.PassiveTreeExpansionJewelSizesKey] = clusterJewel
+	}
+
+	for _, skill := range poe.PassiveTreeExpansionSkills {
+		jewel, ok := bySize[skill.PassiveTreeExpansionJewelSizesKey]
+		if !ok || skill.TagsKey < 0 || skill.TagsKey >= len(poe.Tags) {
+			continue
+		}
+
+		node, ok := passiveNodes[skill.PassiveSkillsKey]
+		if !ok || node.Name == nil {
+			continue
+		}
+
+		enchant := make([]string, len(node.Stats))
+		for i, stat := range node.Stats {
+			enchant[i] = ClusterJewelSkillPrefix + stat
+		}
+
+		id := poe.Tags[skill.TagsKey].ID
+		jewel.Skills[id] = &ClusterJewelSkill{
+			ID:      id,
+			Name:    *node.Name,
+			Icon:    node.Icon,
+			Stats:   slices.Clone(node.Stats),
+			Enchant: enchant,
+		}
+		clusterJewels.SkillLines[strings.ToLower(strings.Join(enchant, " "))] = id
+	}
+
+	for _, special := range poe.PassiveTreeExpansionSpecialSkills {
+		node, ok := passiveNodes[special.PassiveSkillsKey]
+		if !ok || node.Name == nil {
+			continue
+		}
+
+		if node.IsKeystone != nil && *node.IsKeystone {
+			clusterJewels.Keystones[*node.Name] = node
+			clusterJewels.KeystoneLines["adds "+strings.ToLower(*node.Name)] = *node.Name
+			continue
+		}
+
+		clusterJewels.Notables[*node.Name] = node
+		clusterJewels.NotableLines["1 added passive skill is "+strings.ToLower(*node.Name)] = *node.Name
+		clusterJewels.NotableSortOrder[*node.Name] = special.StatsKey
+	}
+
+	return clusterJewels, nil
+}
@@ -403,6 +403,7 @@ const (
 	OneHandAxe            ItemClassName = "One Hand Axe"
 	OneHandMace           ItemClassName = "One Hand Mace"
 	OneHandSword          ItemClassName = "One Hand Sword"
+	Quiver                ItemClassName = "Quiver"
 	RuneDagger            ItemClassName = "Rune Dagger"
 	Sceptre               ItemClassName = "Sceptre"
 	Shield                ItemClassName = "Shield"
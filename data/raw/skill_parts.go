@@ -0,0 +1,59 @@
+package raw
+
+import (
+	"github.com/Vilsol/go-pob/mod"
+)
+
+// SkillPart is one of the ways a multi-part skill deals damage. Mods that only apply to a part are tagged with
+// mod.SkillPart using the 1-based index of the part.
+type SkillPart struct {
+	Name string
+
+	// Skill flags the part enables (true) or disables (false)
+	Flags map[string]bool
+}
+
+// SkillParts holds the parts of multi-part skills, keyed by granted effect ID
+var SkillParts = map[string][]SkillPart{
+	"ChargedAttack": {
+		{Name: "1 Stage"},
+		{Name: "6 Stages"},
+		{Name: "Release at 6 Stages"},
+	},
+	"Cyclone": {
+		{Name: "First Hit"},
+		{Name: "Channelling"},
+	},
+	"IceSpear": {
+		{Name: "First Form"},
+		{Name: "Second Form"},
+	},
+	"ShockNova": {
+		{Name: "Ring"},
+		{Name: "Nova"},
+	},
+	"ShieldCrush": {
+		{Name: "Side Waves"},
+		{Name: "Central Wave"},
+	},
+	"ShrapnelShot": {
+		{Name: "Arrow"},
+		{Name: "Cone", Flags: map[string]bool{"area": true}},
+	},
+	"WildStrike": {
+		{Name: "Fire Hit"},
+		{Name: "Fire Explosion", Flags: map[string]bool{"area": true, "melee": false}},
+		{Name: "Lightning Hit"},
+		{Name: "Lightning Beam", Flags: map[string]bool{"projectile": true, "chaining": true, "melee": false}},
+		{Name: "Cold Hit"},
+		{Name: "Icy Wave", Flags: map[string]bool{"projectile": true, "melee": false}},
+	},
+}
+
+// SkillBaseMods holds the mods skills have regardless of their level, keyed by granted effect ID
+var SkillBaseMods = map[string][]mod.Mod{
+	"ChargedAttack": {
+		mod.NewFloat("Multiplier:BladeFlurryStage", mod.TypeBase, 1).Tag(mod.SkillPart(1)),
+		mod.NewFloat("Multiplier:BladeFlurryStage", mod.TypeBase, 6).Tag(mod.SkillPart(2, 3)),
+	},
+}
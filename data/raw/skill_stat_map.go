@@ -266,7 +266,7 @@ var SkillStatMap = map[string]*StatMap{
 		Div:  utils.Ptr(float64(100)),
 	},
 	"deal_chaos_damage_per_second_for_10_seconds_on_hit": {
-		Mods: []mod.Mod{mod.NewList("SkillData", mod.SkillData{
+		Mods: []mod.Mod{mod.NewList("SkillData", &mod.SkillData{
 			Key:   "decay",
 			Value: 0,
 			Merge: "MAX",
@@ -1776,6 +1776,32 @@ var SkillStatMap = map[string]*StatMap{
 	"supported_elemental_skill_gem_level_+": {
 		Mods: []mod.Mod{mod.NewList("SupportedGemProperty", mod.SupportedGemProperty{Keyword: "active_skill", Key: "level", Value: 0}).KeywordFlag(mod.KeywordFlagLightning | mod.KeywordFlagCold | mod.KeywordFlagFire)},
 	},
+	// Skill parts
+	"charged_attack_damage_per_stack_+%_final": {
+		Mods: []mod.Mod{mod.NewFloat("Damage", "MORE", 0).Tag(mod.Multiplier("BladeFlurryStage"))},
+	},
+	"cyclone_first_hit_damage_+%_final": {
+		Mods: []mod.Mod{mod.NewFloat("Damage", "MORE", 0).Tag(mod.SkillPart(1))},
+	},
+	"ice_spear_second_form_critical_strike_chance_+%": {
+		Mods: []mod.Mod{mod.NewFloat("CritChance", "INC", 0).Tag(mod.SkillPart(2))},
+	},
+	"ice_spear_second_form_critical_strike_multiplier_+": {
+		Mods: []mod.Mod{mod.NewFloat("CritMultiplier", "BASE", 0).Tag(mod.SkillPart(2))},
+	},
+	"ice_spear_second_form_projectile_speed_+%_final": {
+		Mods: []mod.Mod{mod.NewFloat("ProjectileSpeed", "MORE", 0).Tag(mod.SkillPart(2))},
+	},
+	"newshocknova_first_ring_damage_+%_final": {
+		Mods: []mod.Mod{mod.NewFloat("Damage", "MORE", 0).Tag(mod.SkillPart(1))},
+	},
+	"elemental_strike_physical_damage_%_to_convert": {
+		Mods: []mod.Mod{
+			mod.NewFloat("SkillPhysicalDamageConvertToFire", "BASE", 0).Tag(mod.SkillPart(1, 2)),
+			mod.NewFloat("SkillPhysicalDamageConvertToLightning", "BASE", 0).Tag(mod.SkillPart(3, 4)),
+			mod.NewFloat("SkillPhysicalDamageConvertToCold", "BASE", 0).Tag(mod.SkillPart(5, 6)),
+		},
+	},
 	//Minion
 	"supported_minion_skill_gem_level_+": {
 		Mods: []mod.Mod{mod.NewList("SupportedGemProperty", mod.SupportedGemProperty{Keyword: "active_skill", Key: "level", Value: 0}).Tag(mod.SkillType("Minion"))},
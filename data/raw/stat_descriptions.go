@@ -0,0 +1,281 @@
+package raw
+
+import (
+	"context"
+	"fmt"
+	"math"
+	"path"
+	"regexp"
+	"slices"
+	"strconv"
+	"strings"
+	"sync"
+
+	"github.com/Vilsol/go-pob-data/loader"
+	poeraw "github.com/Vilsol/go-pob-data/raw"
+
+	"github.com/Vilsol/go-pob/cache"
+)
+
+const (
+	StatDescriptionsDefault = "stat_descriptions"
+	StatDescriptionsPassive = "passive_skill_stat_descriptions"
+	StatDescriptionsAura    = "passive_skill_aura_stat_descriptions"
+)
+
+// Placeholders look like {0}, {0:+d} or {} for the next stat
+var statPlaceholderRegex = regexp.MustCompile(`\{(\d*)(?::(\+?)d)?\}`)
+
+// Handlers converting the internal stat value to the displayed one, and the amount of decimals to display
+var statValueHandlers = map[string]struct {
+	convert  func(value float64) float64
+	decimals int
+}{
+	"negate":                                   {func(v float64) float64 { return -v }, -1},
+	"negate_and_double":                        {func(v float64) float64 { return -v * 2 }, -1},
+	"double":                                   {func(v float64) float64 { return v * 2 }, -1},
+	"multiply_by_four":                         {func(v float64) float64 { return v * 4 }, -1},
+	"times_twenty":                             {func(v float64) float64 { return v * 20 }, -1},
+	"times_one_point_five":                     {func(v float64) float64 { return v * 1.5 }, -1},
+	"30%_of_value":                             {func(v float64) float64 { return v * 0.3 }, -1},
+	"60%_of_value":                             {func(v float64) float64 { return v * 0.6 }, -1},
+	"multiplicative_damage_modifier":           {func(v float64) float64 { return v + 100 }, -1},
+	"old_leech_percent":                        {func(v float64) float64 { return v / 5 }, -1},
+	"old_leech_permyriad":                      {func(v float64) float64 { return v / 50 }, -1},
+	"divide_by_two_0dp":                        {func(v float64) float64 { return v / 2 }, 0},
+	"divide_by_three":                          {func(v float64) float64 { return v / 3 }, -1},
+	"divide_by_four":                           {func(v float64) float64 { return v / 4 }, -1},
+	"divide_by_five":                           {func(v float64) float64 { return v / 5 }, -1},
+	"divide_by_six":                            {func(v float64) float64 { return v / 6 }, -1},
+	"divide_by_ten_0dp":                        {func(v float64) float64 { return v / 10 }, 0},
+	"divide_by_ten_1dp":                        {func(v float64) float64 { return v / 10 }, 1},
+	"divide_by_ten_1dp_if_required":            {func(v float64) float64 { return v / 10 }, 1},
+	"divide_by_twelve":                         {func(v float64) float64 { return v / 12 }, -1},
+	"divide_by_fifteen_0dp":                    {func(v float64) float64 { return v / 15 }, 0},
+	"divide_by_twenty_then_double_0dp":         {func(v float64) float64 { return math.Floor(v/20) * 2 }, 0},
+	"divide_by_fifty":                          {func(v float64) float64 { return v / 50 }, -1},
+	"divide_by_one_hundred":                    {func(v float64) float64 { return v / 100 }, -1},
+	"divide_by_one_hundred_2dp":                {func(v float64) float64 { return v / 100 }, 2},
+	"divide_by_one_hundred_2dp_if_required":    {func(v float64) float64 { return v / 100 }, 2},
+	"divide_by_one_hundred_and_negate":         {func(v float64) float64 { return -v / 100 }, -1},
+	"divide_by_one_thousand":                   {func(v float64) float64 { return v / 1000 }, -1},
+	"deciseconds_to_seconds":                   {func(v float64) float64 { return v / 10 }, -1},
+	"milliseconds_to_seconds":                  {func(v float64) float64 { return v / 1000 }, -1},
+	"milliseconds_to_seconds_0dp":              {func(v float64) float64 { return v / 1000 }, 0},
+	"milliseconds_to_seconds_1dp":              {func(v float64) float64 { return v / 1000 }, 1},
+	"milliseconds_to_seconds_2dp":              {func(v float64) float64 { return v / 1000 }, 2},
+	"milliseconds_to_seconds_2dp_if_required":  {func(v float64) float64 { return v / 1000 }, 2},
+	"per_minute_to_per_second":                 {func(v float64) float64 { return v / 60 }, 1},
+	"per_minute_to_per_second_0dp":             {func(v float64) float64 { return v / 60 }, 0},
+	"per_minute_to_per_second_1dp":             {func(v float64) float64 { return v / 60 }, 1},
+	"per_minute_to_per_second_2dp":             {func(v float64) float64 { return v / 60 }, 2},
+	"per_minute_to_per_second_2dp_if_required": {func(v float64) float64 { return v / 60 }, 2},
+}
+
+// StatDescriptions renders stats into the lines shown on gem and item tooltips
+type StatDescriptions struct {
+	descriptors map[string]*poeraw.StatTranslation
+	order       map[*poeraw.StatTranslation]int
+}
+
+var (
+	statDescriptionsMu sync.Mutex
+	statDescriptions   = make(map[string]*StatDescriptions)
+)
+
+// GetStatDescriptions returns the english stat descriptions of the provided description file for the latest version
+func GetStatDescriptions(name string) (*StatDescriptions, error) {
+	statDescriptionsMu.Lock()
+	defer statDescriptionsMu.Unlock()
+
+	if descriptions, ok := statDescriptions[name]; ok {
+		return descriptions, nil
+	}
+
+	descriptions := &StatDescriptions{
+		descriptors: make(map[string]*poeraw.StatTranslation),
+		order:       make(map[*poeraw.StatTranslation]int),
+	}
+
+	if err := descriptions.load(context.Background(), name); err != nil {
+		return nil, err
+	}
+
+	statDescriptions[name] = descriptions
+	return descriptions, nil
+}
+
+func (d *StatDescriptions) load(ctx context.Context, name string) error {
+	file, err := loader.LoadTranslation(ctx, LatestVersion, "en", name, cache.Disk())
+	if err != nil {
+		return fmt.Errorf("failed to load stat descriptions %s: %w", name, err)
+	}
+
+	// Descriptors of the file take precedence over included ones, and the first descriptor of a stat wins
+	for _, descriptor := range file.Descriptors {
+		if len(descriptor.List) == 0 {
+			continue
+		}
+
+		d.order[descriptor] = len(d.order)
+		for _, id := range descriptor.IDs {
+			if _, ok := d.descriptors[id]; !ok {
+				d.descriptors[id] = descriptor
+			}
+		}
+	}
+
+	// Includes are paths of the game files, e.g. Metadata/StatDescriptions/stat_descriptions.txt
+	for _, include := range file.Includes {
+		if err := d.load(ctx, strings.TrimSuffix(path.Base(include), ".txt")); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// Describe returns the description lines of the stats in the order of the description file. Stats without a
+// description are omitted.
+func (d *StatDescriptions) Describe(stats map[string]float64) []string {
+	descriptors := make([]*poeraw.StatTranslation, 0)
+	for id := range stats {
+		if descriptor, ok := d.descriptors[id]; ok && !slices.Contains(descriptors, descriptor) {
+			descriptors = append(descriptors, descriptor)
+		}
+	}
+
+	slices.SortFunc(descriptors, func(a, b *poeraw.StatTranslation) int {
+		return d.order[a] - d.order[b]
+	})
+
+	lines := make([]string, 0, len(descriptors))
+	for _, descriptor := range descriptors {
+		values := make([]float64, len(descriptor.IDs))
+		for i, id := range descriptor.IDs {
+			values[i] = stats[id]
+		}
+
+		lines = append(lines, describeStat(descriptor, values)...)
+	}
+
+	return lines
+}
+
+// describeStat renders the first translation of the descriptor whose conditions match the values
+func describeStat(descriptor *poeraw.StatTranslation, values []float64) []string {
+	if !slices.ContainsFunc(values, func(v float64) bool { return v != 0 }) {
+		return nil
+	}
+
+	translation := -1
+	for i, lang := range descriptor.List {
+		if matchStatConditions(lang.Conditions, values) {
+			translation = i
+			break
+		}
+	}
+
+	// Ranges between two numbers only keep one of their bounds, so they may not match anything
+	if translation < 0 {
+		translation = 0
+	}
+
+	lang := descriptor.List[translation]
+	if lang.String == "" {
+		return nil
+	}
+
+	displayed := slices.Clone(values)
+	decimals := make([]int, len(values))
+	for i := range decimals {
+		decimals[i] = -1
+	}
+
+	for handler, index := range lang.IndexHandlers {
+		h, ok := statValueHandlers[handler]
+		if !ok {
+			continue
+		}
+
+		i, err := strconv.Atoi(index)
+		if err != nil || i < 1 || i > len(values) {
+			continue
+		}
+
+		displayed[i-1] = h.convert(displayed[i-1])
+		decimals[i-1] = h.decimals
+	}
+
+	next := 0
+	text := statPlaceholderRegex.ReplaceAllStringFunc(lang.String, func(placeholder string) string {
+		match := statPlaceholderRegex.FindStringSubmatch(placeholder)
+
+		i := next
+		if match[1] != "" {
+			i, _ = strconv.Atoi(match[1])
+		}
+		next = i + 1
+
+		if i >= len(displayed) {
+			return placeholder
+		}
+
+		formatted := formatStatValue(displayed[i], decimals[i])
+		if match[2] == "+" && displayed[i] >= 0 {
+			formatted = "+" + formatted
+		}
+		return formatted
+	})
+
+	return strings.Split(strings.ReplaceAll(text, `\n`, "\n"), "\n")
+}
+
+// matchStatConditions returns whether the conditions hold for the values. Conditions without any bounds are not
+// part of the data, so every condition is matched against the remaining values in order.
+func matchStatConditions(conditions []poeraw.Condition, values []float64) bool {
+	if len(conditions) == 0 {
+		return true
+	}
+
+	for i := 0; i <= len(values)-len(conditions); i++ {
+		if matchStatCondition(conditions[0], values[i]) && matchStatConditions(conditions[1:], values[i+1:]) {
+			return true
+		}
+	}
+
+	return false
+}
+
+func matchStatCondition(condition poeraw.Condition, value float64) bool {
+	matches := true
+	switch {
+	case condition.Min != nil && condition.Max != nil:
+		matches = value >= float64(*condition.Min) && value <= float64(*condition.Max)
+	case condition.Min != nil && *condition.Min < 0:
+		// Upper bounds of ranges like #|-1 are stored as the lower bound
+		matches = value <= float64(*condition.Min)
+	case condition.Min != nil:
+		matches = value >= float64(*condition.Min)
+	case condition.Max != nil:
+		matches = value <= float64(*condition.Max)
+	}
+
+	return matches != condition.Negated
+}
+
+// formatStatValue formats the value with the provided amount of decimals, or up to two decimals if negative
+func formatStatValue(value float64, decimals int) string {
+	if decimals < 0 {
+		decimals = 2
+	}
+
+	scale := math.Pow(10, float64(decimals))
+	value = math.Round(value*scale) / scale
+	if value == 0 {
+		// Avoid rendering negative zero
+		value = 0
+	}
+
+	return strconv.FormatFloat(value, 'f', -1, 64)
+}
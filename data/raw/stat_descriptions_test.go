@@ -0,0 +1,87 @@
+package raw
+
+import (
+	"testing"
+
+	"github.com/MarvinJWendt/testza"
+)
+
+func TestStatDescriptionsDescribe(t *testing.T) {
+	descriptions, err := GetStatDescriptions(StatDescriptionsDefault)
+	testza.AssertNoError(t, err)
+
+	tests := []struct {
+		name     string
+		stats    map[string]float64
+		expected []string
+	}{
+		{
+			name: "MinMax",
+			stats: map[string]float64{
+				"spell_minimum_base_fire_damage": 12,
+				"spell_maximum_base_fire_damage": 18,
+			},
+			expected: []string{"Deals 12 to 18 Fire Damage"},
+		},
+		{
+			name: "MinMaxCondition",
+			stats: map[string]float64{
+				"spell_minimum_base_fire_damage":        12,
+				"spell_maximum_base_fire_damage":        18,
+				"spell_base_fire_damage_%_maximum_life": 3,
+			},
+			expected: []string{"This Spell deals 12 to 18, plus 3% of your maximum Life, as base Fire Damage"},
+		},
+		{
+			name:     "Increased",
+			stats:    map[string]float64{"fire_damage_+%": 20},
+			expected: []string{"20% increased Fire Damage"},
+		},
+		{
+			name:     "Negate",
+			stats:    map[string]float64{"fire_damage_+%": -20},
+			expected: []string{"20% reduced Fire Damage"},
+		},
+		{
+			name:     "Signed",
+			stats:    map[string]float64{"base_maximum_life": 40, "base_fire_damage_resistance_%": -10},
+			expected: []string{"+40 to maximum Life", "-10% to Fire Resistance"},
+		},
+		{
+			name:     "PerMinute",
+			stats:    map[string]float64{"base_life_regeneration_rate_per_minute": 90},
+			expected: []string{"Regenerate 1.5 Life per second"},
+		},
+		{
+			name:     "Permyriad",
+			stats:    map[string]float64{"base_life_leech_from_attack_damage_permyriad": 50},
+			expected: []string{"0.5% of Attack Damage Leeched as Life"},
+		},
+		{
+			name:     "Singular",
+			stats:    map[string]float64{"number_of_additional_projectiles": 1},
+			expected: []string{"Skills fire an additional Projectile"},
+		},
+		{
+			name:     "Plural",
+			stats:    map[string]float64{"number_of_additional_projectiles": 3},
+			expected: []string{"Skills fire 3 additional Projectiles"},
+		},
+		{
+			name:     "Zero",
+			stats:    map[string]float64{"fire_damage_+%": 0},
+			expected: []string{},
+		},
+		{
+			name:     "Unknown",
+			stats:    map[string]float64{"not_a_real_stat": 5},
+			expected: []string{},
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			testza.AssertEqual(t, test.expected, descriptions.Describe(test.stats))
+		})
+	}
+}
@@ -0,0 +1,462 @@
+package data
+
+import (
+	"fmt"
+	"slices"
+	"strconv"
+	"strings"
+
+	"github.com/Vilsol/go-pob/data/raw"
+)
+
+// TimelessJewelType matches the key of the alternate tree version of the jewel
+type TimelessJewelType int
+
+const (
+	TimelessJewelGloriousVanity  = TimelessJewelType(1)
+	TimelessJewelLethalPride     = TimelessJewelType(2)
+	TimelessJewelBrutalRestraint = TimelessJewelType(3)
+	TimelessJewelMilitantFaith   = TimelessJewelType(4)
+	TimelessJewelElegantHubris   = TimelessJewelType(5)
+)
+
+// Passive types of the alternate passives
+const (
+	timelessJewelPassiveAttribute = 1
+	timelessJewelPassiveSmall     = 2
+	timelessJewelPassiveNotable   = 3
+	timelessJewelPassiveKeystone  = 4
+)
+
+type TimelessJewelInfo struct {
+	Name string
+
+	// Conqueror type as used by the jewel mods, e.g. vaal
+	Conqueror string
+
+	MinSeed  int
+	MaxSeed  int
+	SeedStep int
+}
+
+var TimelessJewelTypes = map[TimelessJewelType]TimelessJewelInfo{
+	TimelessJewelGloriousVanity:  {Name: "Glorious Vanity", Conqueror: "vaal", MinSeed: 100, MaxSeed: 8000, SeedStep: 1},
+	TimelessJewelLethalPride:     {Name: "Lethal Pride", Conqueror: "karui", MinSeed: 10000, MaxSeed: 18000, SeedStep: 1},
+	TimelessJewelBrutalRestraint: {Name: "Brutal Restraint", Conqueror: "maraketh", MinSeed: 500, MaxSeed: 8000, SeedStep: 1},
+	TimelessJewelMilitantFaith:   {Name: "Militant Faith", Conqueror: "templar", MinSeed: 2000, MaxSeed: 10000, SeedStep: 1},
+	TimelessJewelElegantHubris:   {Name: "Elegant Hubris", Conqueror: "eternal", MinSeed: 2000, MaxSeed: 160000, SeedStep: 20},
+}
+
+// TimelessJewelTypeByConqueror returns the jewel type of the conqueror type, e.g. vaal
+func TimelessJewelTypeByConqueror(conqueror string) (TimelessJewelType, bool) {
+	for jewelType, info := range TimelessJewelTypes {
+		if info.Conqueror == conqueror {
+			return jewelType, true
+		}
+	}
+	return 0, false
+}
+
+type TimelessJewel struct {
+	Type TimelessJewelType
+	Seed int
+
+	// Conqueror selecting the keystone, see ParseConquerorID
+	ConquerorIndex   int
+	ConquerorVersion int
+}
+
+// ParseConquerorID parses conqueror IDs such as 2 or 2_v2 into the conqueror index and version
+func ParseConquerorID(id string) (int, int, error) {
+	indexStr, versionStr, hasVersion := strings.Cut(id, "_v")
+
+	index, err := strconv.Atoi(indexStr)
+	if err != nil {
+		return 0, 0, fmt.Errorf("invalid conqueror id %s: %w", id, err)
+	}
+
+	if !hasVersion {
+		return index, 0, nil
+	}
+
+	version, err := strconv.Atoi(versionStr)
+	if err != nil {
+		return 0, 0, fmt.Errorf("invalid conqueror id %s: %w", id, err)
+	}
+
+	// Versions are counted from 2 in the ids, but from 0 in the game data
+	return index, version - 1, nil
+}
+
+// timelessJewelVersion is the alternate tree version of a timeless jewel
+type timelessJewelVersion struct {
+	SmallAttributesReplaced       bool
+	SmallNormalReplaced           bool
+	MinimumAdditions              int
+	MaximumAdditions              int
+	NotableReplacementSpawnWeight int
+}
+
+// timelessJewelStat is the value range of an alternate passive stat, the ID is empty for stats missing from the game data
+type timelessJewelStat struct {
+	ID  string
+	Min int
+	Max int
+}
+
+type timelessJewelSkill struct {
+	Name         string
+	PassiveTypes []int
+	SpawnWeight  int
+
+	// Conqueror granting the keystone, see ParseConquerorID
+	ConquerorIndex   int
+	ConquerorVersion int
+
+	// Additions rolled on top of the ones of the version
+	RandomMin int
+	RandomMax int
+
+	Stats []timelessJewelStat
+}
+
+type timelessJewelAddition struct {
+	PassiveTypes []int
+	SpawnWeight  int
+	Stats        []timelessJewelStat
+}
+
+type timelessJewelTable struct {
+	Version timelessJewelVersion
+
+	// Alternate passives in game data order, which the rolls depend on
+	Skills    []timelessJewelSkill
+	Additions []timelessJewelAddition
+}
+
+type TimelessJewelData struct {
+	tree   *Tree
+	tables map[TimelessJewelType]timelessJewelTable
+
+	// Graph IDs of the passives granting nothing but attributes
+	attributeNodes map[int64]bool
+
+	descriptions *raw.StatDescriptions
+}
+
+// TimelessJewels returns the alternate passives of the timeless jewels from timelessJewelTables.
+//
+// Requires the stat descriptions to be loadable, failures are not cached.
+func (v *TreeVersionData) TimelessJewels() (*TimelessJewelData, error) {
+	v.timelessJewelsMu.Lock()
+	defer v.timelessJewelsMu.Unlock()
+
+	if v.cachedTimelessJewels != nil {
+		return v.cachedTimelessJewels, nil
+	}
+
+	timelessJewels, err := v.loadTimelessJewels()
+	if err != nil {
+		return nil, err
+	}
+
+	v.cachedTimelessJewels = timelessJewels
+	return timelessJewels, nil
+}
+
+func (v *TreeVersionData) loadTimelessJewels() (*TimelessJewelData, error) {
+	descriptions, err := raw.GetStatDescriptions(raw.StatDescriptionsPassive)
+	if err != nil {
+		return nil, err
+	}
+
+	timelessJewels := &TimelessJewelData{
+		tree:           v.Tree(),
+		tables:         timelessJewelTables,
+		attributeNodes: make(map[int64]bool, len(timelessJewelAttributeNodes)),
+		descriptions:   descriptions,
+	}
+
+	for _, nodeID := range timelessJewelAttributeNodes {
+		timelessJewels.attributeNodes[nodeID] = true
+	}
+
+	return timelessJewels, nil
+}
+
+// TransformNode returns the node as it is changed by the timeless jewel, or false if the jewel does not affect it
+func (d *TimelessJewelData) TransformNode(nodeID int64, jewel TimelessJewel) (Node, bool) {
+	node, ok := d.tree.Nodes[strconv.FormatInt(nodeID, 10)]
+	if !ok || !timelessJewelAffectsNode(node) {
+		return Node{}, false
+	}
+
+	table, ok := d.tables[jewel.Type]
+	if !ok {
+		return Node{}, false
+	}
+	version := table.Version
+
+	seed := uint32(jewel.Seed)
+	if jewel.Type == TimelessJewelElegantHubris {
+		seed /= 20
+	}
+
+	passiveType := d.passiveType(nodeID, node)
+	rng := &timelessRNG{}
+
+	if passiveType == timelessJewelPassiveKeystone {
+		for _, skill := range table.Skills {
+			if slices.Contains(skill.PassiveTypes, passiveType) && skill.ConquerorIndex == jewel.ConquerorIndex && skill.ConquerorVersion == jewel.ConquerorVersion {
+				return d.keystoneNode(node, skill), true
+			}
+		}
+		return Node{}, false
+	}
+
+	if !d.isReplaced(rng, nodeID, seed, passiveType, version) {
+		rng.reset(uint32(nodeID), seed)
+		if passiveType == timelessJewelPassiveNotable {
+			rng.generateRange(0, 100)
+		}
+
+		additionCount := version.MinimumAdditions
+		if version.MaximumAdditions > version.MinimumAdditions {
+			additionCount = rng.generateRange(version.MinimumAdditions, version.MaximumAdditions)
+		}
+
+		node.Stats = append(slices.Clone(node.Stats), d.rollAdditions(rng, table.Additions, passiveType, additionCount)...)
+		return node, additionCount > 0
+	}
+
+	rng.reset(uint32(nodeID), seed)
+	if passiveType == timelessJewelPassiveNotable {
+		rng.generateRange(0, 100)
+	}
+
+	var rolled *timelessJewelSkill
+	totalWeight := 0
+	for i, skill := range table.Skills {
+		if !slices.Contains(skill.PassiveTypes, passiveType) {
+			continue
+		}
+
+		totalWeight += skill.SpawnWeight
+		if rng.generate(uint32(totalWeight)) < uint32(skill.SpawnWeight) {
+			rolled = &table.Skills[i]
+		}
+	}
+
+	if rolled == nil {
+		return Node{}, false
+	}
+
+	name := rolled.Name
+	node.Name = &name
+	node.ReminderText = nil
+	node.FlavourText = nil
+	node.Stats = d.rollStats(rng, rolled.Stats)
+
+	if rolled.RandomMin == 0 && rolled.RandomMax == 0 {
+		return node, true
+	}
+
+	minAdditions := version.MinimumAdditions + rolled.RandomMin
+	maxAdditions := version.MaximumAdditions + rolled.RandomMax
+	additionCount := minAdditions
+	if maxAdditions > minAdditions {
+		additionCount = rng.generateRange(minAdditions, maxAdditions)
+	}
+
+	node.Stats = append(node.Stats, d.rollAdditions(rng, table.Additions, passiveType, additionCount)...)
+	return node, true
+}
+
+// isReplaced returns whether the passive is replaced, rather than augmented by additions
+func (d *TimelessJewelData) isReplaced(rng *timelessRNG, nodeID int64, seed uint32, passiveType int, version timelessJewelVersion) bool {
+	switch passiveType {
+	case timelessJewelPassiveNotable:
+		if version.NotableReplacementSpawnWeight >= 100 {
+			return true
+		}
+		if version.NotableReplacementSpawnWeight == 0 {
+			return false
+		}
+		rng.reset(uint32(nodeID), seed)
+		return rng.generateRange(0, 100) < version.NotableReplacementSpawnWeight
+	case timelessJewelPassiveAttribute:
+		return version.SmallAttributesReplaced
+	default:
+		return version.SmallNormalReplaced
+	}
+}
+
+func (d *TimelessJewelData) rollAdditions(rng *timelessRNG, additions []timelessJewelAddition, passiveType int, count int) []string {
+	applicable := make([]timelessJewelAddition, 0)
+	totalWeight := 0
+	for _, addition := range additions {
+		if slices.Contains(addition.PassiveTypes, passiveType) {
+			applicable = append(applicable, addition)
+			totalWeight += addition.SpawnWeight
+		}
+	}
+
+	stats := make([]string, 0)
+	if totalWeight == 0 {
+		return stats
+	}
+
+	for i := 0; i < count; i++ {
+		roll := int(rng.generate(uint32(totalWeight)))
+
+		var rolled timelessJewelAddition
+		for _, addition := range applicable {
+			if addition.SpawnWeight > roll {
+				rolled = addition
+				break
+			}
+			roll -= addition.SpawnWeight
+		}
+
+		stats = append(stats, d.rollStats(rng, rolled.Stats)...)
+	}
+
+	return stats
+}
+
+// rollStats rolls the values of the stats and returns their descriptions
+func (d *TimelessJewelData) rollStats(rng *timelessRNG, stats []timelessJewelStat) []string {
+	values := make([]int, 0, len(stats))
+	for _, stat := range stats {
+		value := stat.Min
+		if stat.Max > stat.Min {
+			value = rng.generateRange(stat.Min, stat.Max)
+		}
+		values = append(values, value)
+	}
+	return d.describeStats(stats, values)
+}
+
+// describeStats returns the passive descriptions of the stats with the provided values
+func (d *TimelessJewelData) describeStats(stats []timelessJewelStat, values []int) []string {
+	described := make(map[string]float64, len(stats))
+	for i, stat := range stats {
+		if i < len(values) && stat.ID != "" {
+			described[stat.ID] = float64(values[i])
+		}
+	}
+	return d.descriptions.Describe(described)
+}
+
+func (d *TimelessJewelData) keystoneNode(node Node, skill timelessJewelSkill) Node {
+	values := make([]int, len(skill.Stats))
+	for i, stat := range skill.Stats {
+		values[i] = stat.Min
+	}
+
+	name := skill.Name
+	node.Name = &name
+	node.ReminderText = nil
+	node.FlavourText = nil
+	node.Stats = d.describeStats(skill.Stats, values)
+
+	// Some conqueror keystones are part of the regular tree as well
+	for _, treeNode := range d.tree.Nodes {
+		if treeNode.Name != nil && *treeNode.Name == name && treeNode.IsKeystone != nil && *treeNode.IsKeystone {
+			node.Stats = slices.Clone(treeNode.Stats)
+			node.ReminderText = slices.Clone(treeNode.ReminderText)
+			break
+		}
+	}
+
+	return node
+}
+
+func (d *TimelessJewelData) passiveType(nodeID int64, node Node) int {
+	switch {
+	case node.IsKeystone != nil && *node.IsKeystone:
+		return timelessJewelPassiveKeystone
+	case node.IsNotable != nil && *node.IsNotable:
+		return timelessJewelPassiveNotable
+	case d.attributeNodes[nodeID]:
+		return timelessJewelPassiveAttribute
+	default:
+		return timelessJewelPassiveSmall
+	}
+}
+
+// timelessJewelAffectsNode returns whether the node is a regular passive that timeless jewels can change
+func timelessJewelAffectsNode(node Node) bool {
+	flags := []*bool{node.IsMastery, node.IsJewelSocket, node.IsProxy, node.IsAscendancyStart, node.IsMultipleChoiceOption, node.IsBlighted}
+	for _, flag := range flags {
+		if flag != nil && *flag {
+			return false
+		}
+	}
+
+	return node.Skill != nil && node.AscendancyName == nil && node.ClassStartIndex == nil
+}
+
+type TimelessSeedMatch struct {
+	Seed int
+
+	// Nodes in radius of the socket that become the notable
+	Nodes []int64
+}
+
+// SearchTimelessSeeds returns the seeds of the jewel type that turn a notable in radius of the socket into the provided notable
+func (v *TreeVersionData) SearchTimelessSeeds(socketID int64, jewelType TimelessJewelType, notable string) ([]TimelessSeedMatch, error) {
+	info, ok := TimelessJewelTypes[jewelType]
+	if !ok {
+		return nil, fmt.Errorf("unknown timeless jewel type: %d", jewelType)
+	}
+
+	socket, ok := v.Tree().Nodes[strconv.FormatInt(socketID, 10)]
+	if !ok || socket.IsJewelSocket == nil || !*socket.IsJewelSocket {
+		return nil, fmt.Errorf("node %d is not a jewel socket", socketID)
+	}
+
+	timelessJewels, err := v.TimelessJewels()
+	if err != nil {
+		return nil, err
+	}
+
+	known := false
+	for _, skill := range timelessJewels.tables[jewelType].Skills {
+		if slices.Contains(skill.PassiveTypes, timelessJewelPassiveNotable) && strings.EqualFold(skill.Name, notable) {
+			known = true
+			break
+		}
+	}
+
+	if !known {
+		return nil, fmt.Errorf("%s is not a notable of %s", notable, info.Name)
+	}
+
+	candidates := make([]int64, 0)
+	for _, nodeID := range v.NodesInRadius(socketID, JewelRadiusLarge) {
+		node := v.Tree().Nodes[strconv.FormatInt(nodeID, 10)]
+		if node.IsNotable != nil && *node.IsNotable && timelessJewelAffectsNode(node) {
+			candidates = append(candidates, nodeID)
+		}
+	}
+	slices.Sort(candidates)
+
+	matches := make([]TimelessSeedMatch, 0)
+	for seed := info.MinSeed; seed <= info.MaxSeed; seed += info.SeedStep {
+		jewel := TimelessJewel{Type: jewelType, Seed: seed}
+
+		var nodes []int64
+		for _, nodeID := range candidates {
+			if node, ok := timelessJewels.TransformNode(nodeID, jewel); ok && strings.EqualFold(*node.Name, notable) {
+				nodes = append(nodes, nodeID)
+			}
+		}
+
+		if len(nodes) > 0 {
+			matches = append(matches, TimelessSeedMatch{Seed: seed, Nodes: nodes})
+		}
+	}
+
+	return matches, nil
+}
@@ -0,0 +1,146 @@
+package data
+
+import (
+	"slices"
+	"testing"
+
+	"github.com/MarvinJWendt/testza"
+)
+
+func TestParseConquerorID(t *testing.T) {
+	tests := []struct {
+		id      string
+		index   int
+		version int
+	}{
+		{id: "1", index: 1},
+		{id: "2_v2", index: 2, version: 1},
+		{id: "3_v2", index: 3, version: 1},
+	}
+
+	for _, test := range tests {
+		t.Run(test.id, func(t *testing.T) {
+			index, version, err := ParseConquerorID(test.id)
+			testza.AssertNoError(t, err)
+			testza.AssertEqual(t, test.index, index)
+			testza.AssertEqual(t, test.version, version)
+		})
+	}
+
+	_, _, err := ParseConquerorID("vaal")
+	testza.AssertNotNil(t, err)
+}
+
+func TestTimelessJewelTransformNode(t *testing.T) {
+	timelessJewels, err := TreeVersions[TreeVersion3_18].TimelessJewels()
+	testza.AssertNoError(t, err)
+
+	tests := []struct {
+		name   string
+		nodeID int64
+		jewel  TimelessJewel
+		result string
+		stats  []string
+	}{
+		{
+			name:   "MilitantFaithAttribute",
+			nodeID: 62363,
+			jewel:  TimelessJewel{Type: TimelessJewelMilitantFaith, Seed: 5000, ConquerorIndex: 1},
+			result: "Devotion",
+			stats:  []string{"+10 to Devotion"},
+		},
+		{
+			name:   "ElegantHubrisSmall",
+			nodeID: 2219,
+			jewel:  TimelessJewel{Type: TimelessJewelElegantHubris, Seed: 4000, ConquerorIndex: 3},
+			result: "Price of Glory",
+			stats:  []string{},
+		},
+		{
+			name:   "GloriousVanityKeystone",
+			nodeID: 12926,
+			jewel:  TimelessJewel{Type: TimelessJewelGloriousVanity, Seed: 100, ConquerorIndex: 2, ConquerorVersion: 1},
+			result: "Immortal Ambition",
+			stats: []string{
+				"Energy Shield starts at zero",
+				"Cannot Recharge or Regenerate Energy Shield",
+				"Lose 5% of Energy Shield per second",
+				"Life Leech effects are not removed when Unreserved Life is Filled",
+				"Life Leech effects Recover Energy Shield instead while on Full Life",
+			},
+		},
+		{
+			name:   "ElegantHubrisTreeKeystone",
+			nodeID: 12926,
+			jewel:  TimelessJewel{Type: TimelessJewelElegantHubris, Seed: 2000, ConquerorIndex: 3},
+			result: "Supreme Ego",
+			stats:  TreeVersions[TreeVersion3_18].Tree().Nodes["49639"].Stats,
+		},
+		{
+			name:   "LethalPrideNotable",
+			nodeID: 53118,
+			jewel:  TimelessJewel{Type: TimelessJewelLethalPride, Seed: 12345, ConquerorIndex: 2},
+			result: "Barbarism",
+			stats:  slices.Concat(TreeVersions[TreeVersion3_18].Tree().Nodes["53118"].Stats, []string{"+20 to Strength"}),
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			node, ok := timelessJewels.TransformNode(test.nodeID, test.jewel)
+			testza.AssertTrue(t, ok)
+			testza.AssertEqual(t, test.result, *node.Name)
+			testza.AssertEqual(t, test.stats, node.Stats)
+
+			again, _ := timelessJewels.TransformNode(test.nodeID, test.jewel)
+			testza.AssertEqual(t, node.Stats, again.Stats, "Rolls should only depend on the node and seed")
+		})
+	}
+
+	_, ok := timelessJewels.TransformNode(26725, TimelessJewel{Type: TimelessJewelGloriousVanity, Seed: 100})
+	testza.AssertFalse(t, ok, "Jewel sockets are never conquered")
+}
+
+func TestSearchTimelessSeeds(t *testing.T) {
+	matches, err := TreeVersions[TreeVersion3_18].SearchTimelessSeeds(26725, TimelessJewelElegantHubris, "robust diet")
+	testza.AssertNoError(t, err)
+	testza.AssertGreaterOrEqual(t, len(matches), 10, "Robust Diet should be in radius for many seeds")
+
+	timelessJewels, err := TreeVersions[TreeVersion3_18].TimelessJewels()
+	testza.AssertNoError(t, err)
+
+	for _, match := range matches[:min(10, len(matches))] {
+		testza.AssertEqual(t, 0, match.Seed%20, "Elegant Hubris seeds are multiples of 20")
+		for _, nodeID := range match.Nodes {
+			node, ok := timelessJewels.TransformNode(nodeID, TimelessJewel{Type: TimelessJewelElegantHubris, Seed: match.Seed})
+			testza.AssertTrue(t, ok)
+			testza.AssertEqual(t, "Robust Diet", *node.Name)
+		}
+	}
+
+	_, err = TreeVersions[TreeVersion3_18].SearchTimelessSeeds(26725, TimelessJewelElegantHubris, "Ritual of Flesh")
+	testza.AssertNotNil(t, err, "Notables of other jewels can't be found")
+
+	_, err = TreeVersions[TreeVersion3_18].SearchTimelessSeeds(62363, TimelessJewelElegantHubris, "Robust Diet")
+	testza.AssertNotNil(t, err, "Only jewel sockets can be searched")
+}
+
+func TestTimelessJewelTables(t *testing.T) {
+	for jewelType, info := range TimelessJewelTypes {
+		t.Run(info.Name, func(t *testing.T) {
+			table, ok := timelessJewelTables[jewelType]
+			testza.AssertTrue(t, ok)
+			testza.AssertNotZero(t, len(table.Skills))
+
+			keystones := 0
+			for _, skill := range table.Skills {
+				if slices.Contains(skill.PassiveTypes, timelessJewelPassiveKeystone) {
+					keystones++
+				}
+			}
+			testza.AssertGreaterOrEqual(t, keystones, 3, "Every conqueror grants a keystone")
+		})
+	}
+
+	testza.AssertTrue(t, slices.IsSorted(timelessJewelAttributeNodes))
+}
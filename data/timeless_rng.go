@@ -0,0 +1,95 @@
+package data
+
+// TinyMT32 parameters used by the game for timeless jewels
+const (
+	timelessRNGMat1 = uint32(0x8f7011ee)
+	timelessRNGMat2 = uint32(0xfc78ff1f)
+	timelessRNGTmat = uint32(0x3793fdff)
+
+	timelessRNGMinLoop = 8
+)
+
+// timelessRNG is the TinyMT32 generator that rolls the alternate passives of timeless jewels
+type timelessRNG struct {
+	state [4]uint32
+}
+
+// reset seeds the generator for the node with the provided graph ID
+func (r *timelessRNG) reset(graphID uint32, seed uint32) {
+	r.state = [4]uint32{0, timelessRNGMat1, timelessRNGMat2, timelessRNGTmat}
+
+	key := []uint32{graphID, seed}
+	count := max(len(key)+1, timelessRNGMinLoop)
+
+	st := &r.state
+	x := st[0] ^ st[1] ^ st[3]
+	v := (x ^ (x >> 27)) * 1664525
+	st[1] += v
+	v += uint32(len(key))
+	st[2] += v
+	st[0] = v
+
+	i := 1
+	for j := 1; j < count; j++ {
+		x = st[i%4] ^ st[(i+1)%4] ^ st[(i+3)%4]
+		v = (x ^ (x >> 27)) * 1664525
+		st[(i+1)%4] += v
+		if j-1 < len(key) {
+			v += key[j-1]
+		}
+		v += uint32(i)
+		st[(i+2)%4] += v
+		st[i%4] = v
+		i = (i + 1) % 4
+	}
+
+	for j := 0; j < 4; j++ {
+		x = st[i%4] + st[(i+1)%4] + st[(i+3)%4]
+		v = (x ^ (x >> 27)) * 1566083941
+		st[(i+1)%4] ^= v
+		v -= uint32(i)
+		st[(i+2)%4] ^= v
+		st[i%4] = v
+		i = (i + 1) % 4
+	}
+
+	for j := 0; j < timelessRNGMinLoop; j++ {
+		r.nextState()
+	}
+}
+
+func (r *timelessRNG) nextState() {
+	st := &r.state
+	y := st[3]
+	x := (st[0] & 0x7fffffff) ^ st[1] ^ st[2]
+	x ^= x << 1
+	y ^= (y >> 1) ^ x
+	st[0] = st[1]
+	st[1] = st[2]
+	st[2] = x ^ (y << 10)
+	st[3] = y
+	if y&1 != 0 {
+		st[1] ^= timelessRNGMat1
+		st[2] ^= timelessRNGMat2
+	}
+}
+
+func (r *timelessRNG) temper() uint32 {
+	t1 := r.state[0] + (r.state[2] >> 8)
+	t0 := r.state[3] ^ t1
+	if t1&1 != 0 {
+		t0 ^= timelessRNGTmat
+	}
+	return t0
+}
+
+// generate returns a value in [0, exclusiveMax)
+func (r *timelessRNG) generate(exclusiveMax uint32) uint32 {
+	r.nextState()
+	return r.temper() % exclusiveMax
+}
+
+// generateRange returns a value in [minValue, maxValue]
+func (r *timelessRNG) generateRange(minValue int, maxValue int) int {
+	return minValue + int(r.generate(uint32(maxValue-minValue+1)))
+}
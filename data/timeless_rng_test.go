@@ -0,0 +1,26 @@
+package data
+
+import (
+	"testing"
+
+	"github.com/MarvinJWendt/testza"
+)
+
+func TestTimelessRNG(t *testing.T) {
+	// Seeded like tinymt32_init of the reference implementation, whose check output starts with these values for
+	// the parameters used by the game and seed 1
+	rng := &timelessRNG{state: [4]uint32{1, timelessRNGMat1, timelessRNGMat2, timelessRNGTmat}}
+	for i := 1; i < timelessRNGMinLoop; i++ {
+		prev := rng.state[(i-1)&3]
+		rng.state[i&3] ^= uint32(i) + 1812433253*(prev^(prev>>30))
+	}
+	for i := 0; i < timelessRNGMinLoop; i++ {
+		rng.nextState()
+	}
+
+	expected := []uint32{2545341989, 981918433, 3715302833, 2387538352, 3591001365, 3820442102, 2114400566, 2196103051, 2783359912, 764534509}
+	for _, value := range expected {
+		rng.nextState()
+		testza.AssertEqual(t, value, rng.temper())
+	}
+}
@@ -0,0 +1,198 @@
+package data
+
+import (
+	"maps"
+	"slices"
+	"strconv"
+)
+
+// Nodes that moved less than this distance in tree units are considered in place
+const movedNodeTolerance = 1
+
+type NodeChange struct {
+	ID      int64
+	OldName string
+	NewName string
+
+	// Stats of the node that were removed and added by the new version
+	RemovedStats []string
+	AddedStats   []string
+}
+
+type TreeDiff struct {
+	From string
+	To   string
+
+	// Node IDs only in the new version, sorted
+	Added []int64
+
+	// Node IDs only in the old version, sorted
+	Removed []int64
+
+	// Node IDs whose position changed, sorted
+	Moved []int64
+
+	// Nodes whose name or stats changed, sorted by ID
+	Changed []NodeChange
+
+	// Removed node IDs by the ID of the added node of the same name that replaces them
+	Remapped map[int64]int64
+}
+
+// Diff compares the tree to the provided newer version. Node IDs are stable across versions, so nodes
+// are matched by their ID. Removed nodes are remapped to the closest added node of the same name and kind.
+func (v *TreeVersionData) Diff(to *TreeVersionData) *TreeDiff {
+	oldTree, newTree := v.Tree(), to.Tree()
+
+	diff := &TreeDiff{
+		From:     v.Display,
+		To:       to.Display,
+		Added:    make([]int64, 0),
+		Removed:  make([]int64, 0),
+		Moved:    make([]int64, 0),
+		Changed:  make([]NodeChange, 0),
+		Remapped: make(map[int64]int64),
+	}
+
+	for _, id := range sortedNodeIDs(oldTree) {
+		oldNode := oldTree.Nodes[strconv.FormatInt(id, 10)]
+		newNode, ok := newTree.Nodes[strconv.FormatInt(id, 10)]
+		if !ok {
+			diff.Removed = append(diff.Removed, id)
+			continue
+		}
+
+		oldLayout, okOld := v.NodeLayout(id)
+		newLayout, okNew := to.NodeLayout(id)
+		if okOld && okNew && oldLayout.Position.Distance(newLayout.Position) >= movedNodeTolerance {
+			diff.Moved = append(diff.Moved, id)
+		}
+
+		if change, changed := diffNode(id, oldNode, newNode); changed {
+			diff.Changed = append(diff.Changed, change)
+		}
+	}
+
+	for _, id := range sortedNodeIDs(newTree) {
+		if _, ok := oldTree.Nodes[strconv.FormatInt(id, 10)]; !ok {
+			diff.Added = append(diff.Added, id)
+		}
+	}
+
+	diff.Remapped = v.remapNodes(to, diff.Removed, diff.Added)
+
+	return diff
+}
+
+func sortedNodeIDs(tree *Tree) []int64 {
+	ids := make([]int64, 0, len(tree.Nodes))
+	for _, node := range tree.Nodes {
+		if node.Skill != nil {
+			ids = append(ids, *node.Skill)
+		}
+	}
+	slices.Sort(ids)
+	return ids
+}
+
+func diffNode(id int64, oldNode Node, newNode Node) (NodeChange, bool) {
+	change := NodeChange{
+		ID:           id,
+		OldName:      nodeName(oldNode),
+		NewName:      nodeName(newNode),
+		RemovedStats: make([]string, 0),
+		AddedStats:   make([]string, 0),
+	}
+
+	for _, stat := range oldNode.Stats {
+		if !slices.Contains(newNode.Stats, stat) {
+			change.RemovedStats = append(change.RemovedStats, stat)
+		}
+	}
+
+	for _, stat := range newNode.Stats {
+		if !slices.Contains(oldNode.Stats, stat) {
+			change.AddedStats = append(change.AddedStats, stat)
+		}
+	}
+
+	changed := change.OldName != change.NewName || len(change.RemovedStats) > 0 || len(change.AddedStats) > 0
+	return change, changed
+}
+
+func nodeName(node Node) string {
+	if node.Name == nil {
+		return ""
+	}
+	return *node.Name
+}
+
+// nodeKind groups nodes that can replace each other
+func nodeKind(node Node) string {
+	switch {
+	case node.IsKeystone != nil && *node.IsKeystone:
+		return "keystone"
+	case node.IsNotable != nil && *node.IsNotable:
+		return "notable"
+	case node.IsMastery != nil && *node.IsMastery:
+		return "mastery"
+	case node.IsJewelSocket != nil && *node.IsJewelSocket:
+		return "jewel"
+	case node.ClassStartIndex != nil, node.IsAscendancyStart != nil && *node.IsAscendancyStart:
+		return "start"
+	default:
+		return "normal"
+	}
+}
+
+// remapNodes pairs removed nodes with the closest added node of the same name and kind. Every added node replaces at
+// most one removed node.
+func (v *TreeVersionData) remapNodes(to *TreeVersionData, removed []int64, added []int64) map[int64]int64 {
+	oldTree, newTree := v.Tree(), to.Tree()
+
+	candidates := make(map[string][]int64)
+	for _, id := range added {
+		node := newTree.Nodes[strconv.FormatInt(id, 10)]
+		key := nodeKind(node) + ":" + nodeName(node)
+		candidates[key] = append(candidates[key], id)
+	}
+
+	remapped := make(map[int64]int64)
+	used := make(map[int64]bool)
+	for _, id := range removed {
+		node := oldTree.Nodes[strconv.FormatInt(id, 10)]
+		if node.Name == nil {
+			continue
+		}
+
+		oldLayout, hasLayout := v.NodeLayout(id)
+
+		best, bestDistance := int64(0), 0.0
+		for _, candidate := range candidates[nodeKind(node)+":"+nodeName(node)] {
+			if used[candidate] {
+				continue
+			}
+
+			distance := 0.0
+			if newLayout, ok := to.NodeLayout(candidate); ok && hasLayout {
+				distance = oldLayout.Position.Distance(newLayout.Position)
+			}
+
+			if best == 0 || distance < bestDistance {
+				best, bestDistance = candidate, distance
+			}
+		}
+
+		if best != 0 {
+			remapped[id] = best
+			used[best] = true
+		}
+	}
+
+	return remapped
+}
+
+// RemappedIDs returns the removed node IDs that have a replacement, sorted
+func (d *TreeDiff) RemappedIDs() []int64 {
+	return slices.Sorted(maps.Keys(d.Remapped))
+}
@@ -0,0 +1,116 @@
+package data
+
+import (
+	"encoding/json"
+	"slices"
+	"strconv"
+	"testing"
+
+	"github.com/MarvinJWendt/testza"
+)
+
+// modifiedTreeVersion returns a copy of the latest tree version with the modifications applied
+func modifiedTreeVersion(t *testing.T, modify func(tree *Tree)) *TreeVersionData {
+	var tree Tree
+	testza.AssertNoError(t, json.Unmarshal(TreeVersions[LatestTreeVersion].RawTree(), &tree))
+
+	modify(&tree)
+
+	raw, err := json.Marshal(tree)
+	testza.AssertNoError(t, err)
+
+	return NewTreeVersionData("modified", 99, raw)
+}
+
+// removeTreeNode removes the node and all references to it
+func removeTreeNode(tree *Tree, id string) {
+	for key, node := range tree.Nodes {
+		node.Out = slices.DeleteFunc(node.Out, func(out string) bool { return out == id })
+		node.In = slices.DeleteFunc(node.In, func(in string) bool { return in == id })
+		tree.Nodes[key] = node
+	}
+
+	group := tree.Groups[strconv.FormatInt(*tree.Nodes[id].Group, 10)]
+	group.Nodes = slices.DeleteFunc(group.Nodes, func(node string) bool { return node == id })
+	tree.Groups[strconv.FormatInt(*tree.Nodes[id].Group, 10)] = group
+
+	delete(tree.Nodes, id)
+}
+
+// renameTreeNode moves the node to a new ID, keeping its connections
+func renameTreeNode(tree *Tree, id string, newID int64) {
+	replace := func(ids []string) []string {
+		ids = slices.Clone(ids)
+		for i, other := range ids {
+			if other == id {
+				ids[i] = strconv.FormatInt(newID, 10)
+			}
+		}
+		return ids
+	}
+
+	for key, node := range tree.Nodes {
+		node.Out = replace(node.Out)
+		node.In = replace(node.In)
+		tree.Nodes[key] = node
+	}
+
+	group := tree.Groups[strconv.FormatInt(*tree.Nodes[id].Group, 10)]
+	group.Nodes = replace(group.Nodes)
+	tree.Groups[strconv.FormatInt(*tree.Nodes[id].Group, 10)] = group
+
+	node := tree.Nodes[id]
+	node.Skill = &newID
+	tree.Nodes[strconv.FormatInt(newID, 10)] = node
+	delete(tree.Nodes, id)
+}
+
+func TestTreeDiff(t *testing.T) {
+	from := TreeVersions[LatestTreeVersion]
+
+	// Nothing changes between the same versions
+	diff := from.Diff(from)
+	testza.AssertLen(t, diff.Added, 0)
+	testza.AssertLen(t, diff.Removed, 0)
+	testza.AssertLen(t, diff.Moved, 0)
+	testza.AssertLen(t, diff.Changed, 0)
+	testza.AssertLen(t, diff.Remapped, 0)
+
+	to := modifiedTreeVersion(t, func(tree *Tree) {
+		// Cast Speed between the witch start and Arcanist's Dominion
+		removeTreeNode(tree, "739")
+
+		// Deep Wisdom
+		renameTreeNode(tree, "27929", 90001)
+
+		node := tree.Nodes["1957"]
+		node.Stats = []string{"5% increased Cast Speed"}
+		tree.Nodes["1957"] = node
+
+		// Group of the Intelligence node next to Arcanist's Dominion
+		group := tree.Groups["275"]
+		group.X += 100
+		tree.Groups["275"] = group
+	})
+
+	diff = from.Diff(to)
+	testza.AssertEqual(t, "3.18", diff.From)
+	testza.AssertEqual(t, "modified", diff.To)
+	testza.AssertEqual(t, []int64{90001}, diff.Added)
+	testza.AssertEqual(t, []int64{739, 27929}, diff.Removed)
+	testza.AssertEqual(t, map[int64]int64{27929: 90001}, diff.Remapped)
+	testza.AssertEqual(t, []int64{27929}, diff.RemappedIDs())
+
+	testza.AssertContains(t, diff.Moved, int64(4397))
+	for _, id := range diff.Moved {
+		testza.AssertEqual(t, int64(275), *to.Tree().Nodes[strconv.FormatInt(id, 10)].Group)
+	}
+
+	testza.AssertEqual(t, []NodeChange{{
+		ID:           1957,
+		OldName:      "Cast Speed",
+		NewName:      "Cast Speed",
+		RemovedStats: []string{"4% increased Cast Speed"},
+		AddedStats:   []string{"5% increased Cast Speed"},
+	}}, diff.Changed)
+}
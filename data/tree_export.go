@@ -0,0 +1,247 @@
+package data
+
+import (
+	"bufio"
+	"encoding/json"
+	"encoding/xml"
+	"fmt"
+	"io"
+	"maps"
+	"slices"
+	"strconv"
+	"strings"
+)
+
+type GraphFormat string
+
+const (
+	GraphFormatDOT     = GraphFormat("dot")
+	GraphFormatGraphML = GraphFormat("graphml")
+	GraphFormatJSON    = GraphFormat("json")
+)
+
+var GraphFormats = []GraphFormat{GraphFormatDOT, GraphFormatGraphML, GraphFormatJSON}
+
+type GraphNode struct {
+	ID   int64  `json:"id"`
+	Name string `json:"name"`
+
+	// One of normal, notable, keystone, mastery, jewel or start
+	Type string `json:"type"`
+
+	Ascendancy string  `json:"ascendancy,omitempty"`
+	Group      int64   `json:"group"`
+	X          float64 `json:"x"`
+	Y          float64 `json:"y"`
+
+	// Nodes that can be allocated from this node, sorted
+	Out []int64 `json:"out"`
+}
+
+// TreeGraph is the directed allocation graph of a tree version. Edges into masteries are one-way, and class start
+// nodes have no edges, as they are never part of a path.
+type TreeGraph struct {
+	Version string      `json:"version"`
+	Nodes   []GraphNode `json:"nodes"`
+}
+
+// ExportGraph returns the allocation graph of the tree, restricted to the provided nodes and the edges between them
+// unless no nodes are provided
+func (v *TreeVersionData) ExportGraph(nodes []int64) *TreeGraph {
+	tree := v.Tree()
+	_, adjacencyMap := v.getGraph()
+
+	var subset map[int64]bool
+	if len(nodes) > 0 {
+		subset = make(map[int64]bool, len(nodes))
+		for _, id := range nodes {
+			subset[id] = true
+		}
+	}
+
+	ids := slices.Sorted(maps.Keys(adjacencyMap))
+	result := &TreeGraph{
+		Version: v.Display,
+		Nodes:   make([]GraphNode, 0, len(ids)),
+	}
+
+	for _, id := range ids {
+		if subset != nil && !subset[id] {
+			continue
+		}
+
+		node := tree.Nodes[strconv.FormatInt(id, 10)]
+		graphNode := GraphNode{
+			ID:   id,
+			Name: nodeName(node),
+			Type: nodeKind(node),
+			Out:  make([]int64, 0, len(adjacencyMap[id])),
+		}
+
+		if node.AscendancyName != nil {
+			graphNode.Ascendancy = *node.AscendancyName
+		}
+
+		if node.Group != nil {
+			graphNode.Group = *node.Group
+		}
+
+		if layout, ok := v.NodeLayout(id); ok {
+			graphNode.X, graphNode.Y = layout.Position.X, layout.Position.Y
+		}
+
+		// Paths start at the neighbours of the class start, so edges out of it are never used
+		for target := range adjacencyMap[id] {
+			if node.ClassStartIndex == nil && (subset == nil || subset[target]) {
+				graphNode.Out = append(graphNode.Out, target)
+			}
+		}
+		slices.Sort(graphNode.Out)
+
+		result.Nodes = append(result.Nodes, graphNode)
+	}
+
+	return result
+}
+
+// Write writes the graph in the provided format
+func (g *TreeGraph) Write(w io.Writer, format GraphFormat) error {
+	switch format {
+	case GraphFormatDOT:
+		return g.WriteDOT(w)
+	case GraphFormatGraphML:
+		return g.WriteGraphML(w)
+	case GraphFormatJSON:
+		return g.WriteJSON(w)
+	default:
+		return fmt.Errorf("unknown graph format: %s", format)
+	}
+}
+
+// WriteJSON writes the graph as a list of nodes with their outgoing edges
+func (g *TreeGraph) WriteJSON(w io.Writer) error {
+	if err := json.NewEncoder(w).Encode(g); err != nil {
+		return fmt.Errorf("failed to encode graph: %w", err)
+	}
+	return nil
+}
+
+// WriteDOT writes the graph in the Graphviz DOT language. Nodes are pinned to their tree position.
+func (g *TreeGraph) WriteDOT(w io.Writer) error {
+	bw := bufio.NewWriter(w)
+
+	fmt.Fprintf(bw, "digraph %s {\n", dotString(g.Version))
+	for _, node := range g.Nodes {
+		fmt.Fprintf(bw, "  %d [label=%s, type=%s, ascendancy=%s, group=%d, x=%s, y=%s, pos=%s];\n",
+			node.ID, dotString(node.Name), dotString(node.Type), dotString(node.Ascendancy), node.Group,
+			formatCoordinate(node.X), formatCoordinate(node.Y),
+			// Graphviz has the Y axis pointing up
+			dotString(formatCoordinate(node.X)+","+formatCoordinate(-node.Y)+"!"))
+	}
+
+	for _, node := range g.Nodes {
+		for _, target := range node.Out {
+			fmt.Fprintf(bw, "  %d -> %d;\n", node.ID, target)
+		}
+	}
+	_, _ = bw.WriteString("}\n")
+
+	if err := bw.Flush(); err != nil {
+		return fmt.Errorf("failed to write graph: %w", err)
+	}
+	return nil
+}
+
+func dotString(value string) string {
+	return `"` + strings.NewReplacer(`\`, `\\`, `"`, `\"`).Replace(value) + `"`
+}
+
+func formatCoordinate(value float64) string {
+	return strconv.FormatFloat(value, 'f', -1, 64)
+}
+
+type graphML struct {
+	XMLName xml.Name       `xml:"graphml"`
+	XMLNS   string         `xml:"xmlns,attr"`
+	Keys    []graphMLKey   `xml:"key"`
+	Graph   graphMLContent `xml:"graph"`
+}
+
+type graphMLKey struct {
+	ID   string `xml:"id,attr"`
+	For  string `xml:"for,attr"`
+	Name string `xml:"attr.name,attr"`
+	Type string `xml:"attr.type,attr"`
+}
+
+type graphMLContent struct {
+	ID          string        `xml:"id,attr"`
+	EdgeDefault string        `xml:"edgedefault,attr"`
+	Nodes       []graphMLNode `xml:"node"`
+	Edges       []graphMLEdge `xml:"edge"`
+}
+
+type graphMLNode struct {
+	ID   string        `xml:"id,attr"`
+	Data []graphMLData `xml:"data"`
+}
+
+type graphMLEdge struct {
+	Source string `xml:"source,attr"`
+	Target string `xml:"target,attr"`
+}
+
+type graphMLData struct {
+	Key   string `xml:"key,attr"`
+	Value string `xml:",chardata"`
+}
+
+// WriteGraphML writes the graph as GraphML, with the node attributes as data keys
+func (g *TreeGraph) WriteGraphML(w io.Writer) error {
+	doc := graphML{
+		XMLNS: "http://graphml.graphdrawing.org/xmlns",
+		Keys: []graphMLKey{
+			{ID: "name", For: "node", Name: "name", Type: "string"},
+			{ID: "type", For: "node", Name: "type", Type: "string"},
+			{ID: "ascendancy", For: "node", Name: "ascendancy", Type: "string"},
+			{ID: "group", For: "node", Name: "group", Type: "long"},
+			{ID: "x", For: "node", Name: "x", Type: "double"},
+			{ID: "y", For: "node", Name: "y", Type: "double"},
+		},
+		Graph: graphMLContent{
+			ID:          g.Version,
+			EdgeDefault: "directed",
+			Nodes:       make([]graphMLNode, 0, len(g.Nodes)),
+		},
+	}
+
+	for _, node := range g.Nodes {
+		id := strconv.FormatInt(node.ID, 10)
+		doc.Graph.Nodes = append(doc.Graph.Nodes, graphMLNode{
+			ID: id,
+			Data: []graphMLData{
+				{Key: "name", Value: node.Name},
+				{Key: "type", Value: node.Type},
+				{Key: "ascendancy", Value: node.Ascendancy},
+				{Key: "group", Value: strconv.FormatInt(node.Group, 10)},
+				{Key: "x", Value: formatCoordinate(node.X)},
+				{Key: "y", Value: formatCoordinate(node.Y)},
+			},
+		})
+
+		for _, target := range node.Out {
+			doc.Graph.Edges = append(doc.Graph.Edges, graphMLEdge{Source: id, Target: strconv.FormatInt(target, 10)})
+		}
+	}
+
+	if _, err := io.WriteString(w, xml.Header); err != nil {
+		return fmt.Errorf("failed to write graph: %w", err)
+	}
+
+	encoder := xml.NewEncoder(w)
+	encoder.Indent("", "  ")
+	if err := encoder.Encode(doc); err != nil {
+		return fmt.Errorf("failed to encode graph: %w", err)
+	}
+	return nil
+}
@@ -0,0 +1,120 @@
+package data
+
+import (
+	"bytes"
+	"encoding/json"
+	"encoding/xml"
+	"slices"
+	"strings"
+	"testing"
+
+	"github.com/MarvinJWendt/testza"
+)
+
+func TestExportGraph(t *testing.T) {
+	version := TreeVersions[TreeVersion3_18]
+	tree := version.Tree()
+
+	graph := version.ExportGraph(nil)
+	testza.AssertEqual(t, "3.18", graph.Version)
+
+	skills := 0
+	for _, node := range tree.Nodes {
+		if node.Skill != nil {
+			skills++
+		}
+	}
+	testza.AssertLen(t, graph.Nodes, skills)
+
+	byID := make(map[int64]GraphNode, len(graph.Nodes))
+	for _, node := range graph.Nodes {
+		byID[node.ID] = node
+	}
+
+	// Arcanist's Dominion
+	notable := byID[11420]
+	testza.AssertEqual(t, "Arcanist's Dominion", notable.Name)
+	testza.AssertEqual(t, "notable", notable.Type)
+	testza.AssertEqual(t, int64(319), notable.Group)
+	x, y, _ := version.NodePosition(11420)
+	testza.AssertEqual(t, x, notable.X)
+	testza.AssertEqual(t, y, notable.Y)
+
+	testza.AssertEqual(t, "start", byID[58833].Type)
+	starts := 0
+	for _, node := range graph.Nodes {
+		if node.Type == "start" && node.Ascendancy == "" {
+			starts++
+			testza.AssertLen(t, node.Out, 0, "Class start nodes are not part of any path")
+		}
+	}
+	testza.AssertEqual(t, 7, starts)
+	testza.AssertEqual(t, "Occultist", byID[18378].Ascendancy)
+
+	// Masteries can be allocated from their notables, but can't be travelled through
+	oneWayEdges := 0
+	for _, node := range graph.Nodes {
+		for _, target := range node.Out {
+			if byID[target].Type == "mastery" && !slices.Contains(byID[target].Out, node.ID) {
+				oneWayEdges++
+			}
+		}
+	}
+	testza.AssertGreater(t, oneWayEdges, 0)
+
+	// Witch spell damage root and the small int nodes up towards Arcanist's Dominion
+	subset := version.ExportGraph([]int64{57264, 33296, 1957, 739, 18866})
+	testza.AssertLen(t, subset.Nodes, 5)
+	for _, node := range subset.Nodes {
+		for _, target := range node.Out {
+			testza.AssertContains(t, []int64{57264, 33296, 1957, 739, 18866}, target)
+		}
+	}
+	testza.AssertEqual(t, int64(739), subset.Nodes[0].ID)
+	testza.AssertEqual(t, []int64{1957, 18866}, subset.Nodes[0].Out)
+}
+
+func TestTreeGraphWrite(t *testing.T) {
+	graph := TreeVersions[TreeVersion3_18].ExportGraph([]int64{57264, 33296, 1957, 739, 18866})
+
+	edges := 0
+	for _, node := range graph.Nodes {
+		edges += len(node.Out)
+	}
+
+	var dot bytes.Buffer
+	testza.AssertNoError(t, graph.Write(&dot, GraphFormatDOT))
+	testza.AssertTrue(t, strings.HasPrefix(dot.String(), `digraph "3.18" {`))
+	testza.AssertContains(t, dot.String(), `57264 [label="Spell Damage and Mana", type="normal", ascendancy="", group=320`)
+	testza.AssertContains(t, dot.String(), "  57264 -> 33296;\n")
+	testza.AssertEqual(t, edges, strings.Count(dot.String(), "->"))
+
+	var graphML bytes.Buffer
+	testza.AssertNoError(t, graph.Write(&graphML, GraphFormatGraphML))
+
+	var decodedGraphML struct {
+		Graph struct {
+			EdgeDefault string `xml:"edgedefault,attr"`
+			Nodes       []struct {
+				ID string `xml:"id,attr"`
+			} `xml:"node"`
+			Edges []struct {
+				Source string `xml:"source,attr"`
+				Target string `xml:"target,attr"`
+			} `xml:"edge"`
+		} `xml:"graph"`
+	}
+	testza.AssertNoError(t, xml.Unmarshal(graphML.Bytes(), &decodedGraphML))
+	testza.AssertEqual(t, "directed", decodedGraphML.Graph.EdgeDefault)
+	testza.AssertLen(t, decodedGraphML.Graph.Nodes, 5)
+	testza.AssertLen(t, decodedGraphML.Graph.Edges, edges)
+
+	var jsonGraph bytes.Buffer
+	testza.AssertNoError(t, graph.Write(&jsonGraph, GraphFormatJSON))
+
+	var decoded TreeGraph
+	testza.AssertNoError(t, json.Unmarshal(jsonGraph.Bytes(), &decoded))
+	testza.AssertEqual(t, *graph, decoded)
+
+	testza.AssertNotNil(t, graph.Write(&jsonGraph, GraphFormat("csv")))
+}
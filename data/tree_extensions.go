@@ -0,0 +1,113 @@
+package data
+
+import (
+	"fmt"
+	"maps"
+	"slices"
+	"strconv"
+	"strings"
+)
+
+// GraphExtension adds the edges of a single build to the shared tree graph, e.g. cluster jewel subgraphs.
+// The cached graph itself is never modified.
+type GraphExtension interface {
+	extendGraph(edges map[int64][]int64)
+}
+
+// RadiusExtension makes the nodes in a radius allocatable without being connected to the tree, as long as the
+// jewel socket is allocated, e.g. for Thread of Hope, Intuitive Leap and Impossible Escape
+type RadiusExtension struct {
+	SocketID int64
+	Nodes    []int64
+}
+
+// extendGraph links the socket to every node in radius in a single direction, so allocating the socket (or having
+// it allocated) is enough to reach them, but they can't be used to path to the socket
+func (e *RadiusExtension) extendGraph(edges map[int64][]int64) {
+	edges[e.SocketID] = append(edges[e.SocketID], e.Nodes...)
+}
+
+// EdgeExtension adds plain directed edges to the graph. Unlike the other extensions it can be passed through JS, so
+// the frontend uses it to path through the extensions of the calculated spec.
+type EdgeExtension struct {
+	// Pairs of the node the edge starts at and the node it leads to
+	Edges [][2]int64
+}
+
+func (e *EdgeExtension) extendGraph(edges map[int64][]int64) {
+	for _, edge := range e.Edges {
+		edges[edge[0]] = append(edges[edge[0]], edge[1])
+	}
+}
+
+// NewEdgeExtension returns the edges added by the extensions, sorted by the node they start at
+func NewEdgeExtension(extensions ...GraphExtension) *EdgeExtension {
+	extension := &EdgeExtension{
+		Edges: make([][2]int64, 0),
+	}
+
+	edges := extensionEdges(extensions)
+	for _, from := range slices.Sorted(maps.Keys(edges)) {
+		for _, to := range edges[from] {
+			extension.Edges = append(extension.Edges, [2]int64{from, to})
+		}
+	}
+
+	return extension
+}
+
+// NewRadiusExtension returns the extension for the nodes in radius of the center node, which is the socket itself
+// for all jewels except Impossible Escape. Nodes that are never allocatable, like class starts, are excluded.
+func (v *TreeVersionData) NewRadiusExtension(socketID int64, centerID int64, radiusIndex int) (*RadiusExtension, error) {
+	tree := v.Tree()
+
+	socket, ok := tree.Nodes[strconv.FormatInt(socketID, 10)]
+	if !ok || socket.IsJewelSocket == nil || !*socket.IsJewelSocket {
+		return nil, fmt.Errorf("node %d is not a jewel socket", socketID)
+	}
+
+	if _, ok := v.JewelRadius(radiusIndex); !ok {
+		return nil, fmt.Errorf("invalid radius index: %d", radiusIndex)
+	}
+
+	extension := &RadiusExtension{
+		SocketID: socketID,
+		Nodes:    make([]int64, 0),
+	}
+
+	candidates := v.NodesInRadius(centerID, radiusIndex)
+	if centerID != socketID {
+		// Keystones are in their own radius
+		candidates = append([]int64{centerID}, candidates...)
+	}
+
+	for _, id := range candidates {
+		node := tree.Nodes[strconv.FormatInt(id, 10)]
+		if node.ClassStartIndex != nil || node.AscendancyName != nil {
+			continue
+		}
+
+		extension.Nodes = append(extension.Nodes, id)
+	}
+
+	return extension, nil
+}
+
+// KeystoneByName returns the ID of the keystone with the provided name, ignoring case
+func (v *TreeVersionData) KeystoneByName(name string) (int64, bool) {
+	for _, node := range v.Tree().Nodes {
+		if node.Skill != nil && node.Name != nil && node.IsKeystone != nil && *node.IsKeystone && strings.EqualFold(*node.Name, name) {
+			return *node.Skill, true
+		}
+	}
+	return 0, false
+}
+
+// extensionEdges returns the extra neighbours of nodes added by the extensions
+func extensionEdges(extensions []GraphExtension) map[int64][]int64 {
+	edges := make(map[int64][]int64)
+	for _, extension := range extensions {
+		extension.extendGraph(edges)
+	}
+	return edges
+}
@@ -0,0 +1,67 @@
+package data
+
+import (
+	"testing"
+
+	"github.com/MarvinJWendt/testza"
+)
+
+func TestRadiusExtension(t *testing.T) {
+	treeVersion := TreeVersions[TreeVersion3_18]
+
+	// Thread of Hope in the large ring of the socket
+	extension, err := treeVersion.NewRadiusExtension(26725, 26725, JewelRadiusLargeRing)
+	testza.AssertNoError(t, err)
+	testza.AssertEqual(t, treeVersion.NodesInRadius(26725, JewelRadiusLargeRing), extension.Nodes)
+
+	target := extension.Nodes[0]
+
+	paths := treeVersion.CalculateAllocationPaths([]int64{26725}, []int64{}, extension)
+	testza.AssertEqual(t, int64(26725), paths[target], "Nodes in radius should be reachable from the socket")
+	testza.AssertNotEqual(t, int64(26725), treeVersion.CalculateAllocationPaths([]int64{26725}, []int64{})[target])
+
+	testza.AssertEqual(t, []int64{}, treeVersion.DisconnectedNodes([]int64{26725, target}, []int64{26725}, extension))
+	testza.AssertEqual(t, []int64{target}, treeVersion.DisconnectedNodes([]int64{26725, target}, []int64{26725}))
+	testza.AssertEqual(t, []int64{26725}, treeVersion.DisconnectedNodes([]int64{26725, target}, []int64{target}, extension), "Nodes in radius can't be used to reach the socket")
+}
+
+func TestRadiusExtensionSteinerTree(t *testing.T) {
+	treeVersion := TreeVersions[TreeVersion3_18]
+
+	extension, err := treeVersion.NewRadiusExtension(26725, 26725, JewelRadiusLargeRing)
+	testza.AssertNoError(t, err)
+
+	target := extension.Nodes[0]
+
+	// Nodes in radius are allocated straight from the socket
+	actual := treeVersion.CalculateSteinerTree([]int64{26725}, []int64{26725}, []int64{target}, extension)
+	testza.AssertEqual(t, []int64{target}, actual.Nodes)
+	testza.AssertEqual(t, 1, actual.Cost)
+
+	withoutExtension := treeVersion.CalculateSteinerTree([]int64{26725}, []int64{26725}, []int64{target})
+	testza.AssertGreater(t, withoutExtension.Cost, 1)
+}
+
+func TestRadiusExtensionKeystone(t *testing.T) {
+	treeVersion := TreeVersions[TreeVersion3_18]
+
+	keystone, ok := treeVersion.KeystoneByName("ghost reaver")
+	testza.AssertTrue(t, ok)
+	testza.AssertEqual(t, int64(24426), keystone)
+
+	// Impossible Escape uses the radius of the keystone, which includes the keystone itself
+	extension, err := treeVersion.NewRadiusExtension(26725, keystone, JewelRadiusSmall)
+	testza.AssertNoError(t, err)
+	testza.AssertEqual(t, keystone, extension.Nodes[0])
+	testza.AssertEqual(t, treeVersion.NodesInRadius(keystone, JewelRadiusSmall), extension.Nodes[1:])
+
+	testza.AssertEqual(t, []int64{}, treeVersion.DisconnectedNodes([]int64{26725, keystone, 1655}, []int64{26725}, extension))
+}
+
+func TestRadiusExtensionErrors(t *testing.T) {
+	_, err := TreeVersions[TreeVersion3_18].NewRadiusExtension(62363, 62363, JewelRadiusSmall)
+	testza.AssertNotNil(t, err, "Regular nodes are not jewel sockets")
+
+	_, err = TreeVersions[TreeVersion3_18].NewRadiusExtension(26725, 26725, 0)
+	testza.AssertNotNil(t, err)
+}
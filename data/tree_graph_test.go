@@ -2,6 +2,7 @@ package data
 
 import (
 	"context"
+	"slices"
 	"testing"
 
 	"github.com/MarvinJWendt/testza"
@@ -104,3 +105,49 @@ func BenchmarkCalculateAllocationPaths(b *testing.B) {
 		TreeVersions[TreeVersion3_18].CalculateAllocationPaths([]int64{48828, 55373, 2151, 47062, 15144, 62103}, []int64{48828})
 	}
 }
+
+func TestRootNodes(t *testing.T) {
+	roots := TreeVersions[TreeVersion3_18].RootNodes(Witch, "")
+	testza.AssertContains(t, roots, int64(57264), "Witch spell damage root should be a root node")
+	testza.AssertContains(t, roots, int64(57226), "Witch ES root should be a root node")
+
+	rootsWithAscendancy := TreeVersions[TreeVersion3_18].RootNodes(Witch, Occultist)
+	testza.AssertEqual(t, len(roots)+1, len(rootsWithAscendancy), "Ascendancy start node should be a root node")
+}
+
+func TestDisconnectedNodes(t *testing.T) {
+	// Witch spell damage root and the small int nodes up towards Arcanist's Dominion
+	connected := []int64{57264, 33296, 1957, 739, 18866}
+	roots := TreeVersions[TreeVersion3_18].RootNodes(Witch, "")
+
+	testza.AssertEqual(t, []int64{}, TreeVersions[TreeVersion3_18].DisconnectedNodes(connected, roots))
+
+	// Heart of the Warrior is nowhere near the witch start
+	testza.AssertEqual(t, []int64{61198}, TreeVersions[TreeVersion3_18].DisconnectedNodes(append(connected, 61198), roots))
+
+	// Without the root allocated nothing is connected
+	testza.AssertEqual(t, []int64{739, 1957, 18866, 33296}, TreeVersions[TreeVersion3_18].DisconnectedNodes(connected[1:], roots))
+}
+
+func TestCalculateSteinerTree(t *testing.T) {
+	activeNodes := []int64{57264, 33296, 1957, 739, 18866, 37569, 36542, 4397}
+	rootNodes := []int64{57226, 57264}
+
+	// Arcanist's Dominion lies on the shortest path to Deep Wisdom, Mystic Talents can only be anointed
+	actual := TreeVersions[TreeVersion3_18].CalculateSteinerTree(activeNodes, rootNodes, []int64{27929, 11420, 62596})
+
+	testza.AssertEqual(t, []int64{11420, 60554, 32024, 27929}, actual.Nodes)
+	testza.AssertEqual(t, 4, actual.Cost)
+	testza.AssertEqual(t, []int64{62596}, actual.Unreachable)
+
+	// Every prefix of the allocation order must be connected
+	for i := range actual.Nodes {
+		allocated := append(slices.Clone(activeNodes), actual.Nodes[:i+1]...)
+		testza.AssertLen(t, TreeVersions[TreeVersion3_18].DisconnectedNodes(allocated, rootNodes), 0)
+	}
+
+	// Already allocated targets cost nothing
+	actual = TreeVersions[TreeVersion3_18].CalculateSteinerTree(activeNodes, rootNodes, []int64{4397})
+	testza.AssertLen(t, actual.Nodes, 0)
+	testza.AssertEqual(t, 0, actual.Cost)
+}
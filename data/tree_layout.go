@@ -0,0 +1,252 @@
+package data
+
+import (
+	"cmp"
+	"math"
+	"slices"
+	"strconv"
+)
+
+// Point is a position in tree coordinates, with the Y axis pointing down
+type Point struct {
+	X float64
+	Y float64
+}
+
+func (p Point) distanceSquared(other Point) float64 {
+	dx, dy := p.X-other.X, p.Y-other.Y
+	return dx*dx + dy*dy
+}
+
+// Distance returns the euclidean distance between both points
+func (p Point) Distance(other Point) float64 {
+	return math.Sqrt(p.distanceSquared(other))
+}
+
+// NodeLayout is the placement of a node on the orbit of its group
+type NodeLayout struct {
+	Position Point
+
+	// Center of the group of the node
+	Center Point
+
+	// Radius of the orbit of the node, zero for nodes in the center of their group
+	Radius float64
+
+	// Angle on the orbit in radians, clockwise from the top
+	Angle float64
+}
+
+// Arc is the part of a circle between two angles in radians, clockwise from the top.
+// The arc runs clockwise from StartAngle to EndAngle, which may exceed 2π.
+type Arc struct {
+	Center     Point
+	Radius     float64
+	StartAngle float64
+	EndAngle   float64
+}
+
+// Connection is the drawn edge between two nodes. Connections of nodes on the same orbit of a group
+// follow the orbit, all others are straight lines.
+type Connection struct {
+	From int64
+	To   int64
+
+	// Orbit segment between both nodes, nil for straight lines
+	Arc *Arc
+}
+
+type treeGeometry struct {
+	layouts       map[int64]NodeLayout
+	connections   []Connection
+	nodesInRadius map[int64][][]int64
+}
+
+// NodePosition returns the position of the node on the tree, derived from its group, orbit and orbit index
+func (v *TreeVersionData) NodePosition(nodeID int64) (float64, float64, bool) {
+	layout, ok := v.geometry().layouts[nodeID]
+	return layout.Position.X, layout.Position.Y, ok
+}
+
+// NodeLayout returns the placement of the node on the tree, or false if the node has no position
+func (v *TreeVersionData) NodeLayout(nodeID int64) (NodeLayout, bool) {
+	layout, ok := v.geometry().layouts[nodeID]
+	return layout, ok
+}
+
+// NodeLayouts returns the placement of all nodes with a position by node ID. The returned map is shared and must not
+// be modified.
+func (v *TreeVersionData) NodeLayouts() map[int64]NodeLayout {
+	return v.geometry().layouts
+}
+
+// NodeDistance returns the distance between two nodes on the tree, or false if either has no position
+func (v *TreeVersionData) NodeDistance(fromID int64, toID int64) (float64, bool) {
+	layouts := v.geometry().layouts
+	from, okFrom := layouts[fromID]
+	to, okTo := layouts[toID]
+	if !okFrom || !okTo {
+		return 0, false
+	}
+	return from.Position.Distance(to.Position), true
+}
+
+// Connections returns the geometry of all edges between positioned nodes, sorted by node IDs with From < To.
+// Like on the in-game tree, edges of class start nodes and masteries, and edges between the main tree and
+// ascendancies, are not drawn. The returned slice is shared and must not be modified.
+func (v *TreeVersionData) Connections() []Connection {
+	return v.geometry().connections
+}
+
+func (v *TreeVersionData) geometry() *treeGeometry {
+	// Calculations run in parallel, so unlike the graph this is guarded
+	v.geometryOnce.Do(func() {
+		layouts := v.calculateNodeLayouts()
+		v.cachedGeometry = &treeGeometry{
+			layouts:       layouts,
+			connections:   v.calculateConnections(layouts),
+			nodesInRadius: v.calculateNodesInRadius(layouts),
+		}
+	})
+	return v.cachedGeometry
+}
+
+// orbitAngles returns the angle in radians of every orbit index for an orbit with the provided amount of nodes
+func orbitAngles(nodesInOrbit int64, fixedAngles bool) []float64 {
+	var degrees []float64
+	switch {
+	case fixedAngles && nodesInOrbit == 16:
+		// Every 30 and 45 degrees
+		degrees = []float64{0, 30, 45, 60, 90, 120, 135, 150, 180, 210, 225, 240, 270, 300, 315, 330}
+	case fixedAngles && nodesInOrbit == 40:
+		// Every 10 and 45 degrees
+		degrees = []float64{0, 10, 20, 30, 40, 45, 50, 60, 70, 80, 90, 100, 110, 120, 130, 135, 140, 150, 160, 170, 180, 190, 200, 210, 220, 225, 230, 240, 250, 260, 270, 280, 290, 300, 310, 315, 320, 330, 340, 350}
+	default:
+		degrees = make([]float64, nodesInOrbit)
+		for i := range degrees {
+			degrees[i] = 360 * float64(i) / float64(nodesInOrbit)
+		}
+	}
+
+	angles := make([]float64, len(degrees))
+	for i, degree := range degrees {
+		angles[i] = degree * math.Pi / 180
+	}
+	return angles
+}
+
+func (v *TreeVersionData) calculateNodeLayouts() map[int64]NodeLayout {
+	tree := v.Tree()
+
+	// Trees with 16 node orbits place their nodes at fixed angles instead of spacing them uniformly
+	fixedAngles := slices.Contains(tree.Constants.SkillsPerOrbit, 16)
+
+	angles := make([][]float64, len(tree.Constants.SkillsPerOrbit))
+	for orbit, nodesInOrbit := range tree.Constants.SkillsPerOrbit {
+		angles[orbit] = orbitAngles(nodesInOrbit, fixedAngles)
+	}
+
+	layouts := make(map[int64]NodeLayout, len(tree.Nodes))
+	for _, node := range tree.Nodes {
+		if node.Skill == nil || node.Group == nil || node.Orbit == nil || node.OrbitIndex == nil {
+			continue
+		}
+
+		group, ok := tree.Groups[strconv.FormatInt(*node.Group, 10)]
+		if !ok || int(*node.Orbit) >= len(angles) || int(*node.OrbitIndex) >= len(angles[*node.Orbit]) {
+			continue
+		}
+
+		angle := angles[*node.Orbit][*node.OrbitIndex]
+		orbitRadius := float64(tree.Constants.OrbitRadii[*node.Orbit])
+		layouts[*node.Skill] = NodeLayout{
+			Position: Point{
+				X: group.X + math.Sin(angle)*orbitRadius,
+				Y: group.Y - math.Cos(angle)*orbitRadius,
+			},
+			Center: Point{X: group.X, Y: group.Y},
+			Radius: orbitRadius,
+			Angle:  angle,
+		}
+	}
+
+	return layouts
+}
+
+func (v *TreeVersionData) calculateConnections(layouts map[int64]NodeLayout) []Connection {
+	tree := v.Tree()
+
+	connections := make([]Connection, 0)
+	for _, node := range tree.Nodes {
+		if node.Skill == nil || !drawsConnections(node) {
+			continue
+		}
+
+		for _, out := range node.Out {
+			target, ok := tree.Nodes[out]
+			if !ok || target.Skill == nil || !drawsConnections(target) {
+				continue
+			}
+
+			if ascendancyOf(node) != ascendancyOf(target) {
+				continue
+			}
+
+			from, okFrom := layouts[*node.Skill]
+			to, okTo := layouts[*target.Skill]
+			if !okFrom || !okTo {
+				continue
+			}
+
+			connection := Connection{From: *node.Skill, To: *target.Skill}
+			if *node.Group == *target.Group && *node.Orbit == *target.Orbit && from.Radius > 0 {
+				connection.Arc = orbitArc(from, to)
+			}
+
+			if connection.From > connection.To {
+				connection.From, connection.To = connection.To, connection.From
+			}
+
+			connections = append(connections, connection)
+		}
+	}
+
+	slices.SortFunc(connections, func(a, b Connection) int {
+		return cmp.Or(cmp.Compare(a.From, b.From), cmp.Compare(a.To, b.To))
+	})
+
+	// Edges may be listed by both of their nodes
+	return slices.CompactFunc(connections, func(a, b Connection) bool {
+		return a.From == b.From && a.To == b.To
+	})
+}
+
+func drawsConnections(node Node) bool {
+	return node.ClassStartIndex == nil && (node.IsMastery == nil || !*node.IsMastery)
+}
+
+func ascendancyOf(node Node) string {
+	if node.AscendancyName == nil {
+		return ""
+	}
+	return *node.AscendancyName
+}
+
+// orbitArc returns the shorter arc between two nodes on the same orbit
+func orbitArc(from NodeLayout, to NodeLayout) *Arc {
+	start, end := from.Angle, to.Angle
+	if end < start {
+		start, end = end, start
+	}
+
+	if end-start > math.Pi {
+		start, end = end, start+2*math.Pi
+	}
+
+	return &Arc{
+		Center:     from.Center,
+		Radius:     from.Radius,
+		StartAngle: start,
+		EndAngle:   end,
+	}
+}
@@ -0,0 +1,104 @@
+package data
+
+import (
+	"math"
+	"strconv"
+	"testing"
+
+	"github.com/MarvinJWendt/testza"
+)
+
+func TestNodePosition(t *testing.T) {
+	version := TreeVersions[LatestTreeVersion]
+
+	// Class start nodes are at the centre of their group
+	x, y, ok := version.NodePosition(58833)
+	testza.AssertTrue(t, ok)
+
+	scion := version.Tree().Nodes["58833"]
+	group := version.Tree().Groups[strconv.FormatInt(*scion.Group, 10)]
+	testza.AssertEqual(t, group.X, x)
+	testza.AssertEqual(t, group.Y, y)
+
+	_, _, ok = version.NodePosition(-1)
+	testza.AssertFalse(t, ok)
+}
+
+func TestNodeLayout(t *testing.T) {
+	version := TreeVersions[LatestTreeVersion]
+
+	for nodeID, layout := range version.NodeLayouts() {
+		x, y, ok := version.NodePosition(nodeID)
+		testza.AssertTrue(t, ok)
+		testza.AssertEqual(t, Point{X: x, Y: y}, layout.Position)
+		testza.AssertLess(t, math.Abs(layout.Radius-layout.Center.Distance(layout.Position)), 0.001)
+	}
+
+	// Nodes sit on the orbit of their group
+	layout, ok := version.NodeLayout(61834)
+	testza.AssertTrue(t, ok)
+	testza.AssertEqual(t, float64(version.Tree().Constants.OrbitRadii[*version.Tree().Nodes["61834"].Orbit]), layout.Radius)
+
+	_, ok = version.NodeLayout(-1)
+	testza.AssertFalse(t, ok)
+}
+
+func TestNodeDistance(t *testing.T) {
+	version := TreeVersions[LatestTreeVersion]
+
+	distance, ok := version.NodeDistance(58833, 58833)
+	testza.AssertTrue(t, ok)
+	testza.AssertEqual(t, 0.0, distance)
+
+	// Nodes in radius of the jewel socket are within its outer radius
+	radius, _ := version.JewelRadius(JewelRadiusMedium)
+	for _, nodeID := range version.NodesInRadius(61834, JewelRadiusMedium) {
+		distance, ok := version.NodeDistance(61834, nodeID)
+		testza.AssertTrue(t, ok)
+		testza.AssertLessOrEqual(t, distance, radius.Outer)
+	}
+
+	_, ok = version.NodeDistance(58833, -1)
+	testza.AssertFalse(t, ok)
+}
+
+func TestConnections(t *testing.T) {
+	version := TreeVersions[LatestTreeVersion]
+	tree := version.Tree()
+
+	connections := version.Connections()
+	testza.AssertGreater(t, len(connections), 0)
+
+	arcs := 0
+	for i, connection := range connections {
+		testza.AssertLess(t, connection.From, connection.To)
+		if i > 0 {
+			previous := connections[i-1]
+			testza.AssertTrue(t, previous.From < connection.From || (previous.From == connection.From && previous.To < connection.To), "Connections should be sorted and unique")
+		}
+
+		for _, nodeID := range []int64{connection.From, connection.To} {
+			node := tree.Nodes[strconv.FormatInt(nodeID, 10)]
+			testza.AssertNil(t, node.ClassStartIndex, "Connections of class start nodes are not drawn")
+			testza.AssertTrue(t, node.IsMastery == nil || !*node.IsMastery, "Connections of masteries are not drawn")
+		}
+
+		if connection.Arc == nil {
+			continue
+		}
+		arcs++
+
+		// Both ends of the arc are on the nodes
+		arc := connection.Arc
+		testza.AssertGreater(t, arc.EndAngle, arc.StartAngle)
+		testza.AssertLessOrEqual(t, arc.EndAngle-arc.StartAngle, math.Pi)
+
+		from, _ := version.NodeLayout(connection.From)
+		to, _ := version.NodeLayout(connection.To)
+		for _, angle := range []float64{arc.StartAngle, arc.EndAngle} {
+			end := Point{X: arc.Center.X + math.Sin(angle)*arc.Radius, Y: arc.Center.Y - math.Cos(angle)*arc.Radius}
+			testza.AssertTrue(t, end.Distance(from.Position) < 0.001 || end.Distance(to.Position) < 0.001)
+		}
+	}
+	testza.AssertGreater(t, arcs, 0)
+}
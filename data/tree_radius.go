@@ -0,0 +1,136 @@
+package data
+
+import (
+	"slices"
+	"strconv"
+)
+
+type JewelRadius struct {
+	Inner float64
+	Outer float64
+	Label string
+}
+
+// Jewel radius indices, these start at 1 to match the radiusIndex jewel data produced by the mod parser
+const (
+	JewelRadiusSmall           = 1
+	JewelRadiusMedium          = 2
+	JewelRadiusLarge           = 3
+	JewelRadiusSmallRing       = 4
+	JewelRadiusMediumRing      = 5
+	JewelRadiusLargeRing       = 6
+	JewelRadiusVeryLargeRing   = 7
+	JewelRadiusMassiveRing     = 8
+	jewelRadiusCount           = 8
+	treeScaleChangeTreeVersion = 3.16
+)
+
+var jewelRadiiLegacy = []JewelRadius{
+	{Inner: 0, Outer: 800, Label: "Small"},
+	{Inner: 0, Outer: 1200, Label: "Medium"},
+	{Inner: 0, Outer: 1500, Label: "Large"},
+	{Inner: 850, Outer: 1100, Label: "Variable"},
+	{Inner: 1150, Outer: 1400, Label: "Variable"},
+	{Inner: 1450, Outer: 1700, Label: "Variable"},
+	{Inner: 1750, Outer: 2050, Label: "Variable"},
+	{Inner: 2050, Outer: 2400, Label: "Variable"},
+}
+
+// The tree was scaled up by 20% in 3.16
+var jewelRadii = []JewelRadius{
+	{Inner: 0, Outer: 960, Label: "Small"},
+	{Inner: 0, Outer: 1440, Label: "Medium"},
+	{Inner: 0, Outer: 1800, Label: "Large"},
+	{Inner: 960, Outer: 1320, Label: "Variable"},
+	{Inner: 1320, Outer: 1680, Label: "Variable"},
+	{Inner: 1680, Outer: 2040, Label: "Variable"},
+	{Inner: 2040, Outer: 2400, Label: "Variable"},
+	{Inner: 2400, Outer: 2880, Label: "Variable"},
+}
+
+// JewelRadius returns the radius for a radius index, or false if there is no such radius
+func (v *TreeVersionData) JewelRadius(radiusIndex int) (JewelRadius, bool) {
+	if radiusIndex < 1 || radiusIndex > jewelRadiusCount {
+		return JewelRadius{}, false
+	}
+
+	if v.Num < treeScaleChangeTreeVersion {
+		return jewelRadiiLegacy[radiusIndex-1], true
+	}
+
+	return jewelRadii[radiusIndex-1], true
+}
+
+// NodesInRadius returns all nodes within the radius of the jewel socket or keystone.
+// Masteries and proxy nodes are never in radius.
+func (v *TreeVersionData) NodesInRadius(socketID int64, radiusIndex int) []int64 {
+	if radiusIndex < 1 || radiusIndex > jewelRadiusCount {
+		return nil
+	}
+
+	radii, ok := v.geometry().nodesInRadius[socketID]
+	if !ok {
+		return nil
+	}
+
+	return radii[radiusIndex-1]
+}
+
+func (v *TreeVersionData) calculateNodesInRadius(layouts map[int64]NodeLayout) map[int64][][]int64 {
+	tree := v.Tree()
+
+	nodesInRadius := make(map[int64][][]int64)
+	for _, socket := range tree.Nodes {
+		// Keystones are the center of the radius of Impossible Escape
+		isSocket := socket.IsJewelSocket != nil && *socket.IsJewelSocket
+		isKeystone := socket.IsKeystone != nil && *socket.IsKeystone
+		if socket.Skill == nil || (!isSocket && !isKeystone) {
+			continue
+		}
+
+		socketLayout, ok := layouts[*socket.Skill]
+		if !ok {
+			continue
+		}
+
+		radii := make([][]int64, jewelRadiusCount)
+		for i := range radii {
+			radii[i] = make([]int64, 0)
+		}
+
+		for _, node := range tree.Nodes {
+			if node.Skill == nil || *node.Skill == *socket.Skill {
+				continue
+			}
+
+			if (node.IsMastery != nil && *node.IsMastery) || (node.IsProxy != nil && *node.IsProxy) {
+				continue
+			}
+
+			layout, ok := layouts[*node.Skill]
+			if !ok {
+				continue
+			}
+
+			if group := tree.Groups[strconv.FormatInt(*node.Group, 10)]; group.IsProxy != nil && *group.IsProxy {
+				continue
+			}
+
+			distanceSquared := layout.Position.distanceSquared(socketLayout.Position)
+			for i := range radii {
+				radius, _ := v.JewelRadius(i + 1)
+				if radius.Inner*radius.Inner <= distanceSquared && distanceSquared <= radius.Outer*radius.Outer {
+					radii[i] = append(radii[i], *node.Skill)
+				}
+			}
+		}
+
+		for _, nodes := range radii {
+			slices.Sort(nodes)
+		}
+
+		nodesInRadius[*socket.Skill] = radii
+	}
+
+	return nodesInRadius
+}
@@ -0,0 +1,38 @@
+package data
+
+import (
+	"slices"
+	"testing"
+
+	"github.com/MarvinJWendt/testza"
+)
+
+func TestNodesInRadius(t *testing.T) {
+	version := TreeVersions[LatestTreeVersion]
+
+	small := version.NodesInRadius(61834, JewelRadiusSmall)
+	medium := version.NodesInRadius(61834, JewelRadiusMedium)
+	large := version.NodesInRadius(61834, JewelRadiusLarge)
+	ring := version.NodesInRadius(61834, JewelRadiusSmallRing)
+
+	// Blood Drinker and Mind Drinker
+	testza.AssertTrue(t, slices.Contains(small, 27788))
+	testza.AssertTrue(t, slices.Contains(small, 42804))
+
+	for _, node := range small {
+		testza.AssertTrue(t, slices.Contains(medium, node))
+		testza.AssertFalse(t, slices.Contains(ring, node))
+	}
+
+	for _, node := range medium {
+		testza.AssertTrue(t, slices.Contains(large, node))
+	}
+
+	testza.AssertGreater(t, len(medium), len(small))
+	testza.AssertGreater(t, len(large), len(medium))
+	testza.AssertNotZero(t, len(ring))
+
+	// Not a jewel socket
+	testza.AssertNil(t, version.NodesInRadius(27788, JewelRadiusSmall))
+	testza.AssertNil(t, version.NodesInRadius(61834, 0))
+}
@@ -1,6 +1,12 @@
 package data
 
-import "container/heap"
+import (
+	"container/heap"
+	"iter"
+	"maps"
+	"slices"
+	"strconv"
+)
 
 type SearchState struct {
 	frontier  []int64
@@ -45,10 +51,13 @@ func (f *SearchState) Pop() any {
 // run when there are multiple shortest paths (this property is important to prevent
 // the skill tree UI from flip-flopping between options as users allocate nodes).
 //
+// The graph extensions of the build, like cluster jewel subgraphs, are traversed as part of the tree.
+//
 // Requires a single BFS of the tree, + a heap push/pop pair per node.
 // Time complexity: O(V * log(V) + E)
-func (v *TreeVersionData) CalculateAllocationPaths(activeNodes []int64, rootNodes []int64) map[int64]int64 {
+func (v *TreeVersionData) CalculateAllocationPaths(activeNodes []int64, rootNodes []int64, extensions ...GraphExtension) map[int64]int64 {
 	_, adjacencyMap := v.getGraph()
+	extraEdges := extensionEdges(extensions)
 
 	state := SearchState{
 		frontier:  make([]int64, len(activeNodes)+len(rootNodes)),
@@ -83,7 +92,7 @@ func (v *TreeVersionData) CalculateAllocationPaths(activeNodes []int64, rootNode
 		currentNode := heap.Pop(&state).(int64)
 		currentDistance := state.distances[currentNode]
 
-		for adjacency := range adjacencyMap[currentNode] {
+		for adjacency := range adjacentNodes(adjacencyMap, extraEdges, currentNode) {
 			_, alreadyVisited := state.distances[adjacency]
 			if alreadyVisited {
 				// Visiting in heap order means that we can assume
@@ -100,3 +109,260 @@ func (v *TreeVersionData) CalculateAllocationPaths(activeNodes []int64, rootNode
 
 	return state.nextHops
 }
+
+// RootNodes returns the nodes that can be allocated without being connected to
+// any other allocated node: the neighbours of the class start node and the start
+// node of the ascendancy (if any).
+func (v *TreeVersionData) RootNodes(class ClassName, ascendancy AscendancyName) []int64 {
+	tree := v.Tree()
+	classIndex := int64(ClassIDs[class])
+
+	roots := make([]int64, 0)
+	for _, node := range tree.Nodes {
+		if node.Skill == nil {
+			continue
+		}
+
+		if node.ClassStartIndex != nil && *node.ClassStartIndex == classIndex {
+			for _, out := range slices.Concat(node.Out, node.In) {
+				target, ok := tree.Nodes[out]
+				if !ok || target.Skill == nil || target.AscendancyName != nil {
+					continue
+				}
+				roots = append(roots, *target.Skill)
+			}
+		}
+
+		if ascendancy != "" && node.IsAscendancyStart != nil && *node.IsAscendancyStart &&
+			node.AscendancyName != nil && *node.AscendancyName == string(ascendancy) {
+			roots = append(roots, *node.Skill)
+		}
+	}
+
+	slices.Sort(roots)
+	return slices.Compact(roots)
+}
+
+// DisconnectedNodes returns all nodes that can not be reached from any of the
+// allocated root nodes by only traversing allocated nodes. Class start nodes
+// are never reported, as they are not part of the graph.
+func (v *TreeVersionData) DisconnectedNodes(activeNodes []int64, rootNodes []int64, extensions ...GraphExtension) []int64 {
+	_, adjacencyMap := v.getGraph()
+	extraEdges := extensionEdges(extensions)
+
+	active := make(map[int64]bool, len(activeNodes))
+	for _, node := range activeNodes {
+		active[node] = true
+	}
+
+	visited := make(map[int64]bool, len(activeNodes))
+	queue := make([]int64, 0, len(activeNodes))
+	for _, root := range rootNodes {
+		if active[root] && !visited[root] {
+			visited[root] = true
+			queue = append(queue, root)
+		}
+	}
+
+	for len(queue) > 0 {
+		current := queue[0]
+		queue = queue[1:]
+
+		for adjacency := range adjacentNodes(adjacencyMap, extraEdges, current) {
+			if active[adjacency] && !visited[adjacency] {
+				visited[adjacency] = true
+				queue = append(queue, adjacency)
+			}
+		}
+	}
+
+	tree := v.Tree()
+	disconnected := make([]int64, 0)
+	for _, node := range activeNodes {
+		if visited[node] {
+			continue
+		}
+
+		if treeNode, ok := tree.Nodes[strconv.FormatInt(node, 10)]; ok && treeNode.ClassStartIndex != nil {
+			continue
+		}
+
+		disconnected = append(disconnected, node)
+	}
+
+	slices.Sort(disconnected)
+	return slices.Compact(disconnected)
+}
+
+// adjacentNodes iterates the neighbours of the node in the tree and the extra edges
+func adjacentNodes[E any](adjacencyMap map[int64]map[int64]E, extraEdges map[int64][]int64, node int64) iter.Seq[int64] {
+	return func(yield func(int64) bool) {
+		for adjacency := range adjacencyMap[node] {
+			if !yield(adjacency) {
+				return
+			}
+		}
+
+		for _, adjacency := range extraEdges[node] {
+			if !yield(adjacency) {
+				return
+			}
+		}
+	}
+}
+
+type SteinerTree struct {
+	// Nodes to allocate in order, every node is connected to the tree once all previous nodes are allocated
+	Nodes []int64
+
+	// Amount of points required to allocate all nodes
+	Cost int
+
+	// Target nodes that can not be reached from the tree at all
+	Unreachable []int64
+}
+
+// CalculateSteinerTree approximates the cheapest set of nodes that connects all
+// target nodes to the active tree, using the shortest path heuristic: the nearest
+// unconnected target is connected via its shortest path until all targets are
+// connected, after which nodes that are no longer required are pruned.
+//
+// As it works on the same directed graph as CalculateAllocationPaths, masteries
+// are never used to path through, while the graph extensions of the build are.
+//
+// Time complexity: O(T * (V * log(V) + E)) for T target nodes
+func (v *TreeVersionData) CalculateSteinerTree(activeNodes []int64, rootNodes []int64, targetNodes []int64, extensions ...GraphExtension) *SteinerTree {
+	tree := &SteinerTree{
+		Nodes:       make([]int64, 0),
+		Unreachable: make([]int64, 0),
+	}
+
+	selected := make(map[int64]bool, len(activeNodes))
+	for _, node := range activeNodes {
+		selected[node] = true
+	}
+
+	remaining := make(map[int64]bool, len(targetNodes))
+	for _, node := range targetNodes {
+		if !selected[node] {
+			remaining[node] = true
+		}
+	}
+
+	added := make([]int64, 0)
+	for len(remaining) > 0 {
+		nextHops := v.CalculateAllocationPaths(slices.Collect(maps.Keys(selected)), rootNodes, extensions...)
+
+		// Connect the nearest remaining target, using the node ID as a tiebreaker
+		var path []int64
+		for _, target := range slices.Sorted(maps.Keys(remaining)) {
+			candidate, ok := followNextHops(nextHops, selected, target)
+			if !ok {
+				tree.Unreachable = append(tree.Unreachable, target)
+				delete(remaining, target)
+				continue
+			}
+
+			if path == nil || len(candidate) < len(path) {
+				path = candidate
+			}
+		}
+
+		if path == nil {
+			break
+		}
+
+		for _, node := range path {
+			selected[node] = true
+			delete(remaining, node)
+		}
+
+		added = append(added, path...)
+	}
+
+	targets := make(map[int64]bool, len(targetNodes))
+	for _, node := range targetNodes {
+		targets[node] = true
+	}
+
+	// Later paths can make nodes of earlier paths redundant
+	baseline := len(v.DisconnectedNodes(activeNodes, rootNodes, extensions...))
+	for i := len(added) - 1; i >= 0; i-- {
+		node := added[i]
+		if targets[node] {
+			continue
+		}
+
+		delete(selected, node)
+		if len(v.DisconnectedNodes(slices.Collect(maps.Keys(selected)), rootNodes, extensions...)) > baseline {
+			selected[node] = true
+		}
+	}
+
+	tree.Nodes = v.allocationOrder(activeNodes, rootNodes, selected, extensionEdges(extensions))
+	tree.Cost = len(tree.Nodes)
+
+	slices.Sort(tree.Unreachable)
+
+	return tree
+}
+
+// followNextHops walks the next hops from CalculateAllocationPaths from the target
+// towards the selected nodes, returning all unselected nodes on the way
+func followNextHops(nextHops map[int64]int64, selected map[int64]bool, target int64) ([]int64, bool) {
+	path := make([]int64, 0)
+	for current := target; current != -1; {
+		next, ok := nextHops[current]
+		if !ok {
+			return nil, false
+		}
+
+		if !selected[current] {
+			path = append(path, current)
+		}
+
+		current = next
+	}
+
+	slices.Reverse(path)
+	return path, true
+}
+
+// allocationOrder returns all selected nodes that are not active yet, ordered such
+// that every node is adjacent to an active, root or previously returned node
+func (v *TreeVersionData) allocationOrder(activeNodes []int64, rootNodes []int64, selected map[int64]bool, extraEdges map[int64][]int64) []int64 {
+	_, adjacencyMap := v.getGraph()
+
+	visited := make(map[int64]bool, len(selected))
+	queue := make([]int64, 0, len(selected))
+	for _, node := range activeNodes {
+		visited[node] = true
+		queue = append(queue, node)
+	}
+
+	order := make([]int64, 0)
+	visit := func(node int64) {
+		visited[node] = true
+		queue = append(queue, node)
+		order = append(order, node)
+	}
+
+	for _, root := range rootNodes {
+		if selected[root] && !visited[root] {
+			visit(root)
+		}
+	}
+
+	for len(queue) > 0 {
+		current := queue[0]
+		queue = queue[1:]
+
+		for _, adjacency := range slices.Sorted(adjacentNodes(adjacencyMap, extraEdges, current)) {
+			if selected[adjacency] && !visited[adjacency] {
+				visit(adjacency)
+			}
+		}
+	}
+
+	return order
+}
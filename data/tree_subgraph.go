@@ -0,0 +1,399 @@
+package data
+
+import (
+	"errors"
+	"fmt"
+	"slices"
+	"strconv"
+
+	"github.com/Vilsol/go-pob/utils"
+)
+
+// Subgraph node IDs are composed of:
+//
+//	0-3 = node index (0-11)
+//	4-5 = group size (0-2)
+//	6-8 = large socket index (0-5)
+//	9-10 = medium socket index (0-2)
+//	16 = always set, as tree node IDs always fit in 16 bits
+const subgraphNodeIDSignal = 0x10000
+
+// IsSubgraphNode returns whether the node ID belongs to a node generated by a cluster jewel
+func IsSubgraphNode(nodeID int64) bool {
+	return nodeID&subgraphNodeIDSignal != 0
+}
+
+// ClusterJewelSpec describes the cluster jewel socketed into a socket
+type ClusterJewelSpec struct {
+	BaseName string
+
+	// Amount of added passives including sockets, the maximum of the base if zero
+	NodeCount int
+
+	SocketCount      int
+	NothingnessCount int
+
+	// Skill of the small passives, see ClusterJewelSkill.ID
+	Skill string
+
+	Notables []string
+	Keystone string
+
+	SmallsAreNothingness bool
+
+	// Additional stats granted by the small passives
+	AddedMods []string
+
+	// Increased effect of the small passives
+	IncEffect float64
+}
+
+type Subgraph struct {
+	SocketID     int64
+	EntranceNode int64
+	Nodes        map[int64]Node
+	Edges        [][2]int64
+
+	// Increased effect of the small passives
+	IncEffect float64
+}
+
+// BuildSubgraph generates the nodes of the cluster jewel socketed into the socket.
+//
+// Jewel sockets of the cluster are the existing sockets of the tree in the group of the socket's proxy and are only
+// linked to, all other nodes get generated IDs and are positioned on the orbit of the proxy node.
+func (v *TreeVersionData) BuildSubgraph(socketID int64, jewel ClusterJewelSpec) (*Subgraph, error) {
+	tree := v.Tree()
+
+	socket, ok := tree.Nodes[strconv.FormatInt(socketID, 10)]
+	if !ok || socket.ExpansionJewel == nil {
+		return nil, fmt.Errorf("node %d is not a cluster jewel socket", socketID)
+	}
+
+	clusterJewels, err := v.ClusterJewels()
+	if err != nil {
+		return nil, err
+	}
+
+	clusterJewel, ok := clusterJewels.Jewels[jewel.BaseName]
+	if !ok {
+		return nil, fmt.Errorf("unknown cluster jewel base: %s", jewel.BaseName)
+	}
+
+	if int64(clusterJewel.Size) > socket.ExpansionJewel.Size {
+		return nil, fmt.Errorf("%s does not fit into socket %d", jewel.BaseName, socketID)
+	}
+
+	proxy, proxyGroup, err := v.subgraphProxy(socket, clusterJewel.Size)
+	if err != nil {
+		return nil, err
+	}
+
+	subgraph := &Subgraph{
+		SocketID:  socketID,
+		Nodes:     make(map[int64]Node),
+		Edges:     make([][2]int64, 0),
+		IncEffect: jewel.IncEffect,
+	}
+
+	nodeID := subgraphNodeIDSignal + v.subgraphIDBits(socket) + int64(clusterJewel.Size)<<4
+
+	if jewel.Keystone != "" {
+		keystone, ok := clusterJewels.Keystones[jewel.Keystone]
+		if !ok {
+			return nil, fmt.Errorf("unknown cluster jewel keystone: %s", jewel.Keystone)
+		}
+
+		// Keystones replace the whole cluster and sit in the centre of the group
+		node := subgraphNode(keystone, nodeID, *proxy.Group, 0, 0)
+		subgraph.addNode(node)
+		subgraph.EntranceNode = nodeID
+		subgraph.link(nodeID, socketID)
+
+		return subgraph, nil
+	}
+
+	nodeCount := jewel.NodeCount
+	if nodeCount <= 0 {
+		nodeCount = clusterJewel.MaxNodes
+	}
+
+	notables := make([]string, 0, len(jewel.Notables))
+	for _, name := range jewel.Notables {
+		if _, ok := clusterJewels.Notables[name]; ok {
+			notables = append(notables, name)
+		}
+	}
+
+	slices.SortStableFunc(notables, func(a, b string) int {
+		return clusterJewels.NotableSortOrder[a] - clusterJewels.NotableSortOrder[b]
+	})
+
+	socketCount := jewel.SocketCount
+	smallCount := nodeCount - socketCount - len(notables) - jewel.NothingnessCount
+
+	indices := make(map[int]Node)
+
+	// Jewel sockets
+	if clusterJewel.Size == ClusterJewelSizeLarge && socketCount == 1 {
+		// A single socket on a large cluster is always the middle one
+		if node, ok := v.findGroupSocket(proxyGroup, 1); ok {
+			indices[6] = node
+		}
+	} else {
+		// The outer sockets are filled before the middle one
+		socketOrder := []int64{0, 2, 1}
+		for i := 0; i < socketCount && i < len(clusterJewel.SocketIndices); i++ {
+			if node, ok := v.findGroupSocket(proxyGroup, socketOrder[i]); ok {
+				indices[clusterJewel.SocketIndices[i]] = node
+			}
+		}
+	}
+
+	// Notables
+	notableIndices := make([]int, 0, len(notables))
+	for _, index := range clusterJewel.NotableIndices {
+		if len(notableIndices) == len(notables) {
+			break
+		}
+
+		if clusterJewel.Size == ClusterJewelSizeMedium {
+			if socketCount == 0 && len(notables) == 2 {
+				// Two notables on a medium cluster are placed opposite of each other
+				switch index {
+				case 6:
+					index = 4
+				case 10:
+					index = 8
+				}
+			} else if nodeCount == 4 {
+				switch index {
+				case 10:
+					index = 9
+				case 2:
+					index = 3
+				}
+			}
+		}
+
+		if _, ok := indices[index]; !ok {
+			notableIndices = append(notableIndices, index)
+		}
+	}
+
+	slices.Sort(notableIndices)
+	for i, index := range notableIndices {
+		notable := clusterJewels.Notables[notables[i]]
+		indices[index] = subgraphNode(notable, nodeID+int64(index), *proxy.Group, *proxy.Orbit, int64(index))
+	}
+
+	// Small passives, nothingness last
+	skill, hasSkill := clusterJewel.Skills[jewel.Skill]
+	smallIndices := make([]int, 0, smallCount+jewel.NothingnessCount)
+	for _, index := range clusterJewel.SmallIndices {
+		if len(smallIndices) == max(smallCount, 0)+jewel.NothingnessCount {
+			break
+		}
+
+		if clusterJewel.Size == ClusterJewelSizeMedium {
+			if nodeCount == 5 && index == 4 {
+				index = 3
+			} else if nodeCount == 4 {
+				switch index {
+				case 8:
+					index = 9
+				case 4:
+					index = 3
+				}
+			}
+		}
+
+		if _, ok := indices[index]; !ok {
+			smallIndices = append(smallIndices, index)
+		}
+	}
+
+	for i, index := range smallIndices {
+		node := Node{
+			Name:  utils.Ptr("Nothingness"),
+			Stats: make([]string, 0),
+		}
+
+		if hasSkill && i < smallCount {
+			node.Name = utils.Ptr(skill.Name)
+			node.Icon = skill.Icon
+
+			if !jewel.SmallsAreNothingness {
+				node.Stats = slices.Concat(skill.Stats, jewel.AddedMods)
+			}
+		}
+
+		indices[index] = subgraphNode(node, nodeID+int64(index), *proxy.Group, *proxy.Orbit, int64(index))
+	}
+
+	entrance, ok := indices[0]
+	if !ok {
+		return nil, errors.New("no entrance to subgraph")
+	}
+
+	// Move the nodes relative to the orbit index of the proxy, translating between the indices of the jewel and the orbit
+	orbitSize := int(tree.Constants.SkillsPerOrbit[*proxy.Orbit])
+	proxyIndex := translateOrbitIndex(int(*proxy.OrbitIndex), orbitSize, clusterJewel.TotalIndices)
+	for index, node := range indices {
+		if !IsSubgraphNode(*node.Skill) {
+			// Jewel sockets are part of the tree already
+			continue
+		}
+
+		orbitIndex := translateOrbitIndex((index+proxyIndex)%clusterJewel.TotalIndices, clusterJewel.TotalIndices, orbitSize)
+		node.Group = proxy.Group
+		node.Orbit = proxy.Orbit
+		node.OrbitIndex = utils.Ptr(int64(orbitIndex))
+		node.Out = make([]string, 0)
+		node.In = make([]string, 0)
+		subgraph.addNode(node)
+	}
+
+	subgraph.EntranceNode = *entrance.Skill
+	subgraph.link(*entrance.Skill, socketID)
+
+	var first, last *int64
+	for index := 0; index < clusterJewel.TotalIndices; index++ {
+		node, ok := indices[index]
+		if !ok {
+			continue
+		}
+
+		if first == nil {
+			first = node.Skill
+		}
+
+		if last != nil {
+			subgraph.link(*last, *node.Skill)
+		}
+
+		last = node.Skill
+	}
+
+	// Medium and large clusters form a loop
+	if clusterJewel.Size != ClusterJewelSizeSmall && *first != *last {
+		subgraph.link(*last, *first)
+	}
+
+	return subgraph, nil
+}
+
+// subgraphProxy returns the proxy node and group to position the cluster on, using the
+// proxies of the nested sockets if the jewel is smaller than the socket
+func (v *TreeVersionData) subgraphProxy(socket Node, size ClusterJewelSize) (Node, Group, error) {
+	tree := v.Tree()
+
+	proxy, ok := tree.Nodes[socket.ExpansionJewel.Proxy]
+	if !ok || proxy.Group == nil || proxy.Orbit == nil || proxy.OrbitIndex == nil {
+		return Node{}, Group{}, fmt.Errorf("proxy of socket %d not found", *socket.Skill)
+	}
+
+	proxyGroup := tree.Groups[strconv.FormatInt(*proxy.Group, 10)]
+	for groupSize := socket.ExpansionJewel.Size; int64(size) < groupSize; {
+		// Prefer the middle socket of large groups
+		nested, ok := v.findGroupSocket(proxyGroup, 1)
+		if !ok {
+			nested, ok = v.findGroupSocket(proxyGroup, 0)
+		}
+
+		if !ok {
+			return Node{}, Group{}, fmt.Errorf("no socket to downsize socket %d into", *socket.Skill)
+		}
+
+		proxy = tree.Nodes[nested.ExpansionJewel.Proxy]
+		proxyGroup = tree.Groups[strconv.FormatInt(*proxy.Group, 10)]
+		groupSize = nested.ExpansionJewel.Size
+	}
+
+	return proxy, proxyGroup, nil
+}
+
+// subgraphIDBits returns the socket indices of the socket and all its parent sockets as used in subgraph node IDs
+func (v *TreeVersionData) subgraphIDBits(socket Node) int64 {
+	tree := v.Tree()
+
+	bits := int64(0)
+	for node, ok := socket, true; ok && node.ExpansionJewel != nil; {
+		switch node.ExpansionJewel.Size {
+		case int64(ClusterJewelSizeLarge):
+			bits += node.ExpansionJewel.Index << 6
+		case int64(ClusterJewelSizeMedium):
+			bits += node.ExpansionJewel.Index << 9
+		}
+
+		if node.ExpansionJewel.Parent == nil {
+			break
+		}
+
+		node, ok = tree.Nodes[*node.ExpansionJewel.Parent]
+	}
+
+	return bits
+}
+
+// findGroupSocket returns the socket with the provided expansion index in the group
+func (v *TreeVersionData) findGroupSocket(group Group, index int64) (Node, bool) {
+	for _, nodeID := range group.Nodes {
+		node, ok := v.Tree().Nodes[nodeID]
+		if ok && node.ExpansionJewel != nil && node.ExpansionJewel.Index == index {
+			return node, true
+		}
+	}
+	return Node{}, false
+}
+
+// subgraphNode copies the base node to a new position
+func subgraphNode(base Node, id int64, group int64, orbit int64, orbitIndex int64) Node {
+	node := base
+	node.Skill = utils.Ptr(id)
+	node.Group = utils.Ptr(group)
+	node.Orbit = utils.Ptr(orbit)
+	node.OrbitIndex = utils.Ptr(orbitIndex)
+	node.Stats = slices.Clone(base.Stats)
+	node.Out = make([]string, 0)
+	node.In = make([]string, 0)
+	return node
+}
+
+// translateOrbitIndex translates an orbit index between orbits with a different amount of nodes
+func translateOrbitIndex(index int, from int, to int) int {
+	switch {
+	case from == to:
+		return index
+	case from == 12 && to == 16:
+		return []int{0, 1, 3, 4, 5, 7, 8, 9, 11, 12, 13, 15}[index]
+	case from == 16 && to == 12:
+		return []int{0, 1, 1, 2, 3, 4, 4, 5, 6, 7, 7, 8, 9, 10, 10, 11}[index]
+	default:
+		return index * to / from
+	}
+}
+
+func (s *Subgraph) addNode(node Node) {
+	s.Nodes[*node.Skill] = node
+}
+
+// link connects both nodes in both directions, the parent socket is not part of the subgraph
+func (s *Subgraph) link(a int64, b int64) {
+	s.Edges = append(s.Edges, [2]int64{a, b})
+
+	for _, pair := range [][2]int64{{a, b}, {b, a}} {
+		if node, ok := s.Nodes[pair[0]]; ok {
+			node.Out = append(node.Out, strconv.FormatInt(pair[1], 10))
+			s.Nodes[pair[0]] = node
+		}
+	}
+}
+
+// extendGraph links the subgraph nodes to each other and to the sockets they're connected to
+func (s *Subgraph) extendGraph(edges map[int64][]int64) {
+	for _, edge := range s.Edges {
+		edges[edge[0]] = append(edges[edge[0]], edge[1])
+		edges[edge[1]] = append(edges[edge[1]], edge[0])
+	}
+}
@@ -0,0 +1,181 @@
+package data
+
+import (
+	"maps"
+	"slices"
+	"testing"
+
+	"github.com/MarvinJWendt/testza"
+)
+
+func TestClusterJewels(t *testing.T) {
+	clusterJewels, err := TreeVersions[TreeVersion3_18].ClusterJewels()
+	testza.AssertNoError(t, err)
+
+	testza.AssertLen(t, clusterJewels.Jewels, 3)
+
+	large := clusterJewels.JewelBySize(ClusterJewelSizeLarge)
+	testza.AssertNotNil(t, large)
+	testza.AssertEqual(t, "Large Cluster Jewel", large.BaseName)
+	testza.AssertEqual(t, 12, large.TotalIndices)
+	testza.AssertEqual(t, []string{"Added Small Passive Skills grant: 12% increased Fire Damage"}, large.Skills["affliction_fire_damage"].Enchant)
+
+	_, ok := clusterJewels.Notables["Cooked Alive"]
+	testza.AssertTrue(t, ok)
+
+	_, ok = clusterJewels.Keystones["Kineticism"]
+	testza.AssertTrue(t, ok)
+}
+
+func TestBuildSubgraph(t *testing.T) {
+	tests := []struct {
+		name     string
+		socket   int64
+		jewel    ClusterJewelSpec
+		entrance int64
+		nodes    []int64
+		sockets  []int64
+		notables map[int64]string
+	}{
+		{
+			name:   "Large",
+			socket: 32763,
+			jewel: ClusterJewelSpec{
+				BaseName:    "Large Cluster Jewel",
+				NodeCount:   8,
+				SocketCount: 2,
+				Skill:       "affliction_fire_damage",
+				Notables:    []string{"Burning Bright", "Cooked Alive"},
+			},
+			entrance: 65824,
+			nodes:    []int64{65824, 65826, 65829, 65830, 65831, 65834},
+			sockets:  []int64{6910, 33753},
+			notables: map[int64]string{65830: "Cooked Alive", 65834: "Burning Bright"},
+		},
+		{
+			name:   "LargeSingleSocket",
+			socket: 32763,
+			jewel: ClusterJewelSpec{
+				BaseName:    "Large Cluster Jewel",
+				NodeCount:   8,
+				SocketCount: 1,
+				Skill:       "affliction_fire_damage",
+			},
+			entrance: 65824,
+			nodes:    []int64{65824, 65826, 65828, 65829, 65831, 65832, 65834},
+			sockets:  []int64{49684},
+		},
+		{
+			name:   "MediumInLargeSocket",
+			socket: 32763,
+			jewel: ClusterJewelSpec{
+				BaseName:    "Medium Cluster Jewel",
+				NodeCount:   5,
+				SocketCount: 1,
+				Skill:       "affliction_flask_duration",
+			},
+			entrance: 65808,
+			nodes:    []int64{65808, 65811, 65816, 65818},
+			sockets:  []int64{11150},
+		},
+		{
+			name:   "Small",
+			socket: 12161,
+			jewel: ClusterJewelSpec{
+				BaseName: "Small Cluster Jewel",
+				Skill:    "affliction_maximum_life",
+			},
+			entrance: 66112,
+			nodes:    []int64{66112, 66114, 66116},
+		},
+		{
+			name:   "Keystone",
+			socket: 6910,
+			jewel: ClusterJewelSpec{
+				BaseName: "Medium Cluster Jewel",
+				Keystone: "Kineticism",
+			},
+			entrance: 65808,
+			nodes:    []int64{65808},
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			subgraph, err := TreeVersions[TreeVersion3_18].BuildSubgraph(test.socket, test.jewel)
+			testza.AssertNoError(t, err)
+
+			testza.AssertEqual(t, test.entrance, subgraph.EntranceNode)
+			testza.AssertEqual(t, test.nodes, slices.Sorted(maps.Keys(subgraph.Nodes)))
+			testza.AssertContains(t, subgraph.Edges, [2]int64{test.entrance, test.socket})
+
+			for _, id := range test.nodes {
+				testza.AssertTrue(t, IsSubgraphNode(id))
+			}
+
+			for _, socket := range test.sockets {
+				linked := 0
+				for _, edge := range subgraph.Edges {
+					if edge[0] == socket || edge[1] == socket {
+						linked++
+					}
+				}
+				testza.AssertEqual(t, 2, linked, "Nested sockets should be part of the loop")
+			}
+
+			for id, name := range test.notables {
+				testza.AssertEqual(t, name, *subgraph.Nodes[id].Name)
+			}
+		})
+	}
+}
+
+func TestBuildSubgraphErrors(t *testing.T) {
+	_, err := TreeVersions[TreeVersion3_18].BuildSubgraph(7112, ClusterJewelSpec{BaseName: "Small Cluster Jewel"})
+	testza.AssertNotNil(t, err, "Regular nodes are not cluster jewel sockets")
+
+	_, err = TreeVersions[TreeVersion3_18].BuildSubgraph(12161, ClusterJewelSpec{BaseName: "Large Cluster Jewel"})
+	testza.AssertNotNil(t, err, "Large cluster jewels do not fit into small sockets")
+
+	_, err = TreeVersions[TreeVersion3_18].BuildSubgraph(32763, ClusterJewelSpec{BaseName: "Viridian Jewel"})
+	testza.AssertNotNil(t, err, "Regular jewels are not cluster jewels")
+}
+
+func TestSubgraphOrbitIndices(t *testing.T) {
+	subgraph, err := TreeVersions[TreeVersion3_18].BuildSubgraph(32763, ClusterJewelSpec{
+		BaseName:    "Large Cluster Jewel",
+		NodeCount:   8,
+		SocketCount: 2,
+		Skill:       "affliction_fire_damage",
+	})
+	testza.AssertNoError(t, err)
+
+	// The proxy 48132 of the socket is at orbit 3 index 12, which the entrance node takes over
+	entrance := subgraph.Nodes[subgraph.EntranceNode]
+	testza.AssertEqual(t, int64(3), *entrance.Orbit)
+	testza.AssertEqual(t, int64(12), *entrance.OrbitIndex)
+	testza.AssertEqual(t, int64(632), *entrance.Group)
+
+	// Cluster index 6 is halfway around the orbit
+	testza.AssertEqual(t, int64(4), *subgraph.Nodes[subgraph.EntranceNode+6].OrbitIndex)
+}
+
+func TestCalculateAllocationPathsSubgraph(t *testing.T) {
+	subgraph, err := TreeVersions[TreeVersion3_18].BuildSubgraph(32763, ClusterJewelSpec{
+		BaseName:    "Large Cluster Jewel",
+		NodeCount:   8,
+		SocketCount: 2,
+		Skill:       "affliction_fire_damage",
+	})
+	testza.AssertNoError(t, err)
+
+	actual := TreeVersions[TreeVersion3_18].CalculateAllocationPaths([]int64{32763}, []int64{}, subgraph)
+	testza.AssertEqual(t, int64(32763), actual[subgraph.EntranceNode], "Entrance should connect to the socket")
+	testza.AssertEqual(t, subgraph.EntranceNode, actual[subgraph.EntranceNode+2])
+
+	_, ok := TreeVersions[TreeVersion3_18].CalculateAllocationPaths([]int64{32763}, []int64{})[subgraph.EntranceNode]
+	testza.AssertFalse(t, ok, "Subgraph nodes should only be reachable with the subgraph")
+
+	testza.AssertEqual(t, []int64{}, TreeVersions[TreeVersion3_18].DisconnectedNodes([]int64{32763, subgraph.EntranceNode}, []int64{32763}, subgraph))
+	testza.AssertEqual(t, []int64{subgraph.EntranceNode}, TreeVersions[TreeVersion3_18].DisconnectedNodes([]int64{32763, subgraph.EntranceNode}, []int64{32763}))
+}
@@ -9,6 +9,7 @@ import (
 	"log/slog"
 	"net/http"
 	"strconv"
+	"sync"
 
 	"github.com/Vilsol/go-pob/cache"
 	"github.com/andybalholm/brotli"
@@ -40,10 +41,28 @@ type TreeVersionData struct {
 	rawTree      []byte
 	graph        graph.Graph[int64, int64]
 	adjacencyMap map[int64]map[int64]graph.Edge[int64]
+
+	geometryOnce   sync.Once
+	cachedGeometry *treeGeometry
+
+	clusterJewelsMu     sync.Mutex
+	cachedClusterJewels *ClusterJewelData
+
+	timelessJewelsMu     sync.Mutex
+	cachedTimelessJewels *TimelessJewelData
 }
 
 const cdnTreeBase = "https://go-pob-data.pages.dev/data/%s/tree/data.json.br"
 
+// NewTreeVersionData creates a tree version from raw tree data, for trees that are not fetched from the CDN
+func NewTreeVersionData(display string, num float64, rawTree []byte) *TreeVersionData {
+	return &TreeVersionData{
+		Display: display,
+		Num:     num,
+		rawTree: rawTree,
+	}
+}
+
 func (v *TreeVersionData) Tree() *Tree {
 	if v.cachedTree != nil {
 		return v.cachedTree
@@ -153,8 +172,8 @@ func (v *TreeVersionData) getGraph() (graph.Graph[int64, int64], map[int64]map[i
 
 	v.graph = g
 
-	// We can pre-calculate the adjacency map, as the graph won't change
-	// (at least, until we add support for thread of hope/impossible escape/etc)
+	// We can pre-calculate the adjacency map, as the graph won't change.
+	// Build specific edges are added on top of it with graph extensions.
 	v.adjacencyMap, _ = v.graph.AdjacencyMap()
 
 	return v.graph, v.adjacencyMap
@@ -22,6 +22,9 @@ class PoBWorker {
 
   booted = false;
 
+  // Edges added by the graph extensions of the spec of the last calculated build, e.g. cluster jewel subgraphs
+  graphEdges: Array<number[] | undefined> = [];
+
   callback?: (out: Outputs) => void;
   currentBuildStore?: typeof currentBuild;
 
@@ -105,6 +108,8 @@ class PoBWorker {
       return;
     }
 
+    this.graphEdges = out.Spec?.GraphEdges() ?? [];
+
     if (this.callback) {
       this.callback({
         Output: out.Player.Output,
@@ -235,7 +240,11 @@ class PoBWorker {
   }
 
   CalculateAllocationPaths(version: string, activeNodes: number[], rootNodes: number[]) {
-    return exposition.CalculateAllocationPaths(version, activeNodes, rootNodes);
+    return exposition.CalculateAllocationPaths(version, activeNodes, rootNodes, this.graphEdges);
+  }
+
+  CalculateSteinerTree(version: string, activeNodes: number[], rootNodes: number[], targetNodes: number[]) {
+    return dump(exposition.CalculateSteinerTree(version, activeNodes, rootNodes, targetNodes, this.graphEdges));
   }
 
   BuildInfo() {
@@ -31,12 +31,23 @@ export declare namespace calculator {
     MinionSkillTypes?: Record<string, boolean>;
     BleedCfg?: moddb.ListCfg;
     OHBleedCfg?: moddb.ListCfg;
+    SkillPart: number;
+    SkillPartName: string;
+  }
+  interface ActiveSkillParts {
+    SocketGroup: number;
+    Gem: number;
+    SkillID: string;
+    Name: string;
+    Parts?: Array<string>;
+    Selected: number;
+    SelectedCalcs: number;
   }
   interface Actor {
     ModDB?: moddb.ModDB;
     Level: number;
     Enemy?: calculator.Actor;
-    ItemList?: Record<string, unknown | undefined>;
+    ItemList?: Record<string, calculator.EquippedItem | undefined>;
     ActiveSkillList?: Array<calculator.ActiveSkill | undefined>;
     Output?: Record<string, number>;
     OutputTable?: Record<string, Record<string, number> | undefined>;
@@ -50,6 +61,13 @@ export declare namespace calculator {
   interface Calculator {
     PoB?: pob.PathOfBuilding;
     BuildOutput(mode: string): Promise<(calculator.Environment | undefined)>;
+    CalculateNodePower(stat: string, progress: (arg1: calculator.NodePowerProgress) => Promise<boolean>): Promise<[(Array<calculator.NodePower> | undefined), Error]>;
+    MigrateSpec(to: string): Promise<[(calculator.SpecMigration | undefined), Error]>;
+    OptimizeTree(options: calculator.OptimizerOptions): Promise<[(calculator.OptimizerResult | undefined), Error]>;
+    RankSupports(socketGroup: number, replaceGem: number): Promise<[(Array<calculator.SupportRanking> | undefined), Error]>;
+    SearchNodes(query: string): Promise<(Array<calculator.NodeSearchResult> | undefined)>;
+    SkillParts(): Promise<(Array<calculator.ActiveSkillParts> | undefined)>;
+    ValidateSpec(): Promise<(Array<calculator.SpecFinding> | undefined)>;
   }
   interface ConversionTable {
     Targets?: Record<string, number>;
@@ -69,8 +87,8 @@ export declare namespace calculator {
     Enemy?: calculator.Actor;
     RequirementsTableItems?: Record<string, unknown | undefined>;
     RequirementsTableGems?: Array<calculator.RequirementsTableGems | undefined>;
-    RadiusJewelList?: Record<string, unknown | undefined>;
-    ExtraRadiusNodeList?: Record<string, unknown | undefined>;
+    RadiusJewelList?: Array<calculator.RadiusJewel | undefined>;
+    ExtraRadiusNodeList?: Record<string, data.Node>;
     GrantedSkills?: Record<string, unknown | undefined>;
     GrantedSkillsNodes?: Record<string, unknown | undefined>;
     GrantedSkillsItems?: Record<string, unknown | undefined>;
@@ -88,6 +106,32 @@ export declare namespace calculator {
   interface EnvironmentCache {
     TreeVersion: string;
   }
+  interface EquippedItem {
+    Item?: items.Item;
+    ID: number;
+    Slot: string;
+    Type: string;
+    ModList?: moddb.ModList;
+    WeaponData?: Record<string, unknown | undefined>;
+    ArmourData?: Record<string, number>;
+    Links(): number;
+    SocketsText(): string;
+    Text(): string;
+  }
+  interface GemCatalogueEntry {
+    ID: string;
+    Name: string;
+    GrantedEffectID: string;
+    Colour: string;
+    Support: boolean;
+    Vaal: boolean;
+    Awakened: boolean;
+    MaxLevel: number;
+    Tags?: Array<string>;
+    SkillTypes?: Array<string>;
+    MinionSkillTypes?: Array<string>;
+    Qualities?: Array<calculator.GemQuality>;
+  }
   interface GemEffect {
     GrantedEffect?: calculator.GrantedEffect;
     Level: number;
@@ -100,35 +144,129 @@ export declare namespace calculator {
     IsSupporting?: Record<pob.Gem | undefined, boolean>;
     Values?: Record<string, number>;
   }
+  interface GemFilter {
+    Name: string;
+    Tags?: Array<string>;
+    Colours?: Array<string>;
+    Support?: boolean;
+    Vaal?: boolean;
+    Awakened?: boolean;
+    SkillTypes?: Array<string>;
+  }
+  interface GemQuality {
+    ID: string;
+    Name: string;
+    Stats?: Record<string, number>;
+  }
   interface GrantedEffect {
     Raw?: poe.GrantedEffect;
-    Parts?: Array<unknown | undefined>;
+    Parts?: Array<raw.SkillPart>;
     SkillTypes?: Record<string, boolean>;
+    MinionSkillTypes?: Record<string, boolean>;
     BaseFlags?: Record<string, boolean>;
     BaseMultiplier(): number;
     CastTime(): number;
     DamageEffectiveness(): number;
     WeaponTypes(): (Array<string> | undefined);
   }
+  interface JewelFuncData {
+    ModSource: string;
+    Stats?: Record<string, number>;
+    Total: number;
+    ModList?: moddb.ModList;
+  }
+  interface JewelFuncNode {
+    ID: string;
+    Node: data.Node;
+    ModList?: moddb.ModList;
+  }
+  interface NodePower {
+    NodeID: number;
+    Delta: number;
+    PathNodes?: Array<number>;
+    PathDelta: number;
+    PerPoint: number;
+  }
+  interface NodePowerProgress {
+    Done: number;
+    Total: number;
+    Batch?: Array<calculator.NodePower>;
+  }
+  interface NodeSearchResult {
+    NodeID: number;
+    Distance: number;
+    Score: number;
+    Matches?: Array<string>;
+  }
+  interface OptimizerConstraint {
+    Stat: string;
+    Min: number;
+  }
+  interface OptimizerOptions {
+    Objective: string;
+    Constraints?: Array<calculator.OptimizerConstraint>;
+    Points: number;
+    AscendancyPoints: number;
+    MaxStepCost: number;
+    ImprovementRounds: number;
+    Workers: number;
+  }
+  interface OptimizerResult {
+    Nodes?: Array<number>;
+    MasteryEffects?: Record<number, number>;
+    Objective: number;
+    Feasible: boolean;
+    Trajectory?: Array<calculator.OptimizerStep>;
+  }
+  interface OptimizerStep {
+    Added?: Array<number>;
+    Removed?: Array<number>;
+    Objective: number;
+    Feasible: boolean;
+  }
   interface PassiveSpec {
     Build?: pob.PathOfBuilding;
     TreeVersion: string;
     Nodes?: Record<string, unknown | undefined>;
     AllocNodes?: Record<string, data.Node>;
-    AllocSubgraphNodes?: Record<string, unknown | undefined>;
     AllocExtendedNodes?: Record<string, unknown | undefined>;
     Jewels?: Record<string, unknown | undefined>;
-    SubGraphs?: Record<string, unknown | undefined>;
-    MasterySelections?: Record<string, unknown | undefined>;
+    SubGraphs?: Record<string, data.Subgraph | undefined>;
+    AllocSubgraphNodes?: Record<string, boolean>;
+    MasterySelections?: Record<string, number>;
+    TimelessJewels?: Record<string, data.TimelessJewel>;
+    ConqueredNodes?: Record<string, string>;
+    TransformedNodes?: Record<string, data.Node>;
+    RadiusExtensions?: Record<string, data.RadiusExtension | undefined>;
     ClassName: string;
     AscendancyName: string;
     AllocatedNotableCount: number;
     AllocatedMasteryCount: number;
+    AvailableMasteryEffects(nodeID: number): (Array<data.MasteryEffect> | undefined);
     Class(): data.Class;
+    DeselectMasteryEffect(nodeID: number): void;
+    GraphEdges(): (Array<Array<number> | undefined> | undefined);
+    GraphExtensions(): (Array<unknown | undefined> | undefined);
+    Node(nodeID: string): [data.Node, boolean];
+    RemoveClusterJewel(socketID: number): void;
+    RemoveIntuitiveLeapJewel(socketID: number): void;
+    RemoveTimelessJewel(socketID: number): void;
     SelectAscendancyClass(ascendancyName: string): void;
     SelectClass(className: string): void;
+    SelectMasteryEffect(nodeID: number, effect: number): Error;
+    SocketClusterJewel(socketID: number, baseName: string, jewelModList?: moddb.ModList): Error;
+    SocketIntuitiveLeapJewel(socketID: number, radiusIndex: number, jewelModList?: moddb.ModList): Error;
+    SocketTimelessJewel(socketID: number, jewelModList?: moddb.ModList): Error;
+    Subgraphs(): (Array<data.Subgraph | undefined> | undefined);
     Tree(): (data.Tree | undefined);
   }
+  interface RadiusJewel {
+    NodeID: string;
+    Nodes?: Record<string, boolean>;
+    Type: string;
+    Func: (arg1?: calculator.JewelFuncNode, arg2?: moddb.ModList, arg3?: calculator.JewelFuncData) => void;
+    Data?: calculator.JewelFuncData;
+  }
   interface RequirementsTableGems {
     Source: string;
     SourceGem: pob.Gem;
@@ -136,12 +274,47 @@ export declare namespace calculator {
     Dex: number;
     Int: number;
   }
+  interface SpecFinding {
+    Kind: string;
+    Nodes?: Array<number>;
+    Used: number;
+    Available: number;
+  }
+  interface SpecMigration {
+    From: string;
+    To: string;
+    Nodes?: Array<number>;
+    MasteryEffects?: Record<number, number>;
+    Remapped?: Record<number, number>;
+    Dropped?: Array<number>;
+    Unreachable?: Array<number>;
+    Added?: Array<number>;
+    DroppedMasteryEffects?: Array<number>;
+    Changed?: Array<data.NodeChange>;
+    Diff?: data.TreeDiff;
+    Apply(build?: pob.PathOfBuilding, treeVersion: string): void;
+    Report(): string;
+  }
+  interface SupportRanking {
+    GemID: string;
+    Name: string;
+    Level: number;
+    Quality: number;
+    Value: number;
+    Delta: number;
+  }
   function NewCalculator(build: pob.PathOfBuilding): (calculator.Calculator | undefined);
 }
 export declare namespace config {
   function InitLogging(withTime: boolean): void;
 }
 export declare namespace data {
+  interface Arc {
+    Center: data.Point;
+    Radius: number;
+    StartAngle: number;
+    EndAngle: number;
+  }
   interface Ascendancy {
     ID: string;
     Name: string;
@@ -170,6 +343,11 @@ export declare namespace data {
     StrIntClass: number;
     DexIntClass: number;
   }
+  interface Connection {
+    From: number;
+    To: number;
+    Arc?: data.Arc;
+  }
   interface Constants {
     Classes: data.Classes;
     CharacterAttributes: data.CharacterAttributes;
@@ -246,10 +424,32 @@ export declare namespace data {
     IsBlighted?: boolean;
     ClassStartIndex?: number;
   }
+  interface NodeChange {
+    ID: number;
+    OldName: string;
+    NewName: string;
+    RemovedStats?: Array<string>;
+    AddedStats?: Array<string>;
+  }
+  interface NodeLayout {
+    Position: data.Point;
+    Center: data.Point;
+    Radius: number;
+    Angle: number;
+  }
+  interface Point {
+    X: number;
+    Y: number;
+    Distance(other: data.Point): number;
+  }
   interface Points {
     TotalPoints: number;
     AscendancyPoints: number;
   }
+  interface RadiusExtension {
+    SocketID: number;
+    Nodes?: Array<number>;
+  }
   interface Sprite {
     Filename: string;
     W: number;
@@ -278,6 +478,28 @@ export declare namespace data {
     Line?: Record<string, data.Sprite>;
     JewelRadius?: Record<string, data.Sprite>;
   }
+  interface SteinerTree {
+    Nodes?: Array<number>;
+    Cost: number;
+    Unreachable?: Array<number>;
+  }
+  interface Subgraph {
+    SocketID: number;
+    EntranceNode: number;
+    Nodes?: Record<number, data.Node>;
+    Edges?: Array<Array<number> | undefined>;
+    IncEffect: number;
+  }
+  interface TimelessJewel {
+    Type: number;
+    Seed: number;
+    ConquerorIndex: number;
+    ConquerorVersion: number;
+  }
+  interface TimelessSeedMatch {
+    Seed: number;
+    Nodes?: Array<number>;
+  }
   interface Tree {
     Tree: string;
     Classes?: Array<data.Class>;
@@ -294,6 +516,16 @@ export declare namespace data {
     ImageZoomLevels?: Array<number>;
     Points: data.Points;
   }
+  interface TreeDiff {
+    From: string;
+    To: string;
+    Added?: Array<number>;
+    Removed?: Array<number>;
+    Moved?: Array<number>;
+    Changed?: Array<data.NodeChange>;
+    Remapped?: Record<number, number>;
+    RemappedIDs(): (Array<number> | undefined);
+  }
 }
 export declare namespace debug {
   interface BuildInfo {
@@ -327,12 +559,24 @@ export declare namespace exposition {
     Base: exposition.GemPart;
     Vaal: exposition.GemPart;
     Support: boolean;
+    Qualities?: Array<calculator.GemQuality>;
     CalculateStuff(): void;
   }
-  function CalculateAllocationPaths(version: string, activeNodes: Array<number>, rootNodes: Array<number>): (Record<number, number> | undefined);
+  function CalculateAllocationPaths(version: string, activeNodes: Array<number>, rootNodes: Array<number>, extensionEdges?: Array<Array<number> | undefined>): (Record<number, number> | undefined);
+  function CalculateSteinerTree(version: string, activeNodes?: Array<number>, rootNodes?: Array<number>, targetNodes?: Array<number>, extensionEdges?: Array<Array<number> | undefined>): (data.SteinerTree | undefined);
+  function DescribeStats(descriptionFile: string, stats?: Record<string, number>): [(Array<string> | undefined), Error];
+  function DiffTreeVersions(from: string, to: string): (data.TreeDiff | undefined);
+  function ExportTreeGraph(version: string, format: string, nodes?: Array<number>): [string, Error];
+  function GetCompatibleSupports(activeGemID: string): [(Array<calculator.GemCatalogueEntry> | undefined), Error];
+  function GetGemStatDescriptions(id: string, level: number, quality: number, qualityID: string): [(Array<string> | undefined), Error];
+  function GetNodeLayouts(version: string): (Record<number, data.NodeLayout> | undefined);
   function GetRawTree(version: string): Promise<(Uint8Array | undefined)>;
   function GetSkillGems(): (Array<exposition.SkillGem> | undefined);
+  function GetSkillPartNames(grantedEffectID: string): (Array<string> | undefined);
   function GetStatByIndex(id: number): (poe.Stat | undefined);
+  function GetTreeConnections(version: string): (Array<data.Connection> | undefined);
+  function SearchGems(filter: calculator.GemFilter): (Array<calculator.GemCatalogueEntry> | undefined);
+  function SearchTimelessSeeds(version: string, socketID: number, jewelType: number, notable: string): [(Array<data.TimelessSeedMatch> | undefined), Error];
 }
 export declare namespace fwd {
   interface Reader {
@@ -350,12 +594,52 @@ export declare namespace fwd {
     WriteTo(w?: unknown): [number, Error];
   }
 }
+export declare namespace items {
+  interface Item {
+    Rarity: string;
+    Title: string;
+    BaseName: string;
+    BaseID: string;
+    ItemClass: string;
+    Quality: number;
+    ItemLevel: number;
+    LevelReq: number;
+    Radius: string;
+    LimitedTo: number;
+    Sockets?: Array<items.Socket>;
+    Enchants?: Array<items.ItemMod>;
+    Implicits?: Array<items.ItemMod>;
+    Explicits?: Array<items.ItemMod>;
+    Influences?: Array<string>;
+    Corrupted: boolean;
+    Mirrored: boolean;
+    Split: boolean;
+    Fractured: boolean;
+    Synthesised: boolean;
+    Unidentified: boolean;
+    Links(): number;
+    SocketsText(): string;
+    Text(): string;
+  }
+  interface ItemMod {
+    Line: string;
+    Crafted: boolean;
+    Fractured: boolean;
+  }
+  interface Socket {
+    Colour: string;
+    Group: number;
+  }
+  function ParseItem(text: string): [(items.Item | undefined), Error];
+}
 export declare namespace mod {
   interface ModValueMulti {
     ValueFloat: number;
     ValueFlag: boolean;
     ValueList?: unknown;
     Clone(): (mod.ModValueMulti | undefined);
+    DecodeMsg(r?: msgp.Reader): Error;
+    EncodeMsg(w?: msgp.Writer): Error;
     Flag(): boolean;
     Float(): number;
     List(): (unknown | undefined);
@@ -373,6 +657,8 @@ export declare namespace moddb {
     SkillStats?: Record<string, number>;
     SkillCond?: Record<string, boolean>;
     SlotName: string;
+    SkillPart: number;
+    SkillGem?: moddb.SkillGem;
   }
   interface ModDB {
     ModStore?: moddb.ModStore;
@@ -394,12 +680,16 @@ export declare namespace moddb {
     AddDB(db?: moddb.ModList): void;
     AddMod(newMod?: unknown): void;
     Clone(): (unknown | undefined);
+    DecodeMsg(r?: msgp.Reader): Error;
+    EncodeMsg(w?: msgp.Writer): Error;
     Flag(cfg?: moddb.ListCfg, names?: Array<string>): boolean;
     GetCondition(arg1: string, arg2?: moddb.ListCfg, arg3: boolean): [boolean, boolean];
     GetMultiplier(arg1: string, arg2?: moddb.ListCfg, arg3: boolean): number;
     List(cfg?: moddb.ListCfg, names?: Array<string>): (Array<unknown | undefined> | undefined);
+    Mods(): (Array<unknown | undefined> | undefined);
     More(cfg?: moddb.ListCfg, names?: Array<string>): number;
     Override(cfg?: moddb.ListCfg, names?: Array<string>): (mod.ModValueMulti | undefined);
+    ScaleAddList(list?: moddb.ModList, scale: number): void;
     Sum(modType: string, cfg?: moddb.ListCfg, names?: Array<string>): number;
   }
   interface ModStore {
@@ -412,6 +702,11 @@ export declare namespace moddb {
     GetCondition(variable: string, cfg?: moddb.ListCfg, noMod: boolean): [boolean, boolean];
     GetMultiplier(variable: string, cfg?: moddb.ListCfg, noMod: boolean): number;
   }
+  interface SkillGem {
+    Name: string;
+    Tags?: Array<string>;
+    IsType(keyword: string): boolean;
+  }
 }
 export declare namespace msgp {
   interface Reader {
@@ -511,6 +806,7 @@ export declare namespace pob {
     TargetVersion: string;
     PassiveNodes?: Array<number>;
     PassiveNodesStartPaths?: Record<number, Array<number> | undefined>;
+    MasteryEffects?: Record<number, number>;
     PlayerStats: Array<pob.PlayerStat>;
   }
   interface Calcs {
@@ -543,6 +839,12 @@ export declare namespace pob {
     Number?: number;
     String?: string;
   }
+  interface Item {
+    ID: number;
+    Variant?: number;
+    Text: string;
+    ModRanges?: Array<pob.ModRange>;
+  }
   interface ItemSet {
     ID: string;
     UseSecondWeaponSet?: boolean;
@@ -551,8 +853,17 @@ export declare namespace pob {
   interface Items {
     ActiveItemSet: number;
     UseSecondWeaponSet?: boolean;
+    Items: Array<pob.Item>;
     ItemSets: Array<pob.ItemSet>;
   }
+  interface JewelSocket {
+    NodeID: number;
+    ItemID: number;
+  }
+  interface ModRange {
+    ID: number;
+    Range: number;
+  }
   interface PathOfBuilding {
     Build: pob.Build;
     Tree: pob.Tree;
@@ -562,18 +873,22 @@ export declare namespace pob {
     Skills: pob.Skills;
     TreeView: pob.TreeView;
     Config: pob.Config;
+    AddItem(text: string): number;
     AddNewSocketGroup(): void;
     AllocateNodes(nodeIds?: Array<number>): void;
     DeallocateNodes(nodeId: number): void;
     DeleteAllSocketGroups(): void;
     DeleteSocketGroup(index: number): void;
+    DeselectMasteryEffect(nodeId: number): void;
     GetStringOption(name: string): string;
     RemoveConfigOption(name: string): void;
+    SelectMasteryEffect(nodeId: number, effect: number): void;
     SetAscendancy(ascendancy: string): void;
     SetClass(clazz: string): void;
     SetConfigOption(value: pob.Input): void;
     SetDefaultGemLevel(gemLevel: number): void;
     SetDefaultGemQuality(gemQuality: number): void;
+    SetGemSkillPart(skillSet: number, socketGroup: number, gem: number, skillPart: number): void;
     SetLevel(level: number): void;
     SetMainSocketGroup(mainSocketGroup: number): void;
     SetMatchGemLevelToCharacterLevel(enabled: boolean): void;
@@ -634,6 +949,7 @@ export declare namespace pob {
     NodesAttr: string;
     MasteryEffects: string;
     URL: string;
+    Sockets: Array<pob.JewelSocket>;
   }
   interface Tree {
     ActiveSpec: number;
@@ -1035,6 +1351,10 @@ export declare namespace raw {
     Msgsize(): number;
     UnmarshalMsg(bts?: Uint8Array): [(Uint8Array | undefined), Error];
   }
+  interface SkillPart {
+    Name: string;
+    Flags?: Record<string, boolean>;
+  }
   interface Stat {
     MainHandAliasStatsKey?: number;
     Category?: number;
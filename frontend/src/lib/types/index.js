@@ -5,6 +5,7 @@ export let cache;
 export let calculator;
 export let config;
 export let exposition;
+export let items;
 export let pob;
 export let raw;
 
@@ -24,9 +25,23 @@ export const initializeCrystalline = () => {
   };
   exposition = {
     CalculateAllocationPaths: globalThis['go']['go-pob']['exposition']['CalculateAllocationPaths'],
+    CalculateSteinerTree: globalThis['go']['go-pob']['exposition']['CalculateSteinerTree'],
+    DescribeStats: globalThis['go']['go-pob']['exposition']['DescribeStats'],
+    DiffTreeVersions: globalThis['go']['go-pob']['exposition']['DiffTreeVersions'],
+    ExportTreeGraph: globalThis['go']['go-pob']['exposition']['ExportTreeGraph'],
+    GetCompatibleSupports: globalThis['go']['go-pob']['exposition']['GetCompatibleSupports'],
+    GetGemStatDescriptions: globalThis['go']['go-pob']['exposition']['GetGemStatDescriptions'],
+    GetNodeLayouts: globalThis['go']['go-pob']['exposition']['GetNodeLayouts'],
     GetRawTree: globalThis['go']['go-pob']['exposition']['GetRawTree'],
     GetSkillGems: globalThis['go']['go-pob']['exposition']['GetSkillGems'],
-    GetStatByIndex: globalThis['go']['go-pob']['exposition']['GetStatByIndex']
+    GetSkillPartNames: globalThis['go']['go-pob']['exposition']['GetSkillPartNames'],
+    GetStatByIndex: globalThis['go']['go-pob']['exposition']['GetStatByIndex'],
+    GetTreeConnections: globalThis['go']['go-pob']['exposition']['GetTreeConnections'],
+    SearchGems: globalThis['go']['go-pob']['exposition']['SearchGems'],
+    SearchTimelessSeeds: globalThis['go']['go-pob']['exposition']['SearchTimelessSeeds']
+  };
+  items = {
+    ParseItem: globalThis['go']['go-pob']['items']['ParseItem']
   };
   pob = {
     BuildInfo: globalThis['go']['go-pob']['pob']['BuildInfo'],
@@ -0,0 +1,516 @@
+package items
+
+import (
+	"errors"
+	"fmt"
+	"math"
+	"regexp"
+	"slices"
+	"strconv"
+	"strings"
+
+	"github.com/Vilsol/go-pob-data/poe"
+)
+
+const gameSectionSeparator = "--------"
+
+var numberRegex = regexp.MustCompile(`-?\d+`)
+
+// Markers at the start of PoB item lines, e.g. {crafted} or {variant:1,2}
+var pobMarkerRegex = regexp.MustCompile(`^\{([^}]*)}`)
+
+// Markers at the end of game item lines, e.g. (crafted)
+var gameMarkerRegex = regexp.MustCompile(`\s+\((implicit|crafted|fractured|enchant|scourge|crucible)\)$`)
+
+// Value ranges in PoB item lines, e.g. (70-79)
+var valueRangeRegex = regexp.MustCompile(`\((-?\d+(?:\.\d+)?)-(-?\d+(?:\.\d+)?)\)`)
+
+// Value ranges written after the rolled value by advanced game item text, e.g. +54(50-59)
+var rolledRangeRegex = regexp.MustCompile(`(\d)\(-?\d+(?:\.\d+)?--?\d+(?:\.\d+)?\)`)
+
+// Keys of game item property lines
+var gamePropertyKeys = []string{
+	"Quality", "Sockets", "Item Level", "Requirements", "Level", "Str", "Dex", "Int",
+	"Armour", "Evasion Rating", "Energy Shield", "Ward", "Chance to Block",
+	"Physical Damage", "Elemental Damage", "Chaos Damage", "Critical Strike Chance", "Attacks per Second",
+	"Weapon Range", "Radius", "Limited to", "Stack Size", "Map Tier",
+}
+
+// Prefixes of base type names that are not part of the base type
+var basePrefixes = []string{"Superior ", "Synthesised "}
+
+// ParseItem parses either item text copied from the game or PoB's own item text
+func ParseItem(text string) (*Item, error) {
+	lines := make([]string, 0)
+	for _, line := range strings.Split(strings.ReplaceAll(text, "\r\n", "\n"), "\n") {
+		if line = strings.TrimSpace(line); line != "" {
+			lines = append(lines, line)
+		}
+	}
+
+	if len(lines) == 0 {
+		return nil, errors.New("failed to parse item: text is empty")
+	}
+
+	var item *Item
+	var err error
+	if strings.HasPrefix(lines[0], "Item Class:") || slices.Contains(lines, gameSectionSeparator) {
+		item, err = parseGameItem(lines)
+	} else {
+		item, err = parsePoBItem(lines)
+	}
+
+	if err != nil {
+		return nil, fmt.Errorf("failed to parse item: %w", err)
+	}
+
+	return item, nil
+}
+
+// parseGameItem parses item text copied from the game with Ctrl+C or Ctrl+Alt+C
+func parseGameItem(lines []string) (*Item, error) {
+	sections := make([][]string, 1)
+	for _, line := range lines {
+		if line == gameSectionSeparator {
+			sections = append(sections, nil)
+			continue
+		}
+		sections[len(sections)-1] = append(sections[len(sections)-1], line)
+	}
+
+	item := &Item{}
+	itemClass := ""
+	names := make([]string, 0, 2)
+	for _, line := range sections[0] {
+		if key, value, ok := strings.Cut(line, ": "); ok && (key == "Item Class" || key == "Rarity") {
+			if key == "Rarity" {
+				item.Rarity = Rarity(strings.ToUpper(value))
+			} else {
+				itemClass = value
+			}
+			continue
+		}
+		names = append(names, line)
+	}
+
+	if item.Rarity == "" {
+		return nil, errors.New("missing rarity")
+	}
+
+	if err := item.setNames(names); err != nil {
+		return nil, err
+	}
+
+	if item.ItemClass == "" {
+		item.ItemClass = itemClass
+	}
+
+	// Properties end at the last section with property lines, usually the item level. Modifiers and flags follow.
+	lastProperty := 0
+	for i := 1; i < len(sections); i++ {
+		if slices.ContainsFunc(sections[i], isGameProperty) {
+			lastProperty = i
+		}
+	}
+
+	for _, section := range sections[1 : lastProperty+1] {
+		requirements := false
+		for _, line := range section {
+			if err := item.parseGameProperty(line, requirements); err != nil {
+				return nil, err
+			}
+			requirements = requirements || line == "Requirements:"
+		}
+	}
+
+	explicitsDone := false
+	for _, section := range sections[lastProperty+1:] {
+		if !slices.ContainsFunc(section, func(line string) bool { return !isFlag(line) }) {
+			for _, line := range section {
+				item.parseFlag(line)
+			}
+			continue
+		}
+
+		implicit := false
+		mods := make([]ItemMod, 0, len(section))
+		implicits := make([]ItemMod, 0)
+		enchants := make([]ItemMod, 0)
+		for _, line := range section {
+			// Ctrl+Alt+C adds a header above each modifier
+			if strings.HasPrefix(line, "{ ") && strings.HasSuffix(line, " }") {
+				implicit = strings.Contains(line, "Implicit Modifier")
+				continue
+			}
+
+			// Reminder text
+			if strings.HasPrefix(line, "(") && strings.HasSuffix(line, ")") {
+				continue
+			}
+
+			itemMod := ItemMod{}
+			marker := ""
+			if match := gameMarkerRegex.FindStringSubmatch(line); match != nil {
+				marker = match[1]
+				line = line[:len(line)-len(match[0])]
+			}
+
+			itemMod.Line = rolledRangeRegex.ReplaceAllString(line, "$1")
+			itemMod.Crafted = marker == "crafted"
+			itemMod.Fractured = marker == "fractured"
+
+			switch {
+			case marker == "enchant":
+				enchants = append(enchants, itemMod)
+			case marker == "implicit" || implicit:
+				implicits = append(implicits, itemMod)
+			default:
+				mods = append(mods, itemMod)
+			}
+		}
+
+		item.Enchants = append(item.Enchants, enchants...)
+		item.Implicits = append(item.Implicits, implicits...)
+
+		// Sections after the explicit modifiers hold flavour and usage text
+		if len(mods) > 0 && !explicitsDone {
+			item.Explicits = mods
+			explicitsDone = true
+		}
+	}
+
+	return item, nil
+}
+
+func isGameProperty(line string) bool {
+	if strings.HasPrefix(line, "Requires ") {
+		return true
+	}
+
+	key, _, ok := strings.Cut(line, ":")
+	return ok && slices.Contains(gamePropertyKeys, key)
+}
+
+func (i *Item) parseGameProperty(line string, requirements bool) error {
+	if rest, ok := strings.CutPrefix(line, "Requires Level "); ok {
+		level, err := parseNumber("level requirement", rest)
+		i.LevelReq = level
+		return err
+	}
+
+	key, value, ok := strings.Cut(line, ": ")
+	if !ok {
+		return nil
+	}
+
+	var err error
+	switch key {
+	case "Quality":
+		i.Quality, err = parseNumber("quality", value)
+	case "Item Level":
+		i.ItemLevel, err = parseNumber("item level", value)
+	case "Level":
+		if requirements {
+			i.LevelReq, err = parseNumber("level requirement", value)
+		}
+	case "Sockets":
+		i.Sockets = parseSockets(value)
+	case "Radius":
+		i.Radius = value
+	case "Limited to":
+		i.LimitedTo, err = parseNumber("limit", value)
+	}
+	return err
+}
+
+// parsePoBItem parses item text as saved in PoB builds
+func parsePoBItem(lines []string) (*Item, error) {
+	item := &Item{}
+
+	rarity, ok := strings.CutPrefix(lines[0], "Rarity: ")
+	if !ok {
+		return nil, errors.New("missing rarity")
+	}
+	item.Rarity = Rarity(strings.ToUpper(rarity))
+
+	nameCount := 1
+	if item.Rarity == RarityRare || item.Rarity == RarityUnique || item.Rarity == RarityRelic {
+		nameCount = 2
+	}
+	nameCount = min(nameCount, len(lines)-1)
+
+	if err := item.setNames(lines[1 : nameCount+1]); err != nil {
+		return nil, err
+	}
+
+	var variants []int
+	variantCount, selectedVariant, selectedAltVariant := 0, 0, 0
+	hasAltVariant := false
+	implicits := -1
+	for _, line := range lines[nameCount+1:] {
+		if item.parseFlag(line) {
+			continue
+		}
+
+		if implicits < 0 {
+			if key, value, ok := strings.Cut(line, ": "); ok {
+				var err error
+				switch key {
+				case "Implicits":
+					implicits, err = parseNumber("implicit count", value)
+					variants = selectedVariants(variantCount, selectedVariant, selectedAltVariant, hasAltVariant)
+				case "Item Level":
+					item.ItemLevel, err = parseNumber("item level", value)
+				case "Quality":
+					item.Quality, err = parseNumber("quality", value)
+				case "LevelReq":
+					item.LevelReq, err = parseNumber("level requirement", value)
+				case "Limited to":
+					item.LimitedTo, err = parseNumber("limit", value)
+				case "Sockets":
+					item.Sockets = parseSockets(value)
+				case "Radius":
+					item.Radius = value
+				case "Variant":
+					variantCount++
+				case "Has Alt Variant":
+					hasAltVariant = value == "true"
+				case "Selected Variant":
+					selectedVariant, err = parseNumber("variant", value)
+				case "Selected Alt Variant":
+					selectedAltVariant, err = parseNumber("variant", value)
+				}
+
+				if err != nil {
+					return nil, err
+				}
+				continue
+			}
+
+			// Items with variants repeat their base type after the variant list
+			if base := lookupBase(line); base != nil || line == item.BaseName {
+				if base != nil && item.BaseID == "" {
+					item.setBase(base)
+				}
+				continue
+			}
+
+			// Without an implicit count all modifiers are explicit, starting right after the header
+			implicits = 0
+			variants = selectedVariants(variantCount, selectedVariant, selectedAltVariant, hasAltVariant)
+		}
+
+		itemMod, enchant, ok, err := parsePoBMod(line, variants)
+		if err != nil {
+			return nil, err
+		}
+
+		implicit := implicits > 0
+		implicits = max(implicits-1, 0)
+		if !ok {
+			continue
+		}
+
+		switch {
+		case enchant:
+			item.Enchants = append(item.Enchants, itemMod)
+		case implicit:
+			item.Implicits = append(item.Implicits, itemMod)
+		default:
+			item.Explicits = append(item.Explicits, itemMod)
+		}
+	}
+
+	return item, nil
+}
+
+// selectedVariants returns the selected variant and alt variant of an item. Like PoB, the latest variant is used if
+// none is selected.
+func selectedVariants(count int, selected int, selectedAlt int, hasAlt bool) []int {
+	variants := make([]int, 0, 2)
+	if selected > 0 {
+		variants = append(variants, selected)
+	} else if count > 0 {
+		variants = append(variants, count)
+	}
+
+	if selectedAlt > 0 {
+		variants = append(variants, selectedAlt)
+	} else if hasAlt && count > 0 {
+		variants = append(variants, count)
+	}
+
+	return variants
+}
+
+// parsePoBMod parses a modifier line with its leading markers. Lines that do not apply to the selected variants are
+// not ok.
+func parsePoBMod(line string, variants []int) (ItemMod, bool, bool, error) {
+	itemMod := ItemMod{}
+	enchant := false
+	ok := true
+	valueRange := 0.5
+	for {
+		match := pobMarkerRegex.FindStringSubmatch(line)
+		if match == nil {
+			break
+		}
+		line = line[len(match[0]):]
+
+		name, value, _ := strings.Cut(match[1], ":")
+		switch name {
+		case "crafted":
+			itemMod.Crafted = true
+		case "fractured":
+			itemMod.Fractured = true
+		case "enchant":
+			enchant = true
+		case "range":
+			var err error
+			if valueRange, err = strconv.ParseFloat(value, 64); err != nil {
+				return itemMod, false, false, fmt.Errorf("invalid mod range %q: %w", value, err)
+			}
+		case "variant":
+			if len(variants) > 0 {
+				ok = slices.ContainsFunc(strings.Split(value, ","), func(v string) bool {
+					n, err := strconv.Atoi(v)
+					return err == nil && slices.Contains(variants, n)
+				})
+			}
+		}
+	}
+
+	itemMod.Line = resolveRanges(line, valueRange)
+	return itemMod, enchant, ok, nil
+}
+
+// resolveRanges replaces value ranges with the value at the given position in the range
+func resolveRanges(line string, valueRange float64) string {
+	return valueRangeRegex.ReplaceAllStringFunc(line, func(s string) string {
+		match := valueRangeRegex.FindStringSubmatch(s)
+		low, _ := strconv.ParseFloat(match[1], 64)
+		high, _ := strconv.ParseFloat(match[2], 64)
+
+		precision := 0
+		for _, bound := range match[1:] {
+			if _, decimals, ok := strings.Cut(bound, "."); ok {
+				precision = max(precision, len(decimals))
+			}
+		}
+
+		scale := math.Pow(10, float64(precision))
+		value := math.Round((low+(high-low)*valueRange)*scale) / scale
+		return strconv.FormatFloat(value, 'f', precision, 64)
+	})
+}
+
+// parseFlag applies lines that flag the whole item, e.g. Corrupted or Shaper Item
+func (i *Item) parseFlag(line string) bool {
+	switch line {
+	case "Corrupted":
+		i.Corrupted = true
+	case "Mirrored":
+		i.Mirrored = true
+	case "Split":
+		i.Split = true
+	case "Unidentified":
+		i.Unidentified = true
+	case "Fractured Item":
+		i.Fractured = true
+	case "Synthesised Item":
+		i.Synthesised = true
+	default:
+		name, ok := strings.CutSuffix(line, " Item")
+		if !ok || !slices.Contains(Influences, Influence(name)) {
+			return false
+		}
+
+		if !slices.Contains(i.Influences, Influence(name)) {
+			i.Influences = append(i.Influences, Influence(name))
+		}
+	}
+	return true
+}
+
+func isFlag(line string) bool {
+	return (&Item{}).parseFlag(line)
+}
+
+// setNames sets the title and base type from the name lines. Names of normal and magic items contain the base type.
+func (i *Item) setNames(names []string) error {
+	if len(names) == 0 {
+		return errors.New("missing item name")
+	}
+
+	i.Title = names[0]
+	baseName := names[len(names)-1]
+	for _, prefix := range basePrefixes {
+		if trimmed, ok := strings.CutPrefix(baseName, prefix); ok {
+			i.Synthesised = i.Synthesised || prefix == "Synthesised "
+			baseName = trimmed
+		}
+	}
+
+	i.BaseName = baseName
+	if base := lookupBase(baseName); base != nil {
+		i.setBase(base)
+		return nil
+	}
+
+	// Magic items have affixes around the base type
+	var found *poe.BaseItemType
+	for _, base := range poe.BaseItemTypes {
+		if base.Name != "" && strings.Contains(baseName, base.Name) && (found == nil || len(base.Name) > len(found.Name)) {
+			found = base
+		}
+	}
+
+	if found != nil {
+		i.setBase(found)
+	}
+
+	return nil
+}
+
+func (i *Item) setBase(base *poe.BaseItemType) {
+	i.BaseName = base.Name
+	i.BaseID = base.ID
+
+	for _, itemClass := range poe.ItemClasses {
+		if itemClass.Key == base.ItemClassesKey {
+			i.ItemClass = itemClass.Name
+			break
+		}
+	}
+}
+
+func lookupBase(name string) *poe.BaseItemType {
+	return poe.BaseItemTypeByNameMap[name]
+}
+
+// parseSockets parses sockets written as e.g. R-G-B B
+func parseSockets(value string) []Socket {
+	sockets := make([]Socket, 0, 6)
+	for group, linked := range strings.Fields(value) {
+		for _, colour := range strings.Split(linked, "-") {
+			if colour != "" {
+				sockets = append(sockets, Socket{Colour: colour, Group: group})
+			}
+		}
+	}
+	return sockets
+}
+
+// parseNumber returns the first integer in the value, e.g. 20 for +20% (augmented)
+func parseNumber(name string, value string) (int, error) {
+	match := numberRegex.FindString(value)
+	if match == "" {
+		return 0, fmt.Errorf("invalid %s: %s", name, value)
+	}
+
+	number, err := strconv.Atoi(match)
+	if err != nil {
+		return 0, fmt.Errorf("invalid %s %q: %w", name, value, err)
+	}
+	return number, nil
+}
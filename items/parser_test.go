@@ -0,0 +1,299 @@
+package items
+
+import (
+	"context"
+	"os"
+	"testing"
+
+	"github.com/MarvinJWendt/testza"
+	"github.com/Vilsol/go-pob-data/poe"
+
+	"github.com/Vilsol/go-pob/builds"
+	"github.com/Vilsol/go-pob/cache"
+	"github.com/Vilsol/go-pob/config"
+	"github.com/Vilsol/go-pob/data/raw"
+)
+
+func init() {
+	config.InitLogging(false)
+
+	if err := poe.InitializeAll(context.Background(), raw.LatestVersion, cache.Disk(), nil); err != nil {
+		panic(err)
+	}
+}
+
+const gameBodyArmour = `Item Class: Body Armours
+Rarity: Rare
+Woe Shell
+Synthesised Vaal Regalia
+--------
+Quality: +20% (augmented)
+Energy Shield: 512 (augmented)
+--------
+Requirements:
+Level: 68
+Int: 194
+--------
+Sockets: B-B-B-R-G B
+--------
+Item Level: 86
+--------
+Trigger Level 20 Elemental Storm (enchant)
+--------
++1 to Level of Socketed Gems (implicit)
+--------
++98 to maximum Energy Shield
++42% to Fire Resistance (fractured)
++55 to maximum Life (crafted)
+--------
+Corrupted
+--------
+Hunter Item
+Redeemer Item
+`
+
+const gameAdvancedJewel = `Item Class: Jewels
+Rarity: Magic
+Hale Cobalt Jewel
+--------
+Item Level: 75
+--------
+{ Implicit Modifier }
++12(10-15) to Intelligence
+--------
+{ Prefix Modifier "Hale" (Tier: 1) — Life }
++7(5-7) to maximum Life
+--------
+Place into an allocated Jewel Socket on the Passive Skill Tree. Right click to remove from the Socket.
+`
+
+const gameUnique = `Item Class: Two Hand Swords
+Rarity: Unique
+Terminus Est
+Tiger Sword
+--------
+Two Hand Sword
+Physical Damage: 120-200 (augmented)
+--------
+Requires Level 51, 94 Str, 94 Dex
+--------
+Sockets: G-G-G
+--------
+Item Level: 80
+--------
++360 to Accuracy Rating (implicit)
+--------
+200% increased Physical Damage
+20% increased Attack Speed
+--------
+Only the worthy may wield it.
+`
+
+const pobUnique = `Rarity: UNIQUE
+Terminus Est
+Tiger Sword
+Variant: Pre 2.6.0
+Variant: Current
+Selected Variant: 2
+Tiger Sword
+Quality: 20
+Sockets: G-G-G-G-G-G
+LevelReq: 51
+Implicits: 2
+{variant:1}18% increased Accuracy Rating
+{variant:2}+360 to Accuracy Rating
+{variant:1}{range:0.5}(120-180)% increased Physical Damage
+{variant:2}{range:1}(180-220)% increased Physical Damage
+{range:0.5}(0.4-0.8)% of Physical Attack Damage Leeched as Life
+20% increased Attack Speed
+Corrupted`
+
+const pobMagic = `Rarity: MAGIC
+Dabbler's Basalt Flask of the Hummingbird
+Unique ID: 0fd6ef1630ec5c365ff2a12a599b503ca52cba84783fc858ce2fb63912ddba38
+Item Level: 84
+Quality: 0
+LevelReq: 49
+Shaper Item
+Implicits: 1
+{enchant}Taunts nearby Enemies on use
+32% reduced Duration
+{fractured}25% increased effect
+{crafted}14% increased Cast Speed during Flask effect`
+
+// Item text of older PoB versions has neither an implicit count nor a selected variant
+const pobLegacyUnique = `Rarity: UNIQUE
+Terminus Est
+Tiger Sword
+Variant: Pre 2.6.0
+Variant: Current
+Tiger Sword
+LevelReq: 51
+{variant:1}{range:0.5}(120-180)% increased Physical Damage
+{variant:2}{range:1}(180-220)% increased Physical Damage
+20% increased Attack Speed`
+
+func TestParseItem(t *testing.T) {
+	tc := []struct {
+		name     string
+		text     string
+		expected *Item
+	}{
+		{
+			name: "GameBodyArmour",
+			text: gameBodyArmour,
+			expected: &Item{
+				Rarity:      RarityRare,
+				Title:       "Woe Shell",
+				BaseName:    "Vaal Regalia",
+				BaseID:      "Metadata/Items/Armours/BodyArmours/BodyInt17",
+				ItemClass:   "Body Armours",
+				Quality:     20,
+				ItemLevel:   86,
+				LevelReq:    68,
+				Sockets:     []Socket{{"B", 0}, {"B", 0}, {"B", 0}, {"R", 0}, {"G", 0}, {"B", 1}},
+				Enchants:    []ItemMod{{Line: "Trigger Level 20 Elemental Storm"}},
+				Implicits:   []ItemMod{{Line: "+1 to Level of Socketed Gems"}},
+				Explicits:   []ItemMod{{Line: "+98 to maximum Energy Shield"}, {Line: "+42% to Fire Resistance", Fractured: true}, {Line: "+55 to maximum Life", Crafted: true}},
+				Influences:  []Influence{InfluenceHunter, InfluenceRedeemer},
+				Corrupted:   true,
+				Synthesised: true,
+			},
+		},
+		{
+			name: "GameAdvancedJewel",
+			text: gameAdvancedJewel,
+			expected: &Item{
+				Rarity:    RarityMagic,
+				Title:     "Hale Cobalt Jewel",
+				BaseName:  "Cobalt Jewel",
+				BaseID:    "Metadata/Items/Jewels/JewelInt",
+				ItemClass: "Jewels",
+				ItemLevel: 75,
+				Implicits: []ItemMod{{Line: "+12 to Intelligence"}},
+				Explicits: []ItemMod{{Line: "+7 to maximum Life"}},
+			},
+		},
+		{
+			name: "GameUnique",
+			text: gameUnique,
+			expected: &Item{
+				Rarity:    RarityUnique,
+				Title:     "Terminus Est",
+				BaseName:  "Tiger Sword",
+				BaseID:    "Metadata/Items/Weapons/TwoHandWeapons/TwoHandSwords/TwoHandSword12",
+				ItemClass: "Two Hand Swords",
+				ItemLevel: 80,
+				LevelReq:  51,
+				Sockets:   []Socket{{"G", 0}, {"G", 0}, {"G", 0}},
+				Implicits: []ItemMod{{Line: "+360 to Accuracy Rating"}},
+				Explicits: []ItemMod{{Line: "200% increased Physical Damage"}, {Line: "20% increased Attack Speed"}},
+			},
+		},
+		{
+			name: "PoBUnique",
+			text: pobUnique,
+			expected: &Item{
+				Rarity:    RarityUnique,
+				Title:     "Terminus Est",
+				BaseName:  "Tiger Sword",
+				BaseID:    "Metadata/Items/Weapons/TwoHandWeapons/TwoHandSwords/TwoHandSword12",
+				ItemClass: "Two Hand Swords",
+				Quality:   20,
+				LevelReq:  51,
+				Sockets:   []Socket{{"G", 0}, {"G", 0}, {"G", 0}, {"G", 0}, {"G", 0}, {"G", 0}},
+				Implicits: []ItemMod{{Line: "+360 to Accuracy Rating"}},
+				Explicits: []ItemMod{{Line: "220% increased Physical Damage"}, {Line: "0.6% of Physical Attack Damage Leeched as Life"}, {Line: "20% increased Attack Speed"}},
+				Corrupted: true,
+			},
+		},
+		{
+			name: "PoBLegacyUnique",
+			text: pobLegacyUnique,
+			expected: &Item{
+				Rarity:    RarityUnique,
+				Title:     "Terminus Est",
+				BaseName:  "Tiger Sword",
+				BaseID:    "Metadata/Items/Weapons/TwoHandWeapons/TwoHandSwords/TwoHandSword12",
+				ItemClass: "Two Hand Swords",
+				LevelReq:  51,
+				Explicits: []ItemMod{{Line: "220% increased Physical Damage"}, {Line: "20% increased Attack Speed"}},
+			},
+		},
+		{
+			name: "PoBMagic",
+			text: pobMagic,
+			expected: &Item{
+				Rarity:     RarityMagic,
+				Title:      "Dabbler's Basalt Flask of the Hummingbird",
+				BaseName:   "Basalt Flask",
+				BaseID:     "Metadata/Items/Flasks/FlaskUtility10",
+				ItemClass:  "Utility Flasks",
+				ItemLevel:  84,
+				LevelReq:   49,
+				Enchants:   []ItemMod{{Line: "Taunts nearby Enemies on use"}},
+				Explicits:  []ItemMod{{Line: "32% reduced Duration"}, {Line: "25% increased effect", Fractured: true}, {Line: "14% increased Cast Speed during Flask effect", Crafted: true}},
+				Influences: []Influence{InfluenceShaper},
+			},
+		},
+	}
+
+	for _, test := range tc {
+		t.Run(test.name, func(t *testing.T) {
+			item, err := ParseItem(test.text)
+			testza.AssertNoError(t, err)
+			testza.AssertEqual(t, test.expected, item)
+		})
+	}
+}
+
+func TestParseItemErrors(t *testing.T) {
+	_, err := ParseItem("  \n ")
+	testza.AssertNotNil(t, err)
+
+	_, err = ParseItem("Tiger Sword\nImplicits: 0")
+	testza.AssertNotNil(t, err)
+
+	_, err = ParseItem("Rarity: RARE\nWoe Shell\nVaal Regalia\nItem Level: high")
+	testza.AssertNotNil(t, err)
+}
+
+func TestItemLinks(t *testing.T) {
+	item, err := ParseItem(gameBodyArmour)
+	testza.AssertNoError(t, err)
+	testza.AssertEqual(t, 5, item.Links())
+	testza.AssertEqual(t, "B-B-B-R-G B", item.SocketsText())
+}
+
+func TestItemText(t *testing.T) {
+	for _, text := range []string{gameBodyArmour, gameAdvancedJewel, gameUnique, pobUnique, pobMagic} {
+		item, err := ParseItem(text)
+		testza.AssertNoError(t, err)
+
+		roundTrip, err := ParseItem(item.Text())
+		testza.AssertNoError(t, err)
+		testza.AssertEqual(t, item, roundTrip)
+	}
+}
+
+func TestParseBuildItems(t *testing.T) {
+	file, err := os.ReadFile("../testdata/builds/Fireball-full.xml")
+	testza.AssertNoError(t, err)
+
+	build, err := builds.ParseBuild(file)
+	testza.AssertNoError(t, err)
+	testza.AssertNotZero(t, len(build.Items.Items))
+
+	for _, saved := range build.Items.Items {
+		_, err := ParseItem(saved.Text)
+		testza.AssertNoError(t, err)
+	}
+
+	item, err := ParseItem(build.Items.Items[1].Text)
+	testza.AssertNoError(t, err)
+	testza.AssertEqual(t, "Medium Cluster Jewel", item.BaseName)
+	testza.AssertEqual(t, 69, item.ItemLevel)
+	testza.AssertEqual(t, 3, len(item.Implicits))
+	testza.AssertTrue(t, item.Implicits[0].Crafted)
+	testza.AssertEqual(t, 4, len(item.Explicits))
+}
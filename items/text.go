@@ -0,0 +1,82 @@
+package items
+
+import (
+	"strconv"
+	"strings"
+)
+
+// Text returns the item in PoB's item text format, as saved in builds
+func (i *Item) Text() string {
+	lines := []string{"Rarity: " + string(i.Rarity)}
+
+	switch i.Rarity {
+	case RarityRare, RarityUnique, RarityRelic:
+		lines = append(lines, i.Title, i.BaseName)
+	default:
+		lines = append(lines, i.Title)
+	}
+
+	for _, influence := range i.Influences {
+		lines = append(lines, string(influence)+" Item")
+	}
+
+	if i.Fractured {
+		lines = append(lines, "Fractured Item")
+	}
+	if i.Synthesised {
+		lines = append(lines, "Synthesised Item")
+	}
+
+	if i.ItemLevel > 0 {
+		lines = append(lines, "Item Level: "+strconv.Itoa(i.ItemLevel))
+	}
+	if i.Quality > 0 {
+		lines = append(lines, "Quality: "+strconv.Itoa(i.Quality))
+	}
+	if len(i.Sockets) > 0 {
+		lines = append(lines, "Sockets: "+i.SocketsText())
+	}
+	if i.LevelReq > 0 {
+		lines = append(lines, "LevelReq: "+strconv.Itoa(i.LevelReq))
+	}
+	if i.Radius != "" {
+		lines = append(lines, "Radius: "+i.Radius)
+	}
+	if i.LimitedTo > 0 {
+		lines = append(lines, "Limited to: "+strconv.Itoa(i.LimitedTo))
+	}
+
+	lines = append(lines, "Implicits: "+strconv.Itoa(len(i.Enchants)+len(i.Implicits)))
+	for _, itemMod := range i.Enchants {
+		lines = append(lines, "{enchant}"+itemMod.text())
+	}
+	for _, itemMod := range i.Implicits {
+		lines = append(lines, itemMod.text())
+	}
+	for _, itemMod := range i.Explicits {
+		lines = append(lines, itemMod.text())
+	}
+
+	if i.Mirrored {
+		lines = append(lines, "Mirrored")
+	}
+	if i.Split {
+		lines = append(lines, "Split")
+	}
+	if i.Corrupted {
+		lines = append(lines, "Corrupted")
+	}
+
+	return strings.Join(lines, "\n")
+}
+
+func (m ItemMod) text() string {
+	out := m.Line
+	if m.Fractured {
+		out = "{fractured}" + out
+	}
+	if m.Crafted {
+		out = "{crafted}" + out
+	}
+	return out
+}
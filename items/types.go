@@ -0,0 +1,110 @@
+package items
+
+type Rarity string
+
+const (
+	RarityNormal = Rarity("NORMAL")
+	RarityMagic  = Rarity("MAGIC")
+	RarityRare   = Rarity("RARE")
+	RarityUnique = Rarity("UNIQUE")
+	RarityRelic  = Rarity("RELIC")
+)
+
+type Influence string
+
+const (
+	InfluenceShaper        = Influence("Shaper")
+	InfluenceElder         = Influence("Elder")
+	InfluenceCrusader      = Influence("Crusader")
+	InfluenceRedeemer      = Influence("Redeemer")
+	InfluenceHunter        = Influence("Hunter")
+	InfluenceWarlord       = Influence("Warlord")
+	InfluenceSearingExarch = Influence("Searing Exarch")
+	InfluenceEaterOfWorlds = Influence("Eater of Worlds")
+)
+
+var Influences = []Influence{
+	InfluenceShaper,
+	InfluenceElder,
+	InfluenceCrusader,
+	InfluenceRedeemer,
+	InfluenceHunter,
+	InfluenceWarlord,
+	InfluenceSearingExarch,
+	InfluenceEaterOfWorlds,
+}
+
+type Socket struct {
+	// Socket colour letter, e.g. R, G, B, W or A
+	Colour string
+
+	// Sockets in the same group are linked, groups are 0-based
+	Group int
+}
+
+type ItemMod struct {
+	Line      string
+	Crafted   bool
+	Fractured bool
+}
+
+type Item struct {
+	Rarity Rarity
+
+	// Name of rare and unique items, or the full name of normal and magic items
+	Title string
+
+	// Base type name, ID and item class as found in the data, empty if the base type is unknown
+	BaseName  string
+	BaseID    string
+	ItemClass string
+
+	Quality   int
+	ItemLevel int
+	LevelReq  int
+
+	// Jewel radius and limit, e.g. Large and 1
+	Radius    string
+	LimitedTo int
+
+	Sockets []Socket
+
+	Enchants   []ItemMod
+	Implicits  []ItemMod
+	Explicits  []ItemMod
+	Influences []Influence
+
+	Corrupted    bool
+	Mirrored     bool
+	Split        bool
+	Fractured    bool
+	Synthesised  bool
+	Unidentified bool
+}
+
+// Links returns the size of the largest group of linked sockets
+func (i *Item) Links() int {
+	groups := make(map[int]int)
+	result := 0
+	for _, socket := range i.Sockets {
+		groups[socket.Group]++
+		result = max(result, groups[socket.Group])
+	}
+	return result
+}
+
+// SocketsText returns the sockets as written in item text, e.g. R-G-B B
+func (i *Item) SocketsText() string {
+	out := ""
+	for j, socket := range i.Sockets {
+		if j > 0 {
+			if socket.Group == i.Sockets[j-1].Group {
+				out += "-"
+			} else {
+				out += " "
+			}
+		}
+		out += socket.Colour
+	}
+	return out
+}
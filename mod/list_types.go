@@ -82,6 +82,10 @@ type ShrineBuff struct {
 	Mod Mod
 }
 
+type NodeModifier struct {
+	Mod Mod
+}
+
 type JewelData struct {
 	Key   string
 	Value any
@@ -0,0 +1,605 @@
+package mod
+
+import (
+	"fmt"
+	"reflect"
+
+	"github.com/tinylib/msgp/msgp"
+)
+
+// Mods are serialized as positional msgp arrays. Tags and list values carry a
+// type name in front of their payload so they can be reconstructed from the
+// registries below. Any type that can end up in a mod must be registered here.
+
+var tagTypes = make(map[Type]reflect.Type)
+
+var listValueTypes = make(map[string]reflect.Type)
+
+func init() {
+	for _, tag := range []Tag{
+		&ActorConditionTag{TagType: TypeActorCondition},
+		&ConditionTag{TagType: TypeCondition},
+		&DistanceRampTag{TagType: TypeDistanceRamp},
+		&FlagTag{TagType: TypeFlag},
+		&GlobalTag{TagType: TypeGlobal},
+		&GlobalEffectTag{TagType: TypeGlobalEffect},
+		&IgnoreCondTag{TagType: TypeIgnoreCond},
+		&InSlotTag{TagType: TypeInSlot},
+		&MeleeProximityTag{TagType: TypeMeleeProximity},
+		&ModFlagTag{TagType: TypeModFlag},
+		&ModFlagOrTag{TagType: TypeModFlagOr},
+		&MultiplierTag{TagType: TypeMultiplier},
+		&MultiplierThresholdTag{TagType: TypeMultiplierThreshold},
+		&PerStatTag{TagType: TypePerStat},
+		&PercentStatTag{TagType: TypePercentStat},
+		&SkillIDTag{TagType: TypeSkillID},
+		&SkillNameTag{TagType: TypeSkillName},
+		&SkillPartTag{TagType: TypeSkillPart},
+		&SkillTypeTag{TagType: TypeSkillType},
+		&SlotNameTag{TagType: TypeSlotName},
+		&SlotNumberTag{TagType: TypeSlotNumber},
+		&SocketedInTag{TagType: TypeSocketedIn},
+		&StatThresholdTag{TagType: TypeStatThreshold},
+	} {
+		tagTypes[tag.Type()] = reflect.TypeOf(tag).Elem()
+	}
+
+	for _, value := range []any{
+		AffectedByAuraMod{},
+		AffectedByCurseMod{},
+		ArmourData{},
+		ConquerorType{},
+		EnemyModifier{},
+		ExtraAura{},
+		ExtraAuraEffect{},
+		ExtraCurse{},
+		ExtraMinionSkill{},
+		ExtraSkill{},
+		ExtraSkillMod{},
+		ExtraSkillStat{},
+		ExtraSupport{},
+		GemProperty{},
+		GrantReservedLifeAsAura{},
+		GrantReservedManaAsAura{},
+		GrantedAscendancyNode{},
+		ImpossibleEscapeKeystones{},
+		JewelData{},
+		LegionJewel{},
+		LinkedSupport{},
+		MinionModifier{},
+		NodeModifier{},
+		ShrineBuff{},
+		SkillData{},
+		&SkillData{},
+		SupportedGemProperty{},
+		WeaponData{},
+	} {
+		listValueTypes[listValueName(reflect.TypeOf(value))] = reflect.TypeOf(value)
+	}
+}
+
+func listValueName(t reflect.Type) string {
+	if t.Kind() == reflect.Pointer {
+		return "*" + t.Elem().Name()
+	}
+	return t.Name()
+}
+
+var _ msgp.Encodable = (*BaseMod)(nil)
+var _ msgp.Decodable = (*BaseMod)(nil)
+
+// EncodeMsg implements msgp.Encodable
+func (m *BaseMod) EncodeMsg(w *msgp.Writer) error {
+	if err := w.WriteArrayHeader(7); err != nil {
+		return fmt.Errorf("failed to write mod header: %w", err)
+	}
+
+	if err := w.WriteString(m.ModName); err != nil {
+		return fmt.Errorf("failed to write mod name: %w", err)
+	}
+
+	if err := w.WriteString(string(m.ModType)); err != nil {
+		return fmt.Errorf("failed to write mod type: %w", err)
+	}
+
+	if err := w.WriteString(string(m.ModSource)); err != nil {
+		return fmt.Errorf("failed to write mod source: %w", err)
+	}
+
+	if err := w.WriteInt(int(m.ModFlags)); err != nil {
+		return fmt.Errorf("failed to write mod flags: %w", err)
+	}
+
+	if err := w.WriteInt(int(m.ModKeywordFlags)); err != nil {
+		return fmt.Errorf("failed to write mod keyword flags: %w", err)
+	}
+
+	if err := w.WriteArrayHeader(uint32(len(m.ModTags))); err != nil {
+		return fmt.Errorf("failed to write mod tags header: %w", err)
+	}
+
+	for _, tag := range m.ModTags {
+		if err := EncodeTag(w, tag); err != nil {
+			return fmt.Errorf("failed to write tag of mod %s: %w", m.ModName, err)
+		}
+	}
+
+	if m.ModValue == nil {
+		if err := w.WriteNil(); err != nil {
+			return fmt.Errorf("failed to write mod value: %w", err)
+		}
+		return nil
+	}
+
+	if err := m.ModValue.EncodeMsg(w); err != nil {
+		return fmt.Errorf("failed to write value of mod %s: %w", m.ModName, err)
+	}
+
+	return nil
+}
+
+// DecodeMsg implements msgp.Decodable
+func (m *BaseMod) DecodeMsg(r *msgp.Reader) error {
+	if err := readArrayHeader(r, 7); err != nil {
+		return err
+	}
+
+	name, err := r.ReadString()
+	if err != nil {
+		return fmt.Errorf("failed to read mod name: %w", err)
+	}
+
+	modType, err := r.ReadString()
+	if err != nil {
+		return fmt.Errorf("failed to read mod type: %w", err)
+	}
+
+	source, err := r.ReadString()
+	if err != nil {
+		return fmt.Errorf("failed to read mod source: %w", err)
+	}
+
+	flags, err := r.ReadInt()
+	if err != nil {
+		return fmt.Errorf("failed to read mod flags: %w", err)
+	}
+
+	keywordFlags, err := r.ReadInt()
+	if err != nil {
+		return fmt.Errorf("failed to read mod keyword flags: %w", err)
+	}
+
+	tagCount, err := r.ReadArrayHeader()
+	if err != nil {
+		return fmt.Errorf("failed to read mod tags header: %w", err)
+	}
+
+	var tags []Tag
+	if tagCount > 0 {
+		tags = make([]Tag, tagCount)
+		for i := range tags {
+			if tags[i], err = DecodeTag(r); err != nil {
+				return fmt.Errorf("failed to read tag of mod %s: %w", name, err)
+			}
+		}
+	}
+
+	var value *ModValueMulti
+	if r.IsNil() {
+		if err := r.ReadNil(); err != nil {
+			return fmt.Errorf("failed to read mod value: %w", err)
+		}
+	} else {
+		value = &ModValueMulti{}
+		if err := value.DecodeMsg(r); err != nil {
+			return fmt.Errorf("failed to read value of mod %s: %w", name, err)
+		}
+	}
+
+	*m = BaseMod{
+		ModName:         name,
+		ModType:         Type(modType),
+		ModSource:       Source(source),
+		ModFlags:        MFlag(flags),
+		ModKeywordFlags: KeywordFlag(keywordFlags),
+		ModTags:         tags,
+		ModValue:        value,
+	}
+	m.child = m
+
+	return nil
+}
+
+var _ msgp.Encodable = (*ModValueMulti)(nil)
+var _ msgp.Decodable = (*ModValueMulti)(nil)
+
+// EncodeMsg implements msgp.Encodable
+func (m *ModValueMulti) EncodeMsg(w *msgp.Writer) error {
+	if err := w.WriteArrayHeader(4); err != nil {
+		return fmt.Errorf("failed to write value header: %w", err)
+	}
+
+	if err := w.WriteString(string(m.valueType)); err != nil {
+		return fmt.Errorf("failed to write value type: %w", err)
+	}
+
+	if err := w.WriteFloat64(m.ValueFloat); err != nil {
+		return fmt.Errorf("failed to write float value: %w", err)
+	}
+
+	if err := w.WriteBool(m.ValueFlag); err != nil {
+		return fmt.Errorf("failed to write flag value: %w", err)
+	}
+
+	return encodeAny(w, m.ValueList)
+}
+
+// DecodeMsg implements msgp.Decodable
+func (m *ModValueMulti) DecodeMsg(r *msgp.Reader) error {
+	if err := readArrayHeader(r, 4); err != nil {
+		return err
+	}
+
+	valueType, err := r.ReadString()
+	if err != nil {
+		return fmt.Errorf("failed to read value type: %w", err)
+	}
+
+	valueFloat, err := r.ReadFloat64()
+	if err != nil {
+		return fmt.Errorf("failed to read float value: %w", err)
+	}
+
+	valueFlag, err := r.ReadBool()
+	if err != nil {
+		return fmt.Errorf("failed to read flag value: %w", err)
+	}
+
+	valueList, err := decodeAny(r)
+	if err != nil {
+		return err
+	}
+
+	*m = ModValueMulti{
+		valueType:  ModValueMultiType(valueType),
+		ValueFloat: valueFloat,
+		ValueFlag:  valueFlag,
+		ValueList:  valueList,
+	}
+
+	return nil
+}
+
+// EncodeTag writes the tag type followed by all exported fields of the tag
+func EncodeTag(w *msgp.Writer, tag Tag) error {
+	if _, ok := tagTypes[tag.Type()]; !ok {
+		return fmt.Errorf("unregistered tag type: %s", tag.Type())
+	}
+
+	if err := w.WriteArrayHeader(2); err != nil {
+		return fmt.Errorf("failed to write tag header: %w", err)
+	}
+
+	if err := w.WriteString(string(tag.Type())); err != nil {
+		return fmt.Errorf("failed to write tag type: %w", err)
+	}
+
+	return encodeReflect(w, reflect.Indirect(reflect.ValueOf(tag)))
+}
+
+// DecodeTag reads a tag previously written by EncodeTag
+func DecodeTag(r *msgp.Reader) (Tag, error) {
+	if err := readArrayHeader(r, 2); err != nil {
+		return nil, err
+	}
+
+	tagType, err := r.ReadString()
+	if err != nil {
+		return nil, fmt.Errorf("failed to read tag type: %w", err)
+	}
+
+	t, ok := tagTypes[Type(tagType)]
+	if !ok {
+		return nil, fmt.Errorf("unregistered tag type: %s", tagType)
+	}
+
+	value := reflect.New(t)
+	if err := decodeReflect(r, value.Elem()); err != nil {
+		return nil, fmt.Errorf("failed to read %s tag: %w", tagType, err)
+	}
+
+	return value.Interface().(Tag), nil //nolint:forcetypeassert
+}
+
+func readArrayHeader(r *msgp.Reader, expected uint32) error {
+	size, err := r.ReadArrayHeader()
+	if err != nil {
+		return fmt.Errorf("failed to read array header: %w", err)
+	}
+
+	if size != expected {
+		return fmt.Errorf("unexpected array size: got %d, expected %d", size, expected)
+	}
+
+	return nil
+}
+
+const modValueName = "Mod"
+
+// encodeAny writes a dynamically typed value. Primitives are written as is,
+// everything else is prefixed with its registered type name.
+func encodeAny(w *msgp.Writer, value any) error {
+	var err error
+	switch v := value.(type) {
+	case nil:
+		err = w.WriteNil()
+	case string:
+		err = w.WriteString(v)
+	case bool:
+		err = w.WriteBool(v)
+	case int:
+		err = w.WriteInt(v)
+	case float64:
+		err = w.WriteFloat64(v)
+	case *BaseMod:
+		if err := writeTypedHeader(w, modValueName); err != nil {
+			return err
+		}
+		return v.EncodeMsg(w)
+	default:
+		name := listValueName(reflect.TypeOf(value))
+		if _, ok := listValueTypes[name]; !ok {
+			return fmt.Errorf("unregistered list value type: %T", value)
+		}
+
+		if err := writeTypedHeader(w, name); err != nil {
+			return err
+		}
+
+		return encodeReflect(w, reflect.Indirect(reflect.ValueOf(value)))
+	}
+
+	if err != nil {
+		return fmt.Errorf("failed to write %T value: %w", value, err)
+	}
+
+	return nil
+}
+
+func writeTypedHeader(w *msgp.Writer, name string) error {
+	if err := w.WriteArrayHeader(2); err != nil {
+		return fmt.Errorf("failed to write typed value header: %w", err)
+	}
+
+	if err := w.WriteString(name); err != nil {
+		return fmt.Errorf("failed to write typed value name: %w", err)
+	}
+
+	return nil
+}
+
+func decodeAny(r *msgp.Reader) (any, error) {
+	next, err := r.NextType()
+	if err != nil {
+		return nil, fmt.Errorf("failed to read value type: %w", err)
+	}
+
+	var value any
+	switch next {
+	case msgp.NilType:
+		err = r.ReadNil()
+	case msgp.StrType:
+		value, err = r.ReadString()
+	case msgp.BoolType:
+		value, err = r.ReadBool()
+	case msgp.IntType, msgp.UintType:
+		value, err = r.ReadInt()
+	case msgp.Float32Type, msgp.Float64Type:
+		value, err = r.ReadFloat64()
+	case msgp.ArrayType:
+		return decodeTyped(r)
+	default:
+		return nil, fmt.Errorf("unsupported value type: %s", next)
+	}
+
+	if err != nil {
+		return nil, fmt.Errorf("failed to read %s value: %w", next, err)
+	}
+
+	return value, nil
+}
+
+func decodeTyped(r *msgp.Reader) (any, error) {
+	if err := readArrayHeader(r, 2); err != nil {
+		return nil, err
+	}
+
+	name, err := r.ReadString()
+	if err != nil {
+		return nil, fmt.Errorf("failed to read typed value name: %w", err)
+	}
+
+	if name == modValueName {
+		m := &BaseMod{}
+		if err := m.DecodeMsg(r); err != nil {
+			return nil, err
+		}
+		return m, nil
+	}
+
+	t, ok := listValueTypes[name]
+	if !ok {
+		return nil, fmt.Errorf("unregistered list value type: %s", name)
+	}
+
+	if t.Kind() == reflect.Pointer {
+		value := reflect.New(t.Elem())
+		if err := decodeReflect(r, value.Elem()); err != nil {
+			return nil, fmt.Errorf("failed to read %s: %w", name, err)
+		}
+		return value.Interface(), nil
+	}
+
+	value := reflect.New(t).Elem()
+	if err := decodeReflect(r, value); err != nil {
+		return nil, fmt.Errorf("failed to read %s: %w", name, err)
+	}
+
+	return value.Interface(), nil
+}
+
+// encodeReflect writes plain data (structs, slices, pointers and primitives).
+// Structs are written as arrays of their exported fields in declaration order.
+func encodeReflect(w *msgp.Writer, v reflect.Value) error {
+	var err error
+	switch v.Kind() {
+	case reflect.Bool:
+		err = w.WriteBool(v.Bool())
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		err = w.WriteInt64(v.Int())
+	case reflect.Float32, reflect.Float64:
+		err = w.WriteFloat64(v.Float())
+	case reflect.String:
+		err = w.WriteString(v.String())
+	case reflect.Interface:
+		if v.IsNil() {
+			err = w.WriteNil()
+			break
+		}
+		return encodeAny(w, v.Elem().Interface())
+	case reflect.Pointer, reflect.Slice:
+		if v.IsNil() {
+			err = w.WriteNil()
+			break
+		}
+
+		if v.Kind() == reflect.Pointer {
+			return encodeReflect(w, v.Elem())
+		}
+
+		if err := w.WriteArrayHeader(uint32(v.Len())); err != nil {
+			return fmt.Errorf("failed to write slice header: %w", err)
+		}
+
+		for i := 0; i < v.Len(); i++ {
+			if err := encodeReflect(w, v.Index(i)); err != nil {
+				return err
+			}
+		}
+	case reflect.Struct:
+		fields := exportedFields(v.Type())
+		if err := w.WriteArrayHeader(uint32(len(fields))); err != nil {
+			return fmt.Errorf("failed to write struct header: %w", err)
+		}
+
+		for _, field := range fields {
+			if err := encodeReflect(w, v.Field(field)); err != nil {
+				return fmt.Errorf("failed to write field %s: %w", v.Type().Field(field).Name, err)
+			}
+		}
+	default:
+		return fmt.Errorf("unsupported kind: %s", v.Kind())
+	}
+
+	if err != nil {
+		return fmt.Errorf("failed to write %s: %w", v.Kind(), err)
+	}
+
+	return nil
+}
+
+func decodeReflect(r *msgp.Reader, v reflect.Value) error {
+	switch v.Kind() {
+	case reflect.Interface, reflect.Pointer, reflect.Slice:
+		if r.IsNil() {
+			if err := r.ReadNil(); err != nil {
+				return fmt.Errorf("failed to read nil: %w", err)
+			}
+			v.Set(reflect.Zero(v.Type()))
+			return nil
+		}
+	default:
+	}
+
+	switch v.Kind() {
+	case reflect.Bool:
+		b, err := r.ReadBool()
+		if err != nil {
+			return fmt.Errorf("failed to read bool: %w", err)
+		}
+		v.SetBool(b)
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		i, err := r.ReadInt64()
+		if err != nil {
+			return fmt.Errorf("failed to read int: %w", err)
+		}
+		v.SetInt(i)
+	case reflect.Float32, reflect.Float64:
+		f, err := r.ReadFloat64()
+		if err != nil {
+			return fmt.Errorf("failed to read float: %w", err)
+		}
+		v.SetFloat(f)
+	case reflect.String:
+		s, err := r.ReadString()
+		if err != nil {
+			return fmt.Errorf("failed to read string: %w", err)
+		}
+		v.SetString(s)
+	case reflect.Interface:
+		value, err := decodeAny(r)
+		if err != nil {
+			return err
+		}
+
+		if !reflect.TypeOf(value).AssignableTo(v.Type()) {
+			return fmt.Errorf("%T is not assignable to %s", value, v.Type())
+		}
+
+		v.Set(reflect.ValueOf(value))
+	case reflect.Pointer:
+		value := reflect.New(v.Type().Elem())
+		if err := decodeReflect(r, value.Elem()); err != nil {
+			return err
+		}
+		v.Set(value)
+	case reflect.Slice:
+		size, err := r.ReadArrayHeader()
+		if err != nil {
+			return fmt.Errorf("failed to read slice header: %w", err)
+		}
+
+		slice := reflect.MakeSlice(v.Type(), int(size), int(size))
+		for i := 0; i < int(size); i++ {
+			if err := decodeReflect(r, slice.Index(i)); err != nil {
+				return err
+			}
+		}
+		v.Set(slice)
+	case reflect.Struct:
+		fields := exportedFields(v.Type())
+		if err := readArrayHeader(r, uint32(len(fields))); err != nil {
+			return err
+		}
+
+		for _, field := range fields {
+			if err := decodeReflect(r, v.Field(field)); err != nil {
+				return fmt.Errorf("failed to read field %s: %w", v.Type().Field(field).Name, err)
+			}
+		}
+	default:
+		return fmt.Errorf("unsupported kind: %s", v.Kind())
+	}
+
+	return nil
+}
+
+func exportedFields(t reflect.Type) []int {
+	fields := make([]int, 0, t.NumField())
+	for i := 0; i < t.NumField(); i++ {
+		if t.Field(i).IsExported() {
+			fields = append(fields, i)
+		}
+	}
+	return fields
+}
@@ -3,18 +3,24 @@ package mod
 var _ Tag = (*SkillPartTag)(nil)
 
 type SkillPartTag struct {
-	TagType Type
-	Part    int
+	TagType       Type
+	SkillPartList []int
+	Negative      bool
 }
 
 //nolint:all
-func SkillPart(part int) *SkillPartTag {
+func SkillPart(parts ...int) *SkillPartTag {
 	return &SkillPartTag{
-		TagType: TypeSkillPart,
-		Part:    part,
+		TagType:       TypeSkillPart,
+		SkillPartList: parts,
 	}
 }
 
 func (t SkillPartTag) Type() Type {
 	return t.TagType
 }
+
+func (t *SkillPartTag) Neg(negative bool) *SkillPartTag {
+	t.Negative = negative
+	return t
+}
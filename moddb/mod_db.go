@@ -1,16 +1,27 @@
 package moddb
 
 import (
+	"iter"
+
 	"github.com/Vilsol/go-pob/mod"
-	"github.com/Vilsol/go-pob/utils"
 )
 
 var _ ModStoreFuncs = (*ModDB)(nil)
 
+// maxModDBLayers is the amount of shared layers after which they get flattened into one
+const maxModDBLayers = 16
+
+// ModDB is a copy-on-write mod database.
+//
+// Mods is the mutable overlay, which is queried after all shared layers.
+// Clone and AddDB share the layers and mod slices of the source database instead of copying
+// every mod. The source is only read, so it may be cloned or added concurrently.
 type ModDB struct {
 	*ModStore
 
 	Mods map[string][]mod.Mod
+
+	layers []map[string][]mod.Mod
 }
 
 func NewModDB() *ModDB {
@@ -27,26 +38,95 @@ func (m *ModDB) Clone() ModStoreFuncs {
 		return nil
 	}
 
-	out := NewModDB()
-	out.AddDB(m)
-	out.ModStore = m.ModStore.Clone()
+	out := &ModDB{
+		ModStore: m.ModStore.Clone(),
+		Mods:     m.overlaySnapshot(),
+		layers:   m.layers[:len(m.layers):len(m.layers)],
+	}
 	out.ModStore.Child = out
 	return out
 }
 
 func (m *ModDB) AddMod(newMod mod.Mod) {
-	if _, ok := m.Mods[newMod.Name()]; !ok {
-		m.Mods[newMod.Name()] = make([]mod.Mod, 0)
-	}
 	m.Mods[newMod.Name()] = append(m.Mods[newMod.Name()], newMod)
 }
 
+// AddDB appends all mods of the provided database after the existing ones, sharing them instead of copying.
+//
+// Mods with the same name as existing ones are kept alongside them, as in PoB, instead of replacing them.
 func (m *ModDB) AddDB(db *ModDB) {
 	if db == nil {
 		return
 	}
-	for k, v := range db.Mods {
-		m.Mods[k] = utils.CopySlice(v)
+
+	m.freeze()
+	m.layers = append(m.layers, db.sharedLayers()...)
+	m.compact()
+}
+
+// sharedLayers returns the layers of the database followed by a snapshot of its overlay
+func (m *ModDB) sharedLayers() []map[string][]mod.Mod {
+	layers := m.layers[:len(m.layers):len(m.layers)]
+	if len(m.Mods) == 0 {
+		return layers
+	}
+
+	return append(layers, m.overlaySnapshot())
+}
+
+// overlaySnapshot returns a copy of the overlay map that shares the mod slices.
+//
+// The slices are clipped, so appends to either overlay never show up in the other one.
+func (m *ModDB) overlaySnapshot() map[string][]mod.Mod {
+	snapshot := make(map[string][]mod.Mod, len(m.Mods))
+	for name, mods := range m.Mods {
+		snapshot[name] = mods[:len(mods):len(mods)]
+	}
+	return snapshot
+}
+
+// freeze moves the overlay into the layers and starts a new empty overlay
+func (m *ModDB) freeze() {
+	if len(m.Mods) == 0 {
+		return
+	}
+
+	m.layers = append(m.layers[:len(m.layers):len(m.layers)], m.Mods)
+	m.Mods = make(map[string][]mod.Mod)
+}
+
+// compact flattens the layers into a single one once there are too many of them
+func (m *ModDB) compact() {
+	if len(m.layers) <= maxModDBLayers {
+		return
+	}
+
+	flat := make(map[string][]mod.Mod)
+	for _, layer := range m.layers {
+		for name, mods := range layer {
+			flat[name] = append(flat[name], mods...)
+		}
+	}
+
+	m.layers = []map[string][]mod.Mod{flat}
+}
+
+// named iterates over all mods with the provided name in the order they were added
+func (m *ModDB) named(name string) iter.Seq[mod.Mod] {
+	return func(yield func(mod.Mod) bool) {
+		for _, layer := range m.layers {
+			for _, mo := range layer[name] {
+				if !yield(mo) {
+					return
+				}
+			}
+		}
+
+		for _, mo := range m.Mods[name] {
+			if !yield(mo) {
+				return
+			}
+		}
 	}
 }
 
@@ -54,7 +134,7 @@ func (m *ModDB) List(cfg *ListCfg, names ...string) []interface{} {
 	result := make([]interface{}, 0)
 
 	for _, name := range names {
-		for _, mo := range m.Mods[name] {
+		for mo := range m.named(name) {
 			if mo.Type() == mod.TypeList &&
 				(cfg == nil || cfg.Flags == nil || (*cfg.Flags)&mo.Flags() == mo.Flags()) &&
 				(cfg == nil || cfg.KeywordFlags == nil || mod.MatchKeywordFlags(*cfg.KeywordFlags, mo.KeywordFlags())) &&
@@ -62,7 +142,7 @@ func (m *ModDB) List(cfg *ListCfg, names ...string) []interface{} {
 
 				value := m.evalMod(mo, cfg)
 				if value != nil {
-					result = append(result, value)
+					result = append(result, value.ValueList)
 				}
 			}
 		}
@@ -79,7 +159,7 @@ func (m *ModDB) Sum(modType mod.Type, cfg *ListCfg, names ...string) float64 {
 	result := float64(0)
 
 	for _, name := range names {
-		for _, mo := range m.Mods[name] {
+		for mo := range m.named(name) {
 			if mo.Type() == modType &&
 				(cfg == nil || cfg.Flags == nil || (*cfg.Flags)&mo.Flags() == mo.Flags()) &&
 				(cfg == nil || cfg.KeywordFlags == nil || mod.MatchKeywordFlags(*cfg.KeywordFlags, mo.KeywordFlags())) &&
@@ -102,7 +182,7 @@ func (m *ModDB) Sum(modType mod.Type, cfg *ListCfg, names ...string) float64 {
 
 func (m *ModDB) Flag(cfg *ListCfg, names ...string) bool {
 	for _, name := range names {
-		for _, mo := range m.Mods[name] {
+		for mo := range m.named(name) {
 			if mo.Type() == mod.TypeFlag &&
 				(cfg == nil || cfg.Flags == nil || (*cfg.Flags)&mo.Flags() == mo.Flags()) &&
 				(cfg == nil || cfg.KeywordFlags == nil || mod.MatchKeywordFlags(*cfg.KeywordFlags, mo.KeywordFlags())) &&
@@ -129,7 +209,7 @@ func (m *ModDB) More(cfg *ListCfg, names ...string) float64 {
 	result := float64(1)
 
 	for _, name := range names {
-		for _, mo := range m.Mods[name] {
+		for mo := range m.named(name) {
 			if mo.Type() == mod.TypeMore &&
 				(cfg == nil || cfg.Flags == nil || (*cfg.Flags)&mo.Flags() == mo.Flags()) &&
 				(cfg == nil || cfg.KeywordFlags == nil || mod.MatchKeywordFlags(*cfg.KeywordFlags, mo.KeywordFlags())) &&
@@ -157,7 +237,7 @@ func (m *ModDB) Override(cfg *ListCfg, names ...string) *mod.ModValueMulti {
 	}
 
 	for _, name := range names {
-		for _, mo := range m.Mods[name] {
+		for mo := range m.named(name) {
 			if mo.Type() == mod.TypeOverride &&
 				(cfg == nil || cfg.Flags == nil || (*cfg.Flags)&mo.Flags() == mo.Flags()) &&
 				(cfg == nil || cfg.KeywordFlags == nil || mod.MatchKeywordFlags(*cfg.KeywordFlags, mo.KeywordFlags())) &&
@@ -0,0 +1,141 @@
+package moddb
+
+import (
+	"strconv"
+	"sync"
+	"testing"
+
+	"github.com/MarvinJWendt/testza"
+
+	"github.com/Vilsol/go-pob/mod"
+)
+
+func TestModDBClone(t *testing.T) {
+	original := NewModDB()
+	original.AddMod(mod.NewFloat("Life", mod.TypeBase, 10))
+
+	clone := original.Clone().(*ModDB)
+	clone.AddMod(mod.NewFloat("Life", mod.TypeBase, 5))
+	original.AddMod(mod.NewFloat("Life", mod.TypeBase, 1))
+
+	testza.AssertEqual(t, float64(11), original.Sum(mod.TypeBase, nil, "Life"))
+	testza.AssertEqual(t, float64(15), clone.Sum(mod.TypeBase, nil, "Life"))
+
+	nested := clone.Clone().(*ModDB)
+	nested.AddMod(mod.NewFloat("Life", mod.TypeBase, 100))
+
+	testza.AssertEqual(t, float64(15), clone.Sum(mod.TypeBase, nil, "Life"))
+	testza.AssertEqual(t, float64(115), nested.Sum(mod.TypeBase, nil, "Life"))
+}
+
+func TestModDBAddDB(t *testing.T) {
+	first := NewModDB()
+	first.AddMod(mod.NewFloat("Life", mod.TypeOverride, 1))
+	first.AddMod(mod.NewFlag("Onslaught", true))
+
+	second := NewModDB()
+	second.AddMod(mod.NewFloat("Life", mod.TypeOverride, 2))
+	second.AddDB(first)
+
+	first.AddMod(mod.NewFloat("Mana", mod.TypeBase, 10))
+
+	// Mods keep the order they were added in
+	testza.AssertEqual(t, mod.NewModValueFloat(2), second.Override(nil, "Life"))
+	testza.AssertTrue(t, second.Flag(nil, "Onslaught"))
+	testza.AssertEqual(t, float64(0), second.Sum(mod.TypeBase, nil, "Mana"))
+	testza.AssertEqual(t, float64(10), first.Sum(mod.TypeBase, nil, "Mana"))
+}
+
+func TestModDBConcurrentClone(t *testing.T) {
+	source := NewModDB()
+	source.AddMod(mod.NewFloat("Life", mod.TypeBase, 10))
+
+	var wg sync.WaitGroup
+	for i := 0; i < 8; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+
+			clone := source.Clone().(*ModDB)
+			clone.AddMod(mod.NewFloat("Life", mod.TypeBase, 1))
+
+			target := NewModDB()
+			target.AddDB(source)
+			target.AddMod(mod.NewFloat("Life", mod.TypeBase, 2))
+
+			testza.AssertEqual(t, float64(11), clone.Sum(mod.TypeBase, nil, "Life"))
+			testza.AssertEqual(t, float64(12), target.Sum(mod.TypeBase, nil, "Life"))
+		}()
+	}
+	wg.Wait()
+
+	testza.AssertEqual(t, float64(10), source.Sum(mod.TypeBase, nil, "Life"))
+}
+
+func TestModDBCompact(t *testing.T) {
+	m := NewModDB()
+	for i := 0; i < maxModDBLayers*2; i++ {
+		m.AddMod(mod.NewList("List", i))
+		m = m.Clone().(*ModDB)
+	}
+
+	testza.AssertLessOrEqual(t, len(m.layers), maxModDBLayers)
+
+	expected := make([]interface{}, maxModDBLayers*2)
+	for i := range expected {
+		expected[i] = i
+	}
+	testza.AssertEqual(t, expected, m.List(nil, "List"))
+}
+
+func benchmarkModDB() *ModDB {
+	m := NewModDB()
+	for i := 0; i < 2000; i++ {
+		m.AddMod(mod.NewFloat("Mod"+strconv.Itoa(i%500), mod.TypeBase, float64(i)))
+	}
+	return m
+}
+
+func BenchmarkModDBClone(b *testing.B) {
+	m := benchmarkModDB()
+
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		clone := m.Clone().(*ModDB)
+		clone.AddMod(mod.NewFloat("Mod0", mod.TypeBase, 1))
+	}
+}
+
+func BenchmarkModDBAddDB(b *testing.B) {
+	m := benchmarkModDB()
+
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		out := NewModDB()
+		out.AddDB(m)
+		out.AddMod(mod.NewFloat("Mod0", mod.TypeBase, 1))
+	}
+}
+
+func BenchmarkModDBSum(b *testing.B) {
+	m := benchmarkModDB().Clone().(*ModDB)
+	m.AddMod(mod.NewFloat("Mod0", mod.TypeBase, 1))
+
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		m.Sum(mod.TypeBase, nil, "Mod0", "Mod1", "Mod2")
+	}
+}
+
+func BenchmarkModDBAddList(b *testing.B) {
+	list := NewModList()
+	for i := 0; i < 2000; i++ {
+		list.AddMod(mod.NewFloat("Mod"+strconv.Itoa(i%500), mod.TypeBase, float64(i)))
+	}
+
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		out := NewModDB()
+		out.AddList(list)
+	}
+}
@@ -1,8 +1,12 @@
 package moddb
 
 import (
+	"fmt"
+	"math"
+
+	"github.com/tinylib/msgp/msgp"
+
 	"github.com/Vilsol/go-pob/mod"
-	"github.com/Vilsol/go-pob/utils"
 )
 
 var _ ModStoreFuncs = (*ModList)(nil)
@@ -43,8 +47,91 @@ func (m *ModList) AddDB(db *ModList) {
 		return
 	}
 
-	newMods := utils.CopySlice(db.mods)
-	m.mods = append(m.mods, newMods...)
+	m.mods = append(m.mods, db.mods...)
+}
+
+// Mods returns all mods of the list, the returned slice must not be modified
+func (m *ModList) Mods() []mod.Mod {
+	return m.mods
+}
+
+// ScaleAddList adds all mods of the provided list with their values multiplied by scale
+func (m *ModList) ScaleAddList(list *ModList, scale float64) {
+	if scale == 1 {
+		m.AddDB(list)
+		return
+	}
+
+	scale = math.Max(scale, 0)
+	for _, mo := range list.mods {
+		m.mods = append(m.mods, scaleMod(mo, scale))
+	}
+}
+
+func scaleMod(mo mod.Mod, scale float64) mod.Mod {
+	for _, tag := range mo.Tags() {
+		if effect, ok := tag.(*mod.GlobalEffectTag); ok && effect.UnscalableTag {
+			return mo
+		}
+	}
+
+	value := mo.Value()
+	if value == nil || value.Type() != mod.ModValueMultiTypeFloat {
+		return mo
+	}
+
+	scaled := mo.Clone()
+	if math.Floor(value.Float()) == value.Float() {
+		// Whole numbers stay whole, rounding towards zero like the game does
+		scaled.Value().SetFloat(math.Trunc(math.Round(value.Float()*scale*100) / 100))
+	} else {
+		scaled.Value().SetFloat(value.Float() * scale)
+	}
+
+	return scaled
+}
+
+// EncodeMsg implements msgp.Encodable.
+//
+// Only the mods are serialized, store state like multipliers and conditions is not.
+func (m *ModList) EncodeMsg(w *msgp.Writer) error {
+	if err := w.WriteArrayHeader(uint32(len(m.mods))); err != nil {
+		return fmt.Errorf("failed to write mod list header: %w", err)
+	}
+
+	for _, mo := range m.mods {
+		baseMod, ok := mo.(*mod.BaseMod)
+		if !ok {
+			return fmt.Errorf("unsupported mod implementation: %T", mo)
+		}
+
+		if err := baseMod.EncodeMsg(w); err != nil {
+			return fmt.Errorf("failed to write mod list: %w", err)
+		}
+	}
+
+	return nil
+}
+
+// DecodeMsg implements msgp.Decodable. Decoded mods are appended to the list.
+func (m *ModList) DecodeMsg(r *msgp.Reader) error {
+	size, err := r.ReadArrayHeader()
+	if err != nil {
+		return fmt.Errorf("failed to read mod list header: %w", err)
+	}
+
+	mods := make([]mod.Mod, size)
+	for i := range mods {
+		baseMod := &mod.BaseMod{}
+		if err := baseMod.DecodeMsg(r); err != nil {
+			return fmt.Errorf("failed to read mod list: %w", err)
+		}
+		mods[i] = baseMod
+	}
+
+	m.mods = append(m.mods, mods...)
+
+	return nil
 }
 
 func (m *ModList) List(cfg *ListCfg, names ...string) []interface{} {
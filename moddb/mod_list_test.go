@@ -1,9 +1,12 @@
 package moddb
 
 import (
+	"bytes"
 	"testing"
 
 	"github.com/MarvinJWendt/testza"
+	"github.com/tinylib/msgp/msgp"
+
 	"github.com/Vilsol/go-pob/mod"
 	"github.com/Vilsol/go-pob/utils"
 )
@@ -373,3 +376,124 @@ func TestOverride(t *testing.T) {
 		})
 	}
 }
+
+func TestScaleAddList(t *testing.T) {
+	tc := []struct {
+		name     string
+		mods     []mod.Mod
+		scale    float64
+		expected []float64
+	}{
+		{
+			name:     "unscaled",
+			mods:     []mod.Mod{mod.NewFloat("testMod", mod.TypeBase, 10)},
+			scale:    1,
+			expected: []float64{10},
+		},
+		{
+			name:     "whole numbers round towards zero",
+			mods:     []mod.Mod{mod.NewFloat("testMod", mod.TypeBase, 10), mod.NewFloat("testMod", mod.TypeIncrease, -7)},
+			scale:    1.5,
+			expected: []float64{15, -10},
+		},
+		{
+			name:     "fractional values",
+			mods:     []mod.Mod{mod.NewFloat("testMod", mod.TypeBase, 0.4)},
+			scale:    1.5,
+			expected: []float64{0.6000000000000001},
+		},
+		{
+			name:     "unscalable",
+			mods:     []mod.Mod{mod.NewFloat("testMod", mod.TypeBase, 10).Tag(mod.GlobalEffect("testEffect").Unscalable(true))},
+			scale:    2,
+			expected: []float64{10},
+		},
+		{
+			name:     "negative scale",
+			mods:     []mod.Mod{mod.NewFloat("testMod", mod.TypeBase, 10)},
+			scale:    -1,
+			expected: []float64{0},
+		},
+	}
+
+	for _, test := range tc {
+		t.Run(test.name, func(t *testing.T) {
+			list := NewModList()
+			for _, m := range test.mods {
+				list.AddMod(m)
+			}
+
+			scaled := NewModList()
+			scaled.ScaleAddList(list, test.scale)
+			testza.AssertLen(t, scaled.Mods(), len(test.expected))
+
+			for i, m := range scaled.Mods() {
+				testza.AssertEqual(t, test.expected[i], m.Value().Float())
+			}
+
+			// The original mods are left untouched
+			for i, m := range list.Mods() {
+				testza.AssertEqual(t, test.mods[i].Value().Float(), m.Value().Float())
+			}
+		})
+	}
+}
+
+func TestEncodeDecode(t *testing.T) {
+	tc := []struct {
+		name string
+		mods []mod.Mod
+	}{
+		{
+			name: "empty modlist",
+			mods: []mod.Mod{},
+		},
+		{
+			name: "plain mods",
+			mods: []mod.Mod{
+				mod.NewFloat("testMod0", mod.TypeIncrease, 42.42).Source("Tree:1").Flag(mod.MFlagAttack).KeywordFlag(mod.KeywordFlagCold),
+				mod.NewFlag("testMod1", true),
+				mod.NewList("testMod2", "testVal"),
+			},
+		},
+		{
+			name: "tagged mods",
+			mods: []mod.Mod{
+				mod.NewFloat("testMod0", mod.TypeBase, 1).Tag(mod.Multiplier("testVar").Base(2).Limit(10), mod.Condition("testCond").Neg(true)),
+				mod.NewFloat("testMod1", mod.TypeMore, 1).Tag(mod.PerStat(5, "Str", "Dex").GlobalLimit(3).GlobalLimitKey("testKey")),
+				mod.NewFloat("testMod2", mod.TypeMore, 1).Tag(mod.DistanceRamp([][]int{{35, 0}, {70, 1}}), mod.SkillPart(2)),
+				mod.NewFlag("testMod3", true).Tag(mod.StatThreshold("Int", 100).Upper(true), mod.ModFlagOr(mod.MFlagBow)),
+			},
+		},
+		{
+			name: "list value mods",
+			mods: []mod.Mod{
+				mod.NewList("SkillData", &mod.SkillData{Key: "testKey", Value: 1}),
+				mod.NewList("GemProperty", mod.GemProperty{Key: "level", Value: 1, KeywordList: []string{"aura"}, Keyword: utils.Ptr("grants_active_skill")}),
+				mod.NewList("EnemyModifier", mod.EnemyModifier{Mod: mod.NewFloat("testMod", mod.TypeIncrease, 10).Tag(mod.Condition("testCond"))}),
+				mod.NewList("EnemyModifier", mod.NewFloat("testMod", mod.TypeBase, 5)),
+				mod.NewList("JewelData", mod.JewelData{Key: "conqueredBy", Value: mod.LegionJewel{ID: 1, Conqueror: mod.ConquerorType{ID: "1", Type: "vaal"}}}),
+				mod.NewList("ExtraSkill", mod.ExtraSkill{SkillName: "testSkill", Level: 20, Source: "testSource"}),
+				mod.NewList("NodeModifier", mod.NodeModifier{Mod: mod.NewFloat("testMod", mod.TypeIncrease, 20).Flag(mod.MFlagSpell)}),
+			},
+		},
+	}
+
+	for _, test := range tc {
+		t.Run(test.name, func(t *testing.T) {
+			m := NewModList()
+			for _, tm := range test.mods {
+				m.AddMod(tm)
+			}
+
+			var buf bytes.Buffer
+			w := msgp.NewWriter(&buf)
+			testza.AssertNoError(t, m.EncodeMsg(w))
+			testza.AssertNoError(t, w.Flush())
+
+			got := NewModList()
+			testza.AssertNoError(t, got.DecodeMsg(msgp.NewReader(&buf)))
+			testza.AssertEqual(t, test.mods, got.mods)
+		})
+	}
+}
@@ -3,6 +3,7 @@ package moddb
 import (
 	"maps"
 	"math"
+	"slices"
 
 	"github.com/Vilsol/go-pob/mod"
 )
@@ -14,6 +15,10 @@ type ListCfg struct {
 	SkillStats   map[string]float64
 	SkillCond    map[string]bool
 	SlotName     string
+	SkillPart    int
+
+	// Gem of the active skill, matched against the keywords of socketed in tags
+	SkillGem *SkillGem
 }
 
 type ModStoreFuncs interface {
@@ -405,13 +410,6 @@ func (s *ModStore) evalActorConditionTag(m mod.Mod, cfg *ListCfg, tag *mod.Actor
 	if !match {
 		return nil
 	}
-	/*
-		TODO SocketedIn
-		case *mod.SocketedInTag:
-			if not cfg or tag.slotName ~= cfg.slotName or (tag.keyword and (not cfg or not cfg.skillGem or not calcLib.gemIsType(cfg.skillGem, tag.keyword))) then
-				return
-			end
-	*/
 	/*
 		TODO SkillName
 		case *mod.SkillNameTag:
@@ -441,30 +439,6 @@ func (s *ModStore) evalActorConditionTag(m mod.Mod, cfg *ListCfg, tag *mod.Actor
 				return
 			end
 	*/
-	/*
-		TODO SkillPart
-		case *mod.SkillPartTag:
-			if not cfg then
-				return
-			end
-			local match = false
-			if tag.skillPartList then
-				for _, part in ipairs(tag.skillPartList) do
-					if part == cfg.skillPart then
-						match = true
-						break
-					end
-				end
-			else
-				match = (tag.skillPart == cfg.skillPart)
-			end
-			if tag.neg then
-				match = not match
-			end
-			if not match then
-				return
-			end
-	*/
 	/*
 		TODO SkillType
 		case *mod.SkillTypeTag:
@@ -533,6 +507,29 @@ func (s *ModStore) evalActorConditionTag(m mod.Mod, cfg *ListCfg, tag *mod.Actor
 	return value
 }
 
+func matchSkillPartTag(cfg *ListCfg, tag *mod.SkillPartTag) bool {
+	if cfg == nil {
+		return false
+	}
+
+	match := slices.Contains(tag.SkillPartList, cfg.SkillPart)
+	if tag.Negative {
+		match = !match
+	}
+
+	return match
+}
+
+// matchSocketedInTag returns whether the mod applies to skills socketed in the slot of the config, and whether the
+// skill gem of the config matches the keyword of the tag (if any)
+func matchSocketedInTag(cfg *ListCfg, tag *mod.SocketedInTag) bool {
+	if cfg == nil || cfg.SlotName != tag.SlotName {
+		return false
+	}
+
+	return tag.TagKeyword == "" || (cfg.SkillGem != nil && cfg.SkillGem.IsType(tag.TagKeyword))
+}
+
 func (s *ModStore) evalMod(m mod.Mod, cfg *ListCfg) *mod.ModValueMulti {
 	value := m.Value()
 
@@ -552,6 +549,19 @@ func (s *ModStore) evalMod(m mod.Mod, cfg *ListCfg) *mod.ModValueMulti {
 			value = s.evalConditionTag(m, cfg, tag)
 		case *mod.ActorConditionTag:
 			value = s.evalActorConditionTag(m, cfg, tag)
+		case *mod.SkillPartTag:
+			if !matchSkillPartTag(cfg, tag) {
+				return nil
+			}
+		case *mod.SocketedInTag:
+			if !matchSocketedInTag(cfg, tag) {
+				return nil
+			}
+		}
+
+		// A failing tag excludes the mod, later tags must not bring it back
+		if value == nil {
+			return nil
 		}
 	}
 
@@ -570,8 +580,7 @@ func (s *ModStore) GetMultiplier(variable string, cfg *ListCfg, noMod bool) floa
 	}
 
 	if !noMod {
-		// TODO ???
-		// out += s.Sum(mod.ModTypeBase, cfg, multiplierName[variable])
+		out += s.Child.Sum(mod.TypeBase, cfg, "Multiplier:"+variable)
 	}
 
 	return out
@@ -62,3 +62,87 @@ func TestMultiplier(t *testing.T) {
 		})
 	}
 }
+
+func TestEvalModFailingTag(t *testing.T) {
+	m := NewModList()
+	m.Multipliers["Stage"] = 2
+	m.AddMod(mod.NewFloat("Damage", mod.TypeIncrease, 10).Tag(mod.Condition("Missing")).Tag(mod.Multiplier("Stage")))
+
+	// The multiplier after the failing condition must not apply the mod
+	testza.AssertEqual(t, float64(0), m.Sum(mod.TypeIncrease, nil, "Damage"))
+
+	m.Conditions["Missing"] = true
+	testza.AssertEqual(t, float64(20), m.Sum(mod.TypeIncrease, nil, "Damage"))
+}
+
+func TestMultiplierFromBaseMods(t *testing.T) {
+	m := NewModList()
+	m.Multipliers["Stage"] = 1
+	m.AddMod(mod.NewFloat("Multiplier:Stage", mod.TypeBase, 2))
+	m.AddMod(mod.NewFloat("Multiplier:Stage", mod.TypeBase, 4).Tag(mod.Condition("Missing")))
+
+	testza.AssertEqual(t, float64(3), m.GetMultiplier("Stage", nil, false))
+	testza.AssertEqual(t, float64(1), m.GetMultiplier("Stage", nil, true))
+}
+
+func TestSkillPart(t *testing.T) {
+	m := NewModList()
+	m.AddMod(mod.NewFloat("Damage", mod.TypeIncrease, 1).Tag(mod.SkillPart(1)))
+	m.AddMod(mod.NewFloat("Damage", mod.TypeIncrease, 10).Tag(mod.SkillPart(2, 3)))
+	m.AddMod(mod.NewFloat("Damage", mod.TypeIncrease, 100).Tag(mod.SkillPart(3).Neg(true)))
+
+	tc := []struct {
+		name     string
+		cfg      *ListCfg
+		expected float64
+	}{
+		{name: "no cfg", cfg: nil, expected: 0},
+		{name: "part 1", cfg: &ListCfg{SkillPart: 1}, expected: 101},
+		{name: "part 2", cfg: &ListCfg{SkillPart: 2}, expected: 110},
+		{name: "part 3", cfg: &ListCfg{SkillPart: 3}, expected: 10},
+	}
+
+	for _, test := range tc {
+		t.Run(test.name, func(t *testing.T) {
+			testza.AssertEqual(t, test.expected, m.Sum(mod.TypeIncrease, test.cfg, "Damage"))
+		})
+	}
+}
+
+func TestSkillPartMultiplier(t *testing.T) {
+	m := NewModList()
+	m.AddMod(mod.NewFloat("Multiplier:Stage", mod.TypeBase, 1).Tag(mod.SkillPart(1)))
+	m.AddMod(mod.NewFloat("Multiplier:Stage", mod.TypeBase, 6).Tag(mod.SkillPart(2)))
+	m.AddMod(mod.NewFloat("Damage", mod.TypeIncrease, 20).Tag(mod.Multiplier("Stage")))
+
+	testza.AssertEqual(t, float64(20), m.Sum(mod.TypeIncrease, &ListCfg{SkillPart: 1}, "Damage"))
+	testza.AssertEqual(t, float64(120), m.Sum(mod.TypeIncrease, &ListCfg{SkillPart: 2}, "Damage"))
+}
+
+func TestSocketedIn(t *testing.T) {
+	m := NewModList()
+	m.AddMod(mod.NewFloat("Damage", mod.TypeIncrease, 1).Tag(mod.SocketedIn("Helmet")))
+	m.AddMod(mod.NewFloat("Damage", mod.TypeIncrease, 10).Tag(mod.SocketedIn("Helmet").Keyword("golem")))
+	m.AddMod(mod.NewFloat("Damage", mod.TypeIncrease, 100).Tag(mod.SocketedIn("Helmet").Keyword("elemental")))
+
+	golem := &SkillGem{Name: "Summon Stone Golem", Tags: []string{"active_skill", "golem", "minion", "physical"}}
+	fireball := &SkillGem{Name: "Fireball", Tags: []string{"active_skill", "area", "elemental", "fire", "projectile", "spell"}}
+
+	tc := []struct {
+		name     string
+		cfg      *ListCfg
+		expected float64
+	}{
+		{name: "no cfg", cfg: nil, expected: 0},
+		{name: "other slot", cfg: &ListCfg{SlotName: "Gloves", SkillGem: golem}, expected: 0},
+		{name: "no gem", cfg: &ListCfg{SlotName: "Helmet"}, expected: 1},
+		{name: "golem", cfg: &ListCfg{SlotName: "Helmet", SkillGem: golem}, expected: 11},
+		{name: "fireball", cfg: &ListCfg{SlotName: "Helmet", SkillGem: fireball}, expected: 101},
+	}
+
+	for _, test := range tc {
+		t.Run(test.name, func(t *testing.T) {
+			testza.AssertEqual(t, test.expected, m.Sum(mod.TypeIncrease, test.cfg, "Damage"))
+		})
+	}
+}
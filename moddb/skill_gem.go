@@ -0,0 +1,42 @@
+package moddb
+
+import (
+	"slices"
+	"strings"
+)
+
+// SkillGem is the gem of an active skill, as matched by the keywords of modifiers
+type SkillGem struct {
+	Name string
+
+	// Gem tags, e.g. spell, golem or active_skill
+	Tags []string
+}
+
+// IsType returns whether the gem matches the keyword, e.g. "spell", "elemental" or the name of the gem
+func (g *SkillGem) IsType(keyword string) bool {
+	name := strings.ToLower(g.Name)
+
+	switch keyword {
+	case "all":
+		return true
+	case "elemental":
+		return g.hasTag("fire") || g.hasTag("cold") || g.hasTag("lightning")
+	case "aoe":
+		return g.hasTag("area")
+	case "trap or mine":
+		return g.hasTag("trap") || g.hasTag("mine")
+	case "active skill", "grants_active_skill", "skill":
+		return g.hasTag("active_skill") && !g.hasTag("support")
+	case "non-vaal":
+		return !g.hasTag("vaal")
+	case name, strings.TrimPrefix(name, "vaal "):
+		return true
+	}
+
+	return g.hasTag(keyword)
+}
+
+func (g *SkillGem) hasTag(tag string) bool {
+	return slices.Contains(g.Tags, tag)
+}
@@ -0,0 +1,37 @@
+package moddb
+
+import (
+	"testing"
+
+	"github.com/MarvinJWendt/testza"
+)
+
+func TestSkillGemIsType(t *testing.T) {
+	gem := &SkillGem{Name: "Vaal Fireball", Tags: []string{"active_skill", "area", "fire", "projectile", "spell", "vaal"}}
+	support := &SkillGem{Name: "Trap Support", Tags: []string{"support", "trap"}}
+
+	tc := []struct {
+		gem      *SkillGem
+		keyword  string
+		expected bool
+	}{
+		{gem: gem, keyword: "all", expected: true},
+		{gem: gem, keyword: "spell", expected: true},
+		{gem: gem, keyword: "attack", expected: false},
+		{gem: gem, keyword: "elemental", expected: true},
+		{gem: gem, keyword: "aoe", expected: true},
+		{gem: gem, keyword: "active skill", expected: true},
+		{gem: gem, keyword: "non-vaal", expected: false},
+		{gem: gem, keyword: "vaal fireball", expected: true},
+		{gem: gem, keyword: "fireball", expected: true},
+		{gem: support, keyword: "trap or mine", expected: true},
+		{gem: support, keyword: "active skill", expected: false},
+		{gem: support, keyword: "non-vaal", expected: true},
+	}
+
+	for _, test := range tc {
+		t.Run(test.gem.Name+"/"+test.keyword, func(t *testing.T) {
+			testza.AssertEqual(t, test.expected, test.gem.IsType(test.keyword))
+		})
+	}
+}
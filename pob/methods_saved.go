@@ -22,6 +22,10 @@ func (b *PathOfBuilding) SetSocketGroupGems(skillSet int, socketGroup int, gems
 	b.Skills.SkillSets[skillSet].Skills[socketGroup].Gems = gems
 }
 
+func (b *PathOfBuilding) SetGemSkillPart(skillSet int, socketGroup int, gem int, skillPart int) {
+	b.Skills.SkillSets[skillSet].Skills[socketGroup].Gems[gem].SkillPart = skillPart
+}
+
 func (b *PathOfBuilding) SetSortGemsByDPS(enabled bool) {
 	b.Skills.SortGemsByDPS = enabled
 }
@@ -101,6 +105,20 @@ func (b *PathOfBuilding) DeleteAllSocketGroups() {
 	b.Skills.SkillSets[b.Skills.ActiveSkillSet-1].Skills = make([]Skill, 0)
 }
 
+// AddItem adds an item from PoB item text and returns its ID
+func (b *PathOfBuilding) AddItem(text string) int {
+	id := 1
+	for _, item := range b.Items.Items {
+		id = max(id, item.ID+1)
+	}
+
+	b.Items.Items = append(b.Items.Items, Item{
+		ID:   id,
+		Text: text,
+	})
+	return id
+}
+
 func (b *PathOfBuilding) SetClass(clazz string) {
 	b.Build.ClassName = clazz
 }
@@ -122,4 +140,17 @@ func (b *PathOfBuilding) DeallocateNodes(nodeId int64) {
 	if err == nil {
 		b.Build.PassiveNodes = newNodes
 	}
+
+	delete(b.Build.MasteryEffects, nodeId)
+}
+
+func (b *PathOfBuilding) SelectMasteryEffect(nodeId int64, effect int64) {
+	if b.Build.MasteryEffects == nil {
+		b.Build.MasteryEffects = make(map[int64]int64)
+	}
+	b.Build.MasteryEffects[nodeId] = effect
+}
+
+func (b *PathOfBuilding) DeselectMasteryEffect(nodeId int64) {
+	delete(b.Build.MasteryEffects, nodeId)
 }
@@ -40,6 +40,9 @@ type Build struct {
 	PassiveNodes           []int64
 	PassiveNodesStartPaths map[int64][]int64
 
+	// Selected effect of allocated masteries by mastery node ID
+	MasteryEffects map[int64]int64
+
 	PlayerStats []PlayerStat `xml:"PlayerStat" crystalline:"not_nil"`
 }
 
@@ -63,6 +66,7 @@ type Items struct {
 	ActiveItemSet      int   `xml:"activeItemSet,attr"`
 	UseSecondWeaponSet *bool `xml:"useSecondWeaponSet,attr,omitempty"`
 
+	Items    []Item    `xml:"Item" crystalline:"not_nil"`
 	ItemSets []ItemSet `xml:"ItemSet" crystalline:"not_nil"`
 }
 
@@ -106,6 +110,19 @@ type Section struct {
 	Subsection string `xml:"subsection,attr"`
 }
 
+type Item struct {
+	ID      int    `xml:"id,attr"`
+	Variant *int   `xml:"variant,attr,omitempty"`
+	Text    string `xml:",chardata"`
+
+	ModRanges []ModRange `xml:"ModRange"`
+}
+
+type ModRange struct {
+	ID    int     `xml:"id,attr"`
+	Range float64 `xml:"range,attr"`
+}
+
 type ItemSet struct {
 	ID                 string `xml:"id,attr"`
 	UseSecondWeaponSet *bool  `xml:"useSecondWeaponSet,attr,omitempty"`
@@ -169,4 +186,11 @@ type Spec struct {
 	NodesAttr      string           `xml:"nodes,attr"`
 	MasteryEffects string           `xml:"masteryEffects,attr"`
 	URL            string           `xml:"URL"`
+
+	Sockets []JewelSocket `xml:"Sockets>Socket" crystalline:"not_nil"`
+}
+
+type JewelSocket struct {
+	NodeID int64 `xml:"nodeId,attr"`
+	ItemID int   `xml:"itemId,attr"`
 }
@@ -0,0 +1,141 @@
+package render
+
+import (
+	"image"
+	"image/color"
+	"image/draw"
+	"math"
+
+	"github.com/Vilsol/go-pob/data"
+)
+
+// canvas rasterizes shapes in tree coordinates. Shapes are anti-aliased by the distance of the pixel centers to
+// their outline.
+type canvas struct {
+	img    *image.RGBA
+	bounds Rect
+	zoom   float64
+}
+
+func (s *scene) rasterize() *image.RGBA {
+	width, height := s.size()
+	img := image.NewRGBA(image.Rect(0, 0, width, height))
+	draw.Draw(img, img.Bounds(), &image.Uniform{C: backgroundColor}, image.Point{}, draw.Src)
+
+	c := &canvas{img: img, bounds: s.bounds, zoom: s.zoom}
+	for _, sh := range s.shapes {
+		sh.rasterize(c)
+	}
+
+	return img
+}
+
+// pixel returns the position of the point in pixels
+func (c *canvas) pixel(p data.Point) (float64, float64) {
+	return (p.X - c.bounds.MinX) * c.zoom, (p.Y - c.bounds.MinY) * c.zoom
+}
+
+// strokeWidth returns the width in pixels, strokes are always at least a pixel wide to stay visible when zoomed out
+func (c *canvas) strokeWidth(width float64) float64 {
+	return max(width*c.zoom, 1)
+}
+
+// paint blends the color into all pixels of the box in pixels, by the coverage of their centers
+func (c *canvas) paint(minX, minY, maxX, maxY float64, col color.RGBA, coverage func(x, y float64) float64) {
+	bounds := c.img.Bounds()
+	fromX, toX := max(int(math.Floor(minX)), bounds.Min.X), min(int(math.Ceil(maxX)), bounds.Max.X)
+	fromY, toY := max(int(math.Floor(minY)), bounds.Min.Y), min(int(math.Ceil(maxY)), bounds.Max.Y)
+
+	for y := fromY; y < toY; y++ {
+		for x := fromX; x < toX; x++ {
+			if cov := coverage(float64(x)+0.5, float64(y)+0.5); cov > 0 {
+				c.blend(x, y, col, min(cov, 1))
+			}
+		}
+	}
+}
+
+func (c *canvas) blend(x, y int, col color.RGBA, coverage float64) {
+	alpha := coverage * float64(col.A) / 0xff
+	i := c.img.PixOffset(x, y)
+	pix := c.img.Pix[i : i+4 : i+4]
+	pix[0] = uint8(float64(col.R)*alpha + float64(pix[0])*(1-alpha) + 0.5)
+	pix[1] = uint8(float64(col.G)*alpha + float64(pix[1])*(1-alpha) + 0.5)
+	pix[2] = uint8(float64(col.B)*alpha + float64(pix[2])*(1-alpha) + 0.5)
+	pix[3] = uint8(0xff*alpha + float64(pix[3])*(1-alpha) + 0.5)
+}
+
+// strokeCoverage returns the coverage of a pixel at the distance from the center line of a stroke
+func strokeCoverage(distance, halfWidth float64) float64 {
+	return halfWidth - distance + 0.5
+}
+
+func (s circleShape) rasterize(c *canvas) {
+	cx, cy := c.pixel(s.Center)
+	radius := s.Radius * c.zoom
+	distance := func(x, y float64) float64 {
+		return math.Hypot(x-cx, y-cy)
+	}
+
+	if s.Fill.A > 0 {
+		c.paint(cx-radius-1, cy-radius-1, cx+radius+1, cy+radius+1, s.Fill, func(x, y float64) float64 {
+			return radius - distance(x, y) + 0.5
+		})
+	}
+
+	if s.Stroke.A > 0 && s.StrokeWidth > 0 {
+		halfWidth := c.strokeWidth(s.StrokeWidth) / 2
+		extent := radius + halfWidth + 1
+		c.paint(cx-extent, cy-extent, cx+extent, cy+extent, s.Stroke, func(x, y float64) float64 {
+			return strokeCoverage(math.Abs(distance(x, y)-radius), halfWidth)
+		})
+	}
+}
+
+func (s lineShape) rasterize(c *canvas) {
+	fromX, fromY := c.pixel(s.From)
+	toX, toY := c.pixel(s.To)
+	halfWidth := c.strokeWidth(s.Width) / 2
+
+	dx, dy := toX-fromX, toY-fromY
+	lengthSquared := dx*dx + dy*dy
+
+	c.paint(min(fromX, toX)-halfWidth-1, min(fromY, toY)-halfWidth-1, max(fromX, toX)+halfWidth+1, max(fromY, toY)+halfWidth+1, s.Color, func(x, y float64) float64 {
+		// Distance to the closest point of the segment
+		t := 0.0
+		if lengthSquared > 0 {
+			t = min(max(((x-fromX)*dx+(y-fromY)*dy)/lengthSquared, 0), 1)
+		}
+		return strokeCoverage(math.Hypot(x-(fromX+t*dx), y-(fromY+t*dy)), halfWidth)
+	})
+}
+
+func (s arcShape) rasterize(c *canvas) {
+	cx, cy := c.pixel(s.Arc.Center)
+	radius := s.Arc.Radius * c.zoom
+	halfWidth := c.strokeWidth(s.Width) / 2
+
+	startX, startY := arcPoint(s.Arc, s.Arc.StartAngle)
+	startX, startY = c.pixel(data.Point{X: startX, Y: startY})
+	endX, endY := arcPoint(s.Arc, s.Arc.EndAngle)
+	endX, endY = c.pixel(data.Point{X: endX, Y: endY})
+
+	extent := radius + halfWidth + 1
+	c.paint(cx-extent, cy-extent, cx+extent, cy+extent, s.Color, func(x, y float64) float64 {
+		// Angle clockwise from the top, moved into the range of the arc
+		angle := math.Atan2(x-cx, cy-y)
+		for angle < s.Arc.StartAngle {
+			angle += 2 * math.Pi
+		}
+
+		if angle <= s.Arc.EndAngle {
+			return strokeCoverage(math.Abs(math.Hypot(x-cx, y-cy)-radius), halfWidth)
+		}
+
+		// Outside the arc the closest point is either end
+		return strokeCoverage(min(math.Hypot(x-startX, y-startY), math.Hypot(x-endX, y-endY)), halfWidth)
+	})
+}
+
+// rasterize does nothing, as sprite sheets are only referenced by their URL. TreePNG rejects scenes with sprites.
+func (s spriteShape) rasterize(*canvas) {}
@@ -0,0 +1,102 @@
+package render
+
+import (
+	"bufio"
+	"errors"
+	"fmt"
+	"image/color"
+	"image/png"
+	"io"
+	"math"
+
+	"github.com/Vilsol/go-pob/data"
+)
+
+// Rendered images are limited to 32 megapixels
+const maxImagePixels = 1 << 25
+
+type Rect struct {
+	MinX float64
+	MinY float64
+	MaxX float64
+	MaxY float64
+}
+
+func (r Rect) Width() float64 {
+	return r.MaxX - r.MinX
+}
+
+func (r Rect) Height() float64 {
+	return r.MaxY - r.MinY
+}
+
+type Options struct {
+	// Output pixels per tree unit, defaults to 0.1
+	Zoom float64
+
+	// Area of the tree to render in tree coordinates, the whole tree if nil
+	Crop *Rect
+
+	// Crop to the allocated nodes, ignored if Crop is set
+	CropToAllocated bool
+
+	// Padding around the rendered area in tree units
+	Padding float64
+
+	// Nodes to outline, like the nodes changed by an import
+	Highlight []int64
+
+	// Outline color of highlighted nodes, defaults to cyan
+	HighlightColor color.RGBA
+
+	// Ascendancy to render, in addition to the ascendancies of allocated nodes
+	Ascendancy data.AscendancyName
+
+	// Render node icons and frames from the sprite sheets of the tree instead of plain shapes. Only supported by SVG,
+	// as the sprite sheets are referenced by their URL.
+	Sprites bool
+}
+
+// TreeSVG renders the tree with the allocated nodes as SVG. The view box of the SVG is in tree coordinates.
+func TreeSVG(w io.Writer, treeVersion *data.TreeVersionData, allocated []int64, options Options) error {
+	s, err := newScene(treeVersion, allocated, options)
+	if err != nil {
+		return err
+	}
+
+	writer := bufio.NewWriter(w)
+	s.writeSVG(writer)
+	if err := writer.Flush(); err != nil {
+		return fmt.Errorf("failed to write svg: %w", err)
+	}
+
+	return nil
+}
+
+// TreePNG renders the tree with the allocated nodes as PNG
+func TreePNG(w io.Writer, treeVersion *data.TreeVersionData, allocated []int64, options Options) error {
+	if options.Sprites {
+		return errors.New("sprites can only be rendered as svg")
+	}
+
+	s, err := newScene(treeVersion, allocated, options)
+	if err != nil {
+		return err
+	}
+
+	width, height := s.size()
+	if width*height > maxImagePixels {
+		return fmt.Errorf("image of %dx%d pixels exceeds the maximum size, lower the zoom or crop the tree", width, height)
+	}
+
+	if err := png.Encode(w, s.rasterize()); err != nil {
+		return fmt.Errorf("failed to encode png: %w", err)
+	}
+
+	return nil
+}
+
+// size returns the size of the rendered image in pixels
+func (s *scene) size() (int, int) {
+	return int(math.Ceil(s.bounds.Width() * s.zoom)), int(math.Ceil(s.bounds.Height() * s.zoom))
+}
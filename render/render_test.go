@@ -0,0 +1,101 @@
+package render
+
+import (
+	"bytes"
+	"context"
+	"image/color"
+	"image/png"
+	"math"
+	"strings"
+	"testing"
+
+	"github.com/MarvinJWendt/testza"
+	"github.com/Vilsol/go-pob-data/poe"
+
+	"github.com/Vilsol/go-pob/cache"
+	"github.com/Vilsol/go-pob/config"
+	"github.com/Vilsol/go-pob/data"
+	"github.com/Vilsol/go-pob/data/raw"
+)
+
+func init() {
+	config.InitLogging(false)
+
+	if err := poe.InitializeAll(context.Background(), raw.LatestVersion, cache.Disk(), nil); err != nil {
+		panic(err)
+	}
+}
+
+// Scion start, a path towards the spell damage wheel, and the Ascendant start with a Passive Point
+var allocated = []int64{58833, 47062, 56153, 35754, 22551, 41534}
+
+func TestTreeSVG(t *testing.T) {
+	treeVersion := data.TreeVersions[data.LatestTreeVersion]
+
+	var buf bytes.Buffer
+	testza.AssertNoError(t, TreeSVG(&buf, treeVersion, allocated, Options{Highlight: []int64{56153}}))
+
+	svg := buf.String()
+	testza.AssertTrue(t, strings.HasPrefix(svg, "<svg "))
+	testza.AssertTrue(t, strings.HasSuffix(svg, "</svg>"))
+	testza.AssertContains(t, svg, `data-node="47062"`)
+	testza.AssertContains(t, svg, svgColor(allocatedNodeFillColor))
+	testza.AssertContains(t, svg, svgColor(defaultHighlightColor))
+	testza.AssertContains(t, svg, " A ", "Connections on orbits should be arcs")
+	testza.AssertNotContains(t, svg, `data-node="18378"`, "Ascendancies of other classes should not be drawn")
+
+	buf.Reset()
+	testza.AssertNoError(t, TreeSVG(&buf, treeVersion, allocated, Options{Ascendancy: data.Occultist}))
+	testza.AssertContains(t, buf.String(), `data-node="18378"`)
+
+	buf.Reset()
+	testza.AssertNoError(t, TreeSVG(&buf, treeVersion, allocated, Options{Sprites: true}))
+	testza.AssertContains(t, buf.String(), "https://web.poecdn.com/image/passive-skill/")
+	testza.AssertContains(t, buf.String(), `data-node="47062"`)
+}
+
+func TestTreePNG(t *testing.T) {
+	treeVersion := data.TreeVersions[data.LatestTreeVersion]
+	tree := treeVersion.Tree()
+
+	var buf bytes.Buffer
+	testza.AssertNoError(t, TreePNG(&buf, treeVersion, allocated, Options{Zoom: 0.05}))
+
+	img, err := png.Decode(&buf)
+	testza.AssertNoError(t, err)
+	testza.AssertEqual(t, int(math.Ceil(float64(tree.MaxX-tree.MinX)*0.05)), img.Bounds().Dx())
+	testza.AssertEqual(t, int(math.Ceil(float64(tree.MaxY-tree.MinY)*0.05)), img.Bounds().Dy())
+
+	testza.AssertEqual(t, backgroundColor, color.RGBAModel.Convert(img.At(0, 0)))
+
+	// The center of allocated nodes is filled
+	layout, _ := treeVersion.NodeLayout(47062)
+	x := int((layout.Position.X - float64(tree.MinX)) * 0.05)
+	y := int((layout.Position.Y - float64(tree.MinY)) * 0.05)
+	testza.AssertEqual(t, allocatedNodeFillColor, color.RGBAModel.Convert(img.At(x, y)))
+
+	testza.AssertNotNil(t, TreePNG(&buf, treeVersion, allocated, Options{Sprites: true}), "Sprites can't be rasterized")
+	testza.AssertNotNil(t, TreePNG(&buf, treeVersion, allocated, Options{Zoom: 10}), "Images are limited in size")
+	testza.AssertNotNil(t, TreePNG(&buf, treeVersion, allocated, Options{Zoom: -1}))
+}
+
+func TestCropToAllocated(t *testing.T) {
+	treeVersion := data.TreeVersions[data.LatestTreeVersion]
+
+	nodes := []int64{47062, 56153}
+	first, _ := treeVersion.NodeLayout(nodes[0])
+	second, _ := treeVersion.NodeLayout(nodes[1])
+
+	var buf bytes.Buffer
+	testza.AssertNoError(t, TreePNG(&buf, treeVersion, nodes, Options{CropToAllocated: true, Padding: 100}))
+
+	img, err := png.Decode(&buf)
+	testza.AssertNoError(t, err)
+
+	width := math.Abs(first.Position.X-second.Position.X) + 2*fallbackNodeRadius + 2*100
+	height := math.Abs(first.Position.Y-second.Position.Y) + 2*fallbackNodeRadius + 2*100
+	testza.AssertEqual(t, int(math.Ceil(width*defaultZoom)), img.Bounds().Dx())
+	testza.AssertEqual(t, int(math.Ceil(height*defaultZoom)), img.Bounds().Dy())
+
+	testza.AssertNotNil(t, TreeSVG(&buf, treeVersion, nodes, Options{Crop: &Rect{MinX: 10, MaxX: 10, MaxY: 10}}), "Empty areas can't be rendered")
+}
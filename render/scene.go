@@ -0,0 +1,426 @@
+package render
+
+import (
+	"bufio"
+	"errors"
+	"image/color"
+	"maps"
+	"math"
+	"slices"
+	"strconv"
+	"strings"
+
+	"github.com/Vilsol/go-pob/data"
+)
+
+const (
+	defaultZoom = 0.1
+
+	// Sizes in tree units
+	fallbackNodeRadius        = 50
+	fallbackAscendancyRadius  = 700
+	connectionWidth           = 12
+	allocatedConnectionWidth  = 20
+	nodeStrokeWidth           = 8
+	classStartStrokeWidth     = 16
+	ascendancyStrokeWidth     = 16
+	highlightStrokeWidth      = 14
+	highlightDistance         = 16
+	groupBackgroundOrbitCount = 3
+)
+
+var (
+	backgroundColor           = color.RGBA{R: 0x0f, G: 0x0f, B: 0x12, A: 0xff}
+	ascendancyFillColor       = color.RGBA{R: 0x1b, G: 0x17, B: 0x12, A: 0xff}
+	ascendancyStrokeColor     = color.RGBA{R: 0x3d, G: 0x34, B: 0x26, A: 0xff}
+	connectionColor           = color.RGBA{R: 0x3d, G: 0x3a, B: 0x33, A: 0xff}
+	allocatedConnectionColor  = color.RGBA{R: 0xd8, G: 0xb3, B: 0x5a, A: 0xff}
+	nodeFillColor             = color.RGBA{R: 0x1c, G: 0x1b, B: 0x19, A: 0xff}
+	nodeStrokeColor           = color.RGBA{R: 0x6d, G: 0x65, B: 0x57, A: 0xff}
+	allocatedNodeFillColor    = color.RGBA{R: 0x6b, G: 0x55, B: 0x26, A: 0xff}
+	allocatedNodeStrokeColor  = color.RGBA{R: 0xe8, G: 0xc3, B: 0x6b, A: 0xff}
+	masteryStrokeColor        = color.RGBA{R: 0x4a, G: 0x5b, B: 0x5b, A: 0xff}
+	allocatedMasteryFillColor = color.RGBA{R: 0x2b, G: 0x4d, B: 0x49, A: 0xff}
+	allocatedMasteryColor     = color.RGBA{R: 0x8f, G: 0xd0, B: 0xc8, A: 0xff}
+	defaultHighlightColor     = color.RGBA{R: 0x3a, G: 0xd6, B: 0xff, A: 0xff}
+)
+
+type shape interface {
+	writeSVG(w *bufio.Writer)
+	rasterize(c *canvas)
+}
+
+// scene is the list of shapes to render, in tree coordinates
+type scene struct {
+	bounds Rect
+	zoom   float64
+	shapes []shape
+}
+
+type circleShape struct {
+	// ID of the drawn node, zero for decorations
+	Node int64
+
+	Center      data.Point
+	Radius      float64
+	Fill        color.RGBA
+	Stroke      color.RGBA
+	StrokeWidth float64
+}
+
+type lineShape struct {
+	From  data.Point
+	To    data.Point
+	Width float64
+	Color color.RGBA
+}
+
+type arcShape struct {
+	Arc   data.Arc
+	Width float64
+	Color color.RGBA
+}
+
+type spriteShape struct {
+	// ID of the drawn node, zero for decorations
+	Node int64
+
+	Center data.Point
+	Sprite spriteRef
+
+	// The sprite is the upper half of the image, mirrored to draw the lower half
+	Half bool
+}
+
+func newScene(treeVersion *data.TreeVersionData, allocated []int64, options Options) (*scene, error) {
+	if options.Zoom < 0 {
+		return nil, errors.New("zoom must not be negative")
+	}
+
+	zoom := options.Zoom
+	if zoom == 0 {
+		zoom = defaultZoom
+	}
+
+	highlightColor := options.HighlightColor
+	if highlightColor == (color.RGBA{}) {
+		highlightColor = defaultHighlightColor
+	}
+
+	tree := treeVersion.Tree()
+	layouts := treeVersion.NodeLayouts()
+
+	isAllocated := make(map[int64]bool, len(allocated))
+	for _, nodeID := range allocated {
+		isAllocated[nodeID] = true
+	}
+
+	// Only the ascendancies of the build are drawn
+	ascendancies := make(map[string]bool)
+	if options.Ascendancy != "" {
+		ascendancies[string(options.Ascendancy)] = true
+	}
+	for _, nodeID := range allocated {
+		if node, ok := tree.Nodes[strconv.FormatInt(nodeID, 10)]; ok && node.AscendancyName != nil {
+			ascendancies[*node.AscendancyName] = true
+		}
+	}
+
+	nodes := make(map[int64]data.Node, len(layouts))
+	for nodeID := range layouts {
+		node := tree.Nodes[strconv.FormatInt(nodeID, 10)]
+		if node.IsProxy != nil && *node.IsProxy {
+			continue
+		}
+
+		if group := tree.Groups[strconv.FormatInt(*node.Group, 10)]; group.IsProxy != nil && *group.IsProxy {
+			continue
+		}
+
+		if node.AscendancyName != nil && !ascendancies[*node.AscendancyName] {
+			continue
+		}
+
+		nodes[nodeID] = node
+	}
+	nodeIDs := slices.Sorted(maps.Keys(nodes))
+
+	bounds, err := sceneBounds(tree, layouts, allocated, options)
+	if err != nil {
+		return nil, err
+	}
+
+	s := &scene{
+		bounds: bounds,
+		zoom:   zoom,
+		shapes: make([]shape, 0, len(nodes)*2),
+	}
+
+	// Ascendancy backgrounds around their start node
+	for _, nodeID := range nodeIDs {
+		node := nodes[nodeID]
+		if node.IsAscendancyStart == nil || !*node.IsAscendancyStart {
+			continue
+		}
+
+		center := layouts[nodeID].Center
+		background, ok := lookupDecoration(tree.Sprites, "Classes"+*node.AscendancyName)
+		if options.Sprites && ok {
+			s.shapes = append(s.shapes, spriteShape{Center: center, Sprite: background})
+			continue
+		}
+
+		radius := float64(fallbackAscendancyRadius)
+		if ok {
+			width, _ := background.size()
+			radius = width / 2
+		}
+
+		s.shapes = append(s.shapes, circleShape{
+			Center:      center,
+			Radius:      radius,
+			Fill:        ascendancyFillColor,
+			Stroke:      ascendancyStrokeColor,
+			StrokeWidth: ascendancyStrokeWidth,
+		})
+	}
+
+	if options.Sprites {
+		s.addGroupBackgrounds(tree, nodes, layouts)
+	}
+
+	// Class start backgrounds, the starting class is lit up
+	radii := make(map[int64]float64, len(nodes))
+	for _, nodeID := range nodeIDs {
+		node := nodes[nodeID]
+		if node.ClassStartIndex == nil {
+			continue
+		}
+
+		inactive, ok := lookupDecoration(tree.Sprites, "PSStartNodeBackgroundInactive")
+		radius := float64(fallbackAscendancyRadius) / 2
+		if ok {
+			width, _ := inactive.size()
+			radius = width / 2
+		}
+		radii[nodeID] = radius
+
+		if options.Sprites {
+			sprite := inactive
+			if isAllocated[nodeID] && int(*node.ClassStartIndex) < len(tree.Classes) {
+				if active, ok := lookupDecoration(tree.Sprites, "center"+strings.ToLower(string(tree.Classes[*node.ClassStartIndex].Name))); ok {
+					sprite = active
+				}
+			}
+
+			if sprite.URL != "" {
+				s.shapes = append(s.shapes, spriteShape{Node: nodeID, Center: layouts[nodeID].Position, Sprite: sprite})
+				continue
+			}
+		}
+
+		stroke := nodeStrokeColor
+		if isAllocated[nodeID] {
+			stroke = allocatedNodeStrokeColor
+		}
+
+		s.shapes = append(s.shapes, circleShape{
+			Node:        nodeID,
+			Center:      layouts[nodeID].Position,
+			Radius:      radius,
+			Stroke:      stroke,
+			StrokeWidth: classStartStrokeWidth,
+		})
+	}
+
+	// Allocated connections are drawn on top of the unallocated ones
+	allocatedConnections := make([]data.Connection, 0)
+	for _, connection := range treeVersion.Connections() {
+		if _, ok := nodes[connection.From]; !ok {
+			continue
+		}
+		if _, ok := nodes[connection.To]; !ok {
+			continue
+		}
+
+		if isAllocated[connection.From] && isAllocated[connection.To] {
+			allocatedConnections = append(allocatedConnections, connection)
+			continue
+		}
+
+		s.addConnection(layouts, connection, connectionWidth, connectionColor)
+	}
+	for _, connection := range allocatedConnections {
+		s.addConnection(layouts, connection, allocatedConnectionWidth, allocatedConnectionColor)
+	}
+
+	for _, nodeID := range nodeIDs {
+		node := nodes[nodeID]
+		if node.ClassStartIndex != nil {
+			continue
+		}
+
+		radii[nodeID] = s.addNode(tree, nodeID, node, layouts[nodeID].Position, isAllocated[nodeID], options.Sprites)
+	}
+
+	for _, nodeID := range options.Highlight {
+		radius, ok := radii[nodeID]
+		if !ok {
+			continue
+		}
+
+		s.shapes = append(s.shapes, circleShape{
+			Center:      layouts[nodeID].Position,
+			Radius:      radius + highlightDistance,
+			Stroke:      highlightColor,
+			StrokeWidth: highlightStrokeWidth,
+		})
+	}
+
+	return s, nil
+}
+
+// sceneBounds returns the rendered area of the tree
+func sceneBounds(tree *data.Tree, layouts map[int64]data.NodeLayout, allocated []int64, options Options) (Rect, error) {
+	bounds := Rect{
+		MinX: float64(tree.MinX),
+		MinY: float64(tree.MinY),
+		MaxX: float64(tree.MaxX),
+		MaxY: float64(tree.MaxY),
+	}
+
+	if options.Crop != nil {
+		bounds = *options.Crop
+	} else if options.CropToAllocated {
+		cropped := Rect{MinX: math.Inf(1), MinY: math.Inf(1), MaxX: math.Inf(-1), MaxY: math.Inf(-1)}
+		for _, nodeID := range allocated {
+			layout, ok := layouts[nodeID]
+			if !ok {
+				continue
+			}
+
+			cropped.MinX = min(cropped.MinX, layout.Position.X-fallbackNodeRadius)
+			cropped.MinY = min(cropped.MinY, layout.Position.Y-fallbackNodeRadius)
+			cropped.MaxX = max(cropped.MaxX, layout.Position.X+fallbackNodeRadius)
+			cropped.MaxY = max(cropped.MaxY, layout.Position.Y+fallbackNodeRadius)
+		}
+
+		if !math.IsInf(cropped.MinX, 1) {
+			bounds = cropped
+		}
+	}
+
+	bounds.MinX -= options.Padding
+	bounds.MinY -= options.Padding
+	bounds.MaxX += options.Padding
+	bounds.MaxY += options.Padding
+
+	if bounds.Width() <= 0 || bounds.Height() <= 0 {
+		return Rect{}, errors.New("rendered area must not be empty")
+	}
+
+	return bounds, nil
+}
+
+// addGroupBackgrounds adds the backgrounds of all groups of the main tree, sized by their largest orbit
+func (s *scene) addGroupBackgrounds(tree *data.Tree, nodes map[int64]data.Node, layouts map[int64]data.NodeLayout) {
+	groups := make(map[int64]data.Point)
+	skipped := make(map[int64]bool)
+	for nodeID, node := range nodes {
+		if node.AscendancyName != nil || node.ClassStartIndex != nil {
+			skipped[*node.Group] = true
+			continue
+		}
+		groups[*node.Group] = layouts[nodeID].Center
+	}
+
+	for _, groupID := range slices.Sorted(maps.Keys(groups)) {
+		if skipped[groupID] {
+			continue
+		}
+
+		group := tree.Groups[strconv.FormatInt(groupID, 10)]
+		maxOrbit := int64(0)
+		for _, orbit := range group.Orbits {
+			maxOrbit = max(maxOrbit, orbit)
+		}
+
+		var key string
+		switch {
+		case maxOrbit == 1:
+			key = "PSGroupBackground1"
+		case maxOrbit == 2:
+			key = "PSGroupBackground2"
+		case maxOrbit == groupBackgroundOrbitCount || len(group.Orbits) > 1:
+			key = "PSGroupBackground3"
+		default:
+			continue
+		}
+
+		if sprite, ok := lookupDecoration(tree.Sprites, key); ok {
+			s.shapes = append(s.shapes, spriteShape{Center: groups[groupID], Sprite: sprite, Half: key == "PSGroupBackground3"})
+		}
+	}
+}
+
+func (s *scene) addConnection(layouts map[int64]data.NodeLayout, connection data.Connection, width float64, c color.RGBA) {
+	if connection.Arc != nil {
+		s.shapes = append(s.shapes, arcShape{Arc: *connection.Arc, Width: width, Color: c})
+		return
+	}
+
+	s.shapes = append(s.shapes, lineShape{
+		From:  layouts[connection.From].Position,
+		To:    layouts[connection.To].Position,
+		Width: width,
+		Color: c,
+	})
+}
+
+// addNode adds the node and returns its radius
+func (s *scene) addNode(tree *data.Tree, nodeID int64, node data.Node, position data.Point, allocated bool, sprites bool) float64 {
+	frameKey := nodeFrame(node, allocated)
+	frame, hasFrame := lookupDecoration(tree.Sprites, frameKey)
+	icon, hasIcon := nodeIcon(tree.Sprites, node, allocated)
+
+	radius := float64(fallbackNodeRadius)
+	switch {
+	case hasFrame:
+		width, _ := frame.size()
+		radius = width / 2
+	case hasIcon:
+		width, _ := icon.size()
+		radius = width / 2
+	}
+
+	if sprites && (hasFrame || hasIcon) {
+		if hasIcon {
+			s.shapes = append(s.shapes, spriteShape{Node: nodeID, Center: position, Sprite: icon})
+		}
+		if hasFrame {
+			s.shapes = append(s.shapes, spriteShape{Node: nodeID, Center: position, Sprite: frame})
+		}
+		return radius
+	}
+
+	fill, stroke := nodeFillColor, nodeStrokeColor
+	switch {
+	case node.IsMastery != nil && *node.IsMastery && allocated:
+		fill, stroke = allocatedMasteryFillColor, allocatedMasteryColor
+	case node.IsMastery != nil && *node.IsMastery:
+		stroke = masteryStrokeColor
+	case allocated:
+		fill, stroke = allocatedNodeFillColor, allocatedNodeStrokeColor
+	}
+
+	s.shapes = append(s.shapes, circleShape{
+		Node:        nodeID,
+		Center:      position,
+		Radius:      radius,
+		Fill:        fill,
+		Stroke:      stroke,
+		StrokeWidth: nodeStrokeWidth,
+	})
+
+	return radius
+}
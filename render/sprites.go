@@ -0,0 +1,131 @@
+package render
+
+import (
+	"strconv"
+
+	"github.com/Vilsol/go-pob/data"
+)
+
+// spriteRef is a single image within a sprite sheet of the tree
+type spriteRef struct {
+	URL         string
+	SheetWidth  int64
+	SheetHeight int64
+	Coord       data.Coord
+
+	// Zoom level the sprite sheet was made for
+	Zoom float64
+}
+
+// size returns the size of the sprite in tree units
+func (r spriteRef) size() (float64, float64) {
+	return float64(r.Coord.W) / r.Zoom, float64(r.Coord.H) / r.Zoom
+}
+
+// lookupSprite returns the sprite with the provided key from the sprite sheet of the highest zoom level
+func lookupSprite(sheets map[string]data.Sprite, key string) (spriteRef, bool) {
+	bestZoom := 0.0
+	var best data.Sprite
+	for zoomKey, sheet := range sheets {
+		zoom, err := strconv.ParseFloat(zoomKey, 64)
+		if err != nil || zoom <= bestZoom {
+			continue
+		}
+
+		bestZoom = zoom
+		best = sheet
+	}
+
+	coord, ok := best.Coords[key]
+	if !ok {
+		return spriteRef{}, false
+	}
+
+	return spriteRef{
+		URL:         best.Filename,
+		SheetWidth:  best.W,
+		SheetHeight: best.H,
+		Coord:       coord,
+		Zoom:        bestZoom,
+	}, true
+}
+
+// lookupDecoration returns the frame or background sprite with the provided key
+func lookupDecoration(sprites data.Sprites, key string) (spriteRef, bool) {
+	for _, sheets := range []map[string]data.Sprite{
+		sprites.Frame,
+		sprites.Ascendancy,
+		sprites.StartNode,
+		sprites.AscendancyBackground,
+		sprites.GroupBackground,
+	} {
+		if ref, ok := lookupSprite(sheets, key); ok {
+			return ref, true
+		}
+	}
+	return spriteRef{}, false
+}
+
+// nodeFrame returns the key of the frame sprite of the node, or an empty key for nodes without a frame
+func nodeFrame(node data.Node, allocated bool) string {
+	state := func(allocatedKey string, unallocatedKey string) string {
+		if allocated {
+			return allocatedKey
+		}
+		return unallocatedKey
+	}
+
+	switch {
+	case node.IsAscendancyStart != nil && *node.IsAscendancyStart:
+		return "AscendancyMiddle"
+	case node.IsMastery != nil && *node.IsMastery:
+		return ""
+	case node.IsKeystone != nil && *node.IsKeystone:
+		return state("KeystoneFrameAllocated", "KeystoneFrameUnallocated")
+	case node.IsNotable != nil && *node.IsNotable && node.AscendancyName != nil:
+		return state("AscendancyFrameLargeAllocated", "AscendancyFrameLargeNormal")
+	case node.IsNotable != nil && *node.IsNotable:
+		return state("NotableFrameAllocated", "NotableFrameUnallocated")
+	case node.IsJewelSocket != nil && *node.IsJewelSocket && node.ExpansionJewel != nil:
+		return state("JewelSocketAltActive", "JewelSocketAltNormal")
+	case node.IsJewelSocket != nil && *node.IsJewelSocket:
+		return state("JewelFrameAllocated", "JewelFrameUnallocated")
+	case node.AscendancyName != nil:
+		return state("AscendancyFrameSmallAllocated", "AscendancyFrameSmallNormal")
+	default:
+		return state("PSSkillFrameActive", "PSSkillFrame")
+	}
+}
+
+// nodeIcon returns the icon sprite of the node, if it has one
+func nodeIcon(sprites data.Sprites, node data.Node, allocated bool) (spriteRef, bool) {
+	if node.IsMastery != nil && *node.IsMastery {
+		if allocated && node.ActiveIcon != nil {
+			return lookupSprite(sprites.MasteryActiveSelected, *node.ActiveIcon)
+		}
+		if node.InactiveIcon != nil {
+			return lookupSprite(sprites.MasteryInactive, *node.InactiveIcon)
+		}
+		return spriteRef{}, false
+	}
+
+	if node.Icon == nil || (node.IsJewelSocket != nil && *node.IsJewelSocket) ||
+		(node.IsAscendancyStart != nil && *node.IsAscendancyStart) {
+		return spriteRef{}, false
+	}
+
+	var active, inactive map[string]data.Sprite
+	switch {
+	case node.IsKeystone != nil && *node.IsKeystone:
+		active, inactive = sprites.KeystoneActive, sprites.KeystoneInactive
+	case node.IsNotable != nil && *node.IsNotable:
+		active, inactive = sprites.NotableActive, sprites.NotableInactive
+	default:
+		active, inactive = sprites.NormalActive, sprites.NormalInactive
+	}
+
+	if allocated {
+		return lookupSprite(active, *node.Icon)
+	}
+	return lookupSprite(inactive, *node.Icon)
+}
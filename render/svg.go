@@ -0,0 +1,110 @@
+package render
+
+import (
+	"bufio"
+	"fmt"
+	"html"
+	"image/color"
+	"math"
+	"strconv"
+
+	"github.com/Vilsol/go-pob/data"
+)
+
+func (s *scene) writeSVG(w *bufio.Writer) {
+	width, height := s.size()
+	fmt.Fprintf(w, `<svg xmlns="http://www.w3.org/2000/svg" width="%d" height="%d" viewBox="%s %s %s %s">`,
+		width, height, svgNumber(s.bounds.MinX), svgNumber(s.bounds.MinY), svgNumber(s.bounds.Width()), svgNumber(s.bounds.Height()))
+	fmt.Fprintf(w, `<rect x="%s" y="%s" width="%s" height="%s" fill="%s"/>`,
+		svgNumber(s.bounds.MinX), svgNumber(s.bounds.MinY), svgNumber(s.bounds.Width()), svgNumber(s.bounds.Height()), svgColor(backgroundColor))
+
+	for _, sh := range s.shapes {
+		sh.writeSVG(w)
+	}
+
+	_, _ = w.WriteString("</svg>")
+}
+
+func (c circleShape) writeSVG(w *bufio.Writer) {
+	fmt.Fprintf(w, `<circle cx="%s" cy="%s" r="%s"`, svgNumber(c.Center.X), svgNumber(c.Center.Y), svgNumber(c.Radius))
+	if c.Node != 0 {
+		fmt.Fprintf(w, ` data-node="%d"`, c.Node)
+	}
+
+	if c.Fill.A > 0 {
+		fmt.Fprintf(w, ` fill="%s"%s`, svgColor(c.Fill), svgOpacity("fill-opacity", c.Fill))
+	} else {
+		_, _ = w.WriteString(` fill="none"`)
+	}
+
+	if c.Stroke.A > 0 && c.StrokeWidth > 0 {
+		fmt.Fprintf(w, ` stroke="%s" stroke-width="%s"%s`, svgColor(c.Stroke), svgNumber(c.StrokeWidth), svgOpacity("stroke-opacity", c.Stroke))
+	}
+
+	_, _ = w.WriteString("/>")
+}
+
+func (l lineShape) writeSVG(w *bufio.Writer) {
+	fmt.Fprintf(w, `<line x1="%s" y1="%s" x2="%s" y2="%s" stroke="%s" stroke-width="%s" stroke-linecap="round"%s/>`,
+		svgNumber(l.From.X), svgNumber(l.From.Y), svgNumber(l.To.X), svgNumber(l.To.Y),
+		svgColor(l.Color), svgNumber(l.Width), svgOpacity("stroke-opacity", l.Color))
+}
+
+func (a arcShape) writeSVG(w *bufio.Writer) {
+	startX, startY := arcPoint(a.Arc, a.Arc.StartAngle)
+	endX, endY := arcPoint(a.Arc, a.Arc.EndAngle)
+
+	// Arcs are at most half a circle and run clockwise
+	fmt.Fprintf(w, `<path d="M %s %s A %s %s 0 0 1 %s %s" fill="none" stroke="%s" stroke-width="%s" stroke-linecap="round"%s/>`,
+		svgNumber(startX), svgNumber(startY), svgNumber(a.Arc.Radius), svgNumber(a.Arc.Radius), svgNumber(endX), svgNumber(endY),
+		svgColor(a.Color), svgNumber(a.Width), svgOpacity("stroke-opacity", a.Color))
+}
+
+func (s spriteShape) writeSVG(w *bufio.Writer) {
+	width, height := s.Sprite.size()
+
+	top := s.Center.Y - height/2
+	if s.Half {
+		top = s.Center.Y - height
+	}
+
+	s.writeImage(w, s.Center.X-width/2, top, width, height)
+
+	if s.Half {
+		fmt.Fprintf(w, `<g transform="matrix(1 0 0 -1 0 %s)">`, svgNumber(2*s.Center.Y))
+		s.writeImage(w, s.Center.X-width/2, top, width, height)
+		_, _ = w.WriteString("</g>")
+	}
+}
+
+// writeImage writes the sprite as nested svg, with the view box cropping the sprite sheet to the sprite
+func (s spriteShape) writeImage(w *bufio.Writer, x float64, y float64, width float64, height float64) {
+	fmt.Fprintf(w, `<svg x="%s" y="%s" width="%s" height="%s" viewBox="%d %d %d %d" preserveAspectRatio="none"`,
+		svgNumber(x), svgNumber(y), svgNumber(width), svgNumber(height),
+		s.Sprite.Coord.X, s.Sprite.Coord.Y, s.Sprite.Coord.W, s.Sprite.Coord.H)
+	if s.Node != 0 {
+		fmt.Fprintf(w, ` data-node="%d"`, s.Node)
+	}
+	fmt.Fprintf(w, `><image href="%s" width="%d" height="%d"/></svg>`, html.EscapeString(s.Sprite.URL), s.Sprite.SheetWidth, s.Sprite.SheetHeight)
+}
+
+// arcPoint returns the point on the arc at the angle, clockwise from the top
+func arcPoint(arc data.Arc, angle float64) (float64, float64) {
+	return arc.Center.X + math.Sin(angle)*arc.Radius, arc.Center.Y - math.Cos(angle)*arc.Radius
+}
+
+// svgNumber formats coordinates with a precision of a tenth of a tree unit
+func svgNumber(value float64) string {
+	return strconv.FormatFloat(math.Round(value*10)/10, 'f', -1, 64)
+}
+
+func svgColor(c color.RGBA) string {
+	return fmt.Sprintf("#%02x%02x%02x", c.R, c.G, c.B)
+}
+
+func svgOpacity(attribute string, c color.RGBA) string {
+	if c.A == 0xff {
+		return ""
+	}
+	return fmt.Sprintf(` %s="%s"`, attribute, strconv.FormatFloat(float64(c.A)/0xff, 'f', 3, 64))
+}
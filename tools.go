@@ -3,12 +3,28 @@
 package main
 
 import (
+	"bytes"
+	"context"
+	"flag"
+	"fmt"
+	"go/format"
+	"io"
 	"os"
+	"slices"
 
+	"github.com/Vilsol/go-pob-data/loader"
+	"github.com/Vilsol/go-pob-data/poe"
+	"github.com/Vilsol/go-pob-data/raw"
+
+	"github.com/Vilsol/go-pob/builds"
+	"github.com/Vilsol/go-pob/cache"
+	"github.com/Vilsol/go-pob/data"
+	raw2 "github.com/Vilsol/go-pob/data/raw"
 	"github.com/Vilsol/go-pob/wasm/exposition"
 )
 
 //go:generate go run tools.go types
+//go:generate go run tools.go timeless
 
 func main() {
 	if len(os.Args) < 2 {
@@ -18,9 +34,67 @@ func main() {
 	switch os.Args[1] {
 	case "types":
 		generateTypes()
+	case "graph":
+		exportGraph(os.Args[2:])
+	case "timeless":
+		generateTimelessJewels()
+	}
+}
+
+// exportGraph writes the passive tree graph, e.g. go run tools.go graph -format graphml -build build.xml -out tree.graphml
+func exportGraph(args []string) {
+	flags := flag.NewFlagSet("graph", flag.ExitOnError)
+	version := flags.String("version", string(data.LatestTreeVersion), "tree version")
+	format := flags.String("format", string(data.GraphFormatDOT), fmt.Sprintf("output format, one of %v", data.GraphFormats))
+	buildPath := flags.String("build", "", "only export the allocated nodes of the Path of Building XML file")
+	out := flags.String("out", "", "output file, stdout if empty")
+	_ = flags.Parse(args)
+
+	treeVersion, ok := data.TreeVersions[data.TreeVersion(*version)]
+	if !ok {
+		exitWithError(fmt.Errorf("unknown tree version: %s", *version))
+	}
+
+	if !slices.Contains(data.GraphFormats, data.GraphFormat(*format)) {
+		exitWithError(fmt.Errorf("unknown graph format %s, expected one of %v", *format, data.GraphFormats))
+	}
+
+	var nodes []int64
+	if *buildPath != "" {
+		rawBuild, err := os.ReadFile(*buildPath)
+		if err != nil {
+			exitWithError(fmt.Errorf("failed to read build: %w", err))
+		}
+
+		build, err := builds.ParseBuild(rawBuild)
+		if err != nil {
+			exitWithError(fmt.Errorf("failed to parse build: %w", err))
+		}
+
+		nodes = build.Build.PassiveNodes
+	}
+
+	var w io.Writer = os.Stdout
+	if *out != "" {
+		f, err := os.Create(*out)
+		if err != nil {
+			exitWithError(fmt.Errorf("failed to create output file: %w", err))
+		}
+		defer f.Close()
+		w = f
+	}
+
+	if err := treeVersion.ExportGraph(nodes).Write(w, data.GraphFormat(*format)); err != nil {
+		exitWithError(fmt.Errorf("failed to write graph: %w", err))
 	}
 }
 
+// exitWithError reports errors caused by the command line arguments without a stack trace
+func exitWithError(err error) {
+	_, _ = fmt.Fprintln(os.Stderr, err)
+	os.Exit(1)
+}
+
 func generateTypes() {
 	e := exposition.Expose()
 	tsFile, jsFile, err := e.Build()
@@ -45,3 +119,138 @@ func generateTypes() {
 		panic(err)
 	}
 }
+
+var timelessJewelTypeNames = map[data.TimelessJewelType]string{
+	data.TimelessJewelGloriousVanity:  "TimelessJewelGloriousVanity",
+	data.TimelessJewelLethalPride:     "TimelessJewelLethalPride",
+	data.TimelessJewelBrutalRestraint: "TimelessJewelBrutalRestraint",
+	data.TimelessJewelMilitantFaith:   "TimelessJewelMilitantFaith",
+	data.TimelessJewelElegantHubris:   "TimelessJewelElegantHubris",
+}
+
+// generateTimelessJewels writes the alternate passives of the timeless jewels from the game data to data/timeless_jewel_table.go
+func generateTimelessJewels() {
+	ctx := context.Background()
+	if err := poe.InitializeAll(ctx, raw2.LatestVersion, cache.Disk(), nil); err != nil {
+		panic(err)
+	}
+
+	treeVersions, err := loader.LoadRaw[*raw.AlternateTreeVersion](ctx, raw2.LatestVersion, "AlternateTreeVersions", nil, cache.Disk())
+	if err != nil {
+		panic(err)
+	}
+
+	// Passive skills aren't part of the regular game data, but are required to tell attribute passives apart
+	passiveSkills, err := loader.LoadRaw[*raw.PassiveSkill](ctx, raw2.LatestVersion, "PassiveSkills", nil, cache.Disk())
+	if err != nil {
+		panic(err)
+	}
+
+	statID := func(key int) string {
+		if key < 0 || key >= len(poe.Stats) {
+			return ""
+		}
+		return poe.Stats[key].ID
+	}
+
+	writeStats := func(b *bytes.Buffer, statKeys []int, ranges [][2]int) {
+		b.WriteString("Stats: []timelessJewelStat{")
+		for i, key := range statKeys {
+			if i >= len(ranges) {
+				break
+			}
+			fmt.Fprintf(b, "{ID: %q, Min: %d, Max: %d},", statID(key), ranges[i][0], ranges[i][1])
+		}
+		b.WriteString("},")
+	}
+
+	b := &bytes.Buffer{}
+	b.WriteString("// Code generated by go run tools.go timeless. DO NOT EDIT.\n\n")
+	b.WriteString("package data\n\n")
+	fmt.Fprintf(b, "// Alternate passives of the timeless jewels of game version %s, in game data order\n", raw2.LatestVersion)
+	b.WriteString("var timelessJewelTables = map[TimelessJewelType]timelessJewelTable{\n")
+
+	for _, version := range treeVersions {
+		name, ok := timelessJewelTypeNames[data.TimelessJewelType(version.Key)]
+		if !ok {
+			continue
+		}
+
+		fmt.Fprintf(b, "%s: {\n", name)
+		fmt.Fprintf(b, "Version: timelessJewelVersion{SmallAttributesReplaced: %t, SmallNormalReplaced: %t, MinimumAdditions: %d, MaximumAdditions: %d, NotableReplacementSpawnWeight: %d},\n",
+			version.AreSmallAttributePassiveSkillsReplaced, version.AreSmallNormalPassiveSkillsReplaced, version.MinimumAdditions, version.MaximumAdditions, version.NotableReplacementSpawnWeight)
+
+		b.WriteString("Skills: []timelessJewelSkill{\n")
+		for _, skill := range poe.AlternatePassiveSkills {
+			if skill.AlternateTreeVersionsKey != version.Key {
+				continue
+			}
+
+			fmt.Fprintf(b, "{Name: %q, PassiveTypes: %#v, SpawnWeight: %d, ConquerorIndex: %d, ConquerorVersion: %d, RandomMin: %d, RandomMax: %d, ",
+				skill.Name, skill.PassiveType, skill.SpawnWeight, skill.ConquerorIndex, skill.ConquerorVersion, skill.RandomMin, skill.RandomMax)
+			writeStats(b, skill.StatsKeys, [][2]int{
+				{skill.Stat1Min, skill.Stat1Max},
+				{skill.Stat2Min, skill.Stat2Max},
+				{skill.Stat3Min, skill.Stat3Max},
+				{skill.Stat4Min, skill.Stat4Max},
+			})
+			b.WriteString("},\n")
+		}
+		b.WriteString("},\n")
+
+		b.WriteString("Additions: []timelessJewelAddition{\n")
+		for _, addition := range poe.AlternatePassiveAdditions {
+			if addition.AlternateTreeVersionsKey != version.Key {
+				continue
+			}
+
+			fmt.Fprintf(b, "{PassiveTypes: %#v, SpawnWeight: %d, ", addition.PassiveType, addition.SpawnWeight)
+			writeStats(b, addition.StatsKeys, [][2]int{
+				{addition.Stat1Min, addition.Stat1Max},
+				{addition.Stat2Min, addition.Stat2Max},
+			})
+			b.WriteString("},\n")
+		}
+		b.WriteString("},\n")
+
+		b.WriteString("},\n")
+	}
+	b.WriteString("}\n\n")
+
+	attributeNodes := make([]int64, 0)
+	for _, passive := range passiveSkills {
+		if len(passive.Stats) == 0 {
+			continue
+		}
+
+		attribute := true
+		for _, stat := range passive.Stats {
+			switch statID(int(stat)) {
+			case "base_strength", "base_dexterity", "base_intelligence":
+			default:
+				attribute = false
+			}
+		}
+
+		if attribute {
+			attributeNodes = append(attributeNodes, passive.Hash)
+		}
+	}
+	slices.Sort(attributeNodes)
+
+	b.WriteString("// Graph IDs of the passives granting nothing but attributes\n")
+	b.WriteString("var timelessJewelAttributeNodes = []int64{\n")
+	for _, nodeID := range attributeNodes {
+		fmt.Fprintf(b, "%d,\n", nodeID)
+	}
+	b.WriteString("}\n")
+
+	source, err := format.Source(b.Bytes())
+	if err != nil {
+		panic(err)
+	}
+
+	if err := os.WriteFile("./data/timeless_jewel_table.go", source, 0644); err != nil {
+		panic(err)
+	}
+}
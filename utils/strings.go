@@ -14,3 +14,28 @@ var capitalEachRegex = regexp.MustCompile(`(^\w|\s\w)`)
 func CapitalEach(s string) string {
 	return capitalEachRegex.ReplaceAllStringFunc(s, strings.ToUpper)
 }
+
+// EditDistance returns the Levenshtein distance between both strings, counting runes
+func EditDistance(a, b string) int {
+	ra, rb := []rune(a), []rune(b)
+
+	previous := make([]int, len(rb)+1)
+	current := make([]int, len(rb)+1)
+	for j := range previous {
+		previous[j] = j
+	}
+
+	for i := 1; i <= len(ra); i++ {
+		current[0] = i
+		for j := 1; j <= len(rb); j++ {
+			cost := 1
+			if ra[i-1] == rb[j-1] {
+				cost = 0
+			}
+			current[j] = min(previous[j]+1, current[j-1]+1, previous[j-1]+cost)
+		}
+		previous, current = current, previous
+	}
+
+	return previous[len(rb)]
+}
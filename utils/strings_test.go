@@ -20,3 +20,25 @@ func TestCapitalEach(t *testing.T) {
 		})
 	}
 }
+
+func TestEditDistance(t *testing.T) {
+	tests := []struct {
+		a    string
+		b    string
+		want int
+	}{
+		{a: "", b: "", want: 0},
+		{a: "life", b: "", want: 4},
+		{a: "life", b: "life", want: 0},
+		{a: "lief", b: "life", want: 2},
+		{a: "dominion", b: "dominoin", want: 2},
+		{a: "kitten", b: "sitting", want: 3},
+	}
+	for _, tt := range tests {
+		t.Run(tt.a+"/"+tt.b, func(t *testing.T) {
+			if got := EditDistance(tt.a, tt.b); got != tt.want {
+				t.Errorf("EditDistance() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
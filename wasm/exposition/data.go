@@ -2,8 +2,20 @@ package exposition
 
 import (
 	"github.com/Vilsol/go-pob-data/poe"
+
+	"github.com/Vilsol/go-pob/data/raw"
 )
 
 func GetStatByIndex(id int) *poe.Stat {
 	return poe.Stats[id]
 }
+
+// DescribeStats returns the tooltip lines of the stats, using the provided stat description file
+func DescribeStats(descriptionFile string, stats map[string]float64) ([]string, error) {
+	descriptions, err := raw.GetStatDescriptions(descriptionFile)
+	if err != nil {
+		return nil, err
+	}
+
+	return descriptions.Describe(stats), nil
+}
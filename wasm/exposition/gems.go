@@ -1,6 +1,12 @@
 package exposition
 
-import "github.com/Vilsol/go-pob-data/poe"
+import (
+	"fmt"
+
+	"github.com/Vilsol/go-pob-data/poe"
+
+	"github.com/Vilsol/go-pob/calculator"
+)
 
 type GemPart struct {
 	Name        string
@@ -17,12 +23,13 @@ const (
 )
 
 type SkillGem struct {
-	MaxLevel int
-	ID       string
-	GemType  GemType
-	Base     GemPart
-	Vaal     GemPart
-	Support  bool
+	MaxLevel  int
+	ID        string
+	GemType   GemType
+	Base      GemPart
+	Vaal      GemPart
+	Support   bool
+	Qualities []calculator.GemQuality
 }
 
 func (g SkillGem) CalculateStuff() {
@@ -47,21 +54,23 @@ func GetSkillGems() []SkillGem {
 			}
 
 			outGem := SkillGem{
-				MaxLevel: len(grantedEffect.Levels()),
-				ID:       baseType.ID,
-				GemType:  GemTypeNone,
-				Support:  grantedEffect.IsSupport,
+				MaxLevel:  len(grantedEffect.Levels()),
+				ID:        baseType.ID,
+				GemType:   GemTypeNone,
+				Support:   grantedEffect.IsSupport,
+				Qualities: calculator.GemQualities(grantedEffect, baseType.Name),
 				Base: GemPart{
 					Name:        baseType.Name,
 					Description: description,
 				},
 			}
 
-			if gem.Str > gem.Dex && gem.Str > gem.Int {
+			switch calculator.SkillGemColour(gem) {
+			case calculator.GemColourRed:
 				outGem.GemType = GemTypeStrength
-			} else if gem.Dex > gem.Int && gem.Dex > gem.Str {
+			case calculator.GemColourGreen:
 				outGem.GemType = GemTypeDexterity
-			} else if gem.Int > gem.Dex && gem.Int > gem.Str {
+			case calculator.GemColourBlue:
 				outGem.GemType = GemTypeIntelligence
 			}
 
@@ -85,3 +94,26 @@ func GetSkillGems() []SkillGem {
 
 	return skillGemCache
 }
+
+// GetGemStatDescriptions returns the tooltip lines of the gem with the provided ID at the provided level and quality
+func GetGemStatDescriptions(id string, level int, quality int, qualityID string) ([]string, error) {
+	for _, gem := range poe.SkillGems {
+		if gem.GetBaseItemType().ID == id {
+			return calculator.DescribeGrantedEffect(gem.GetGrantedEffect(), level, quality, qualityID)
+		}
+	}
+
+	return nil, fmt.Errorf("unknown skill gem: %s", id)
+}
+
+func SearchGems(filter calculator.GemFilter) []calculator.GemCatalogueEntry {
+	return calculator.SearchGems(filter)
+}
+
+func GetCompatibleSupports(activeGemID string) ([]calculator.GemCatalogueEntry, error) {
+	return calculator.CompatibleSupports(activeGemID)
+}
+
+func GetSkillPartNames(grantedEffectID string) []string {
+	return calculator.SkillPartNames(grantedEffectID)
+}
@@ -10,6 +10,7 @@ import (
 	"github.com/Vilsol/go-pob/calculator"
 	"github.com/Vilsol/go-pob/config"
 	"github.com/Vilsol/go-pob/data/raw"
+	"github.com/Vilsol/go-pob/items"
 	"github.com/Vilsol/go-pob/pob"
 )
 
@@ -17,6 +18,9 @@ func Expose() *crystalline.Exposer {
 	e := crystalline.NewExposer("go-pob")
 
 	crystalline.MarkPromise("calculator.Calculator", "BuildOutput")
+	crystalline.MarkPromise("calculator.Calculator", "CalculateNodePower")
+	crystalline.MarkPromise("calculator.Calculator", "OptimizeTree")
+	crystalline.MarkPromise("calculator.Calculator", "RankSupports")
 
 	crystalline.MarkIgnored("msgp.Reader", "ReadComplex64")
 	crystalline.MarkIgnored("msgp.Reader", "ReadComplex128")
@@ -30,15 +34,28 @@ func Expose() *crystalline.Exposer {
 	e.ExposeFuncOrPanic(builds.ParseBuild)
 	e.ExposeFuncOrPanic(builds.ParseBuildStr)
 
+	e.ExposeFuncOrPanic(items.ParseItem)
+
 	e.ExposeFuncOrPanic(calculator.NewCalculator)
 	e.ExposeFuncOrPanicPromise(raw.InitializeAll)
 	e.ExposeFuncOrPanic(cache.InitializeDiskCache)
 	e.ExposeFuncOrPanic(config.InitLogging)
 
 	e.ExposeFuncOrPanic(GetSkillGems)
+	e.ExposeFuncOrPanic(GetGemStatDescriptions)
+	e.ExposeFuncOrPanic(SearchGems)
+	e.ExposeFuncOrPanic(GetCompatibleSupports)
+	e.ExposeFuncOrPanic(GetSkillPartNames)
 	e.ExposeFuncOrPanicPromise(GetRawTree)
 	e.ExposeFuncOrPanic(GetStatByIndex)
+	e.ExposeFuncOrPanic(DescribeStats)
 	e.ExposeFuncOrPanic(CalculateAllocationPaths)
+	e.ExposeFuncOrPanic(CalculateSteinerTree)
+	e.ExposeFuncOrPanic(GetNodeLayouts)
+	e.ExposeFuncOrPanic(GetTreeConnections)
+	e.ExposeFuncOrPanic(DiffTreeVersions)
+	e.ExposeFuncOrPanic(ExportTreeGraph)
+	e.ExposeFuncOrPanic(SearchTimelessSeeds)
 
 	info, _ := debug.ReadBuildInfo()
 	e.ExposeOrPanic(info, "pob", "BuildInfo")
@@ -1,13 +1,47 @@
 package exposition
 
-import "github.com/Vilsol/go-pob/data"
+import (
+	"strings"
+
+	"github.com/Vilsol/go-pob/data"
+)
 
 func GetRawTree(version data.TreeVersion) []byte {
 	return data.TreeVersions[version].RawTree()
 }
 
-func CalculateAllocationPaths(version data.TreeVersion, activeNodes []int64, rootNodes []int64) map[int64]int64 {
-	return data.TreeVersions[version].CalculateAllocationPaths(activeNodes, rootNodes)
+// CalculateAllocationPaths returns the next hops towards the tree of the active nodes. Graph extensions can't be
+// converted from JS, so the extra edges of the spec are passed as plain edges instead, see PassiveSpec.GraphEdges.
+func CalculateAllocationPaths(version data.TreeVersion, activeNodes []int64, rootNodes []int64, extensionEdges [][2]int64) map[int64]int64 {
+	return data.TreeVersions[version].CalculateAllocationPaths(activeNodes, rootNodes, &data.EdgeExtension{Edges: extensionEdges})
+}
+
+func CalculateSteinerTree(version data.TreeVersion, activeNodes []int64, rootNodes []int64, targetNodes []int64, extensionEdges [][2]int64) *data.SteinerTree {
+	return data.TreeVersions[version].CalculateSteinerTree(activeNodes, rootNodes, targetNodes, &data.EdgeExtension{Edges: extensionEdges})
+}
+
+func GetNodeLayouts(version data.TreeVersion) map[int64]data.NodeLayout {
+	return data.TreeVersions[version].NodeLayouts()
+}
+
+func GetTreeConnections(version data.TreeVersion) []data.Connection {
+	return data.TreeVersions[version].Connections()
+}
+
+func DiffTreeVersions(from data.TreeVersion, to data.TreeVersion) *data.TreeDiff {
+	return data.TreeVersions[from].Diff(data.TreeVersions[to])
+}
+
+func ExportTreeGraph(version data.TreeVersion, format data.GraphFormat, nodes []int64) (string, error) {
+	var sb strings.Builder
+	if err := data.TreeVersions[version].ExportGraph(nodes).Write(&sb, format); err != nil {
+		return "", err
+	}
+	return sb.String(), nil
+}
+
+func SearchTimelessSeeds(version data.TreeVersion, socketID int64, jewelType data.TimelessJewelType, notable string) ([]data.TimelessSeedMatch, error) {
+	return data.TreeVersions[version].SearchTimelessSeeds(socketID, jewelType, notable)
 }
 
 // TODO: Need some algorithm that figures out which nodes would be disconnected and therefore removed if the target node is removed
@@ -0,0 +1,52 @@
+package exposition
+
+import (
+	"context"
+	"os"
+	"slices"
+	"testing"
+
+	"github.com/MarvinJWendt/testza"
+	"github.com/Vilsol/go-pob-data/poe"
+
+	"github.com/Vilsol/go-pob/builds"
+	"github.com/Vilsol/go-pob/cache"
+	"github.com/Vilsol/go-pob/calculator"
+	"github.com/Vilsol/go-pob/data"
+	"github.com/Vilsol/go-pob/data/raw"
+)
+
+func TestCalculateAllocationPathsExtensionEdges(t *testing.T) {
+	testza.AssertNoError(t, poe.InitializeAll(context.Background(), raw.LatestVersion, cache.Disk(), nil))
+
+	file, err := os.ReadFile("../../testdata/builds/Fireball-full.xml")
+	testza.AssertNoError(t, err)
+
+	build, err := builds.ParseBuild(file)
+	testza.AssertNoError(t, err)
+
+	// The edges take the same way through JS as in the worker: out of the calculated spec and back in as arguments
+	out := calculator.NewCalculator(*build).BuildOutput(calculator.OutputModeMain)
+	edges := out.Spec.GraphEdges()
+	testza.AssertNotZero(t, len(edges))
+
+	var target int64
+	for id := range out.Spec.SubGraphs["32763"].Nodes {
+		if !slices.Contains(build.Build.PassiveNodes, id) {
+			target = id
+			break
+		}
+	}
+	testza.AssertNotZero(t, target, "The cluster jewel should have unallocated nodes")
+
+	roots := data.TreeVersions[data.LatestTreeVersion].RootNodes(out.Spec.ClassName, out.Spec.AscendancyName)
+
+	paths := CalculateAllocationPaths(data.LatestTreeVersion, build.Build.PassiveNodes, roots, edges)
+	testza.AssertNotZero(t, paths[target], "Subgraph nodes should be reachable through the edges of the spec")
+
+	paths = CalculateAllocationPaths(data.LatestTreeVersion, build.Build.PassiveNodes, roots, nil)
+	testza.AssertZero(t, paths[target])
+
+	steiner := CalculateSteinerTree(data.LatestTreeVersion, build.Build.PassiveNodes, roots, []int64{target}, edges)
+	testza.AssertEqual(t, []int64{}, steiner.Unreachable)
+}